@@ -0,0 +1,336 @@
+// archive.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+// Package archive provides access to files stored in zip and tar archives.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// Separator separates the path of an archive and the name of an entry in IDs such as "archive.zip!/dir/image.png".
+const Separator = "!/"
+
+// maxBufferedSize is the maximum size of zip archives read into memory since they don't support random access.
+const maxBufferedSize = 64 << 20
+
+// IsArchive returns true if the given file name has an extension of supported archives.
+func IsArchive(name string) bool {
+	return format(name) != ""
+}
+
+// Join returns an ID of the entry stored in the given archive.
+func Join(archive, entry string) string {
+	return archive + Separator + entry
+}
+
+// Split splits the given ID into the path of an archive and the name of an entry.
+// ok is false if the ID doesn't point to a file in an archive.
+func Split(id string) (archive, entry string, ok bool) {
+	for i := strings.Index(id, Separator); i != -1; {
+		if IsArchive(id[:i]) {
+			return id[:i], id[i+len(Separator):], true
+		}
+
+		next := strings.Index(id[i+len(Separator):], Separator)
+		if next == -1 {
+			break
+		}
+		i += len(Separator) + next
+	}
+	return "", "", false
+}
+
+// WalkFunc is the type of the function called by Walk for each regular file stored in an archive.
+// r reads the contents of the entry and is valid only until the function returns.
+type WalkFunc func(entry string, info fs.FileInfo, r io.Reader) error
+
+// Walk calls fn for each regular file stored in the archive of the given name in fsys.
+func Walk(fsys fs.FS, name string, fn WalkFunc) error {
+	switch format(name) {
+	case zipFormat:
+		return walkZip(fsys, name, fn)
+	case tarFormat, tarGzFormat:
+		return walkTar(fsys, name, fn)
+	default:
+		return errors.New("archive format not supported")
+	}
+}
+
+// List returns information of regular files stored in the given archive, keyed by their names, without reading
+// their contents.
+func List(fsys fs.FS, name string) (map[string]fs.FileInfo, error) {
+	switch format(name) {
+	case zipFormat:
+		r, closer, err := newZipReader(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		res := make(map[string]fs.FileInfo)
+		for _, f := range r.File {
+			if f.Mode().IsRegular() {
+				res[clean(f.Name)] = f.FileInfo()
+			}
+		}
+		return res, closer.Close()
+
+	case tarFormat, tarGzFormat:
+		tr, closer, err := newTarReader(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		res := make(map[string]fs.FileInfo)
+		for {
+			h, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				return res, closer.Close()
+			} else if err != nil {
+				return nil, errors.Join(err, closer.Close())
+			}
+			if h.Typeflag == tar.TypeReg {
+				res[clean(h.Name)] = h.FileInfo()
+			}
+		}
+
+	default:
+		return nil, errors.New("archive format not supported")
+	}
+}
+
+// Open opens the given entry stored in the archive of the given name in fsys.
+func Open(fsys fs.FS, name, entry string) (fs.File, error) {
+	entry = clean(entry)
+	switch format(name) {
+	case zipFormat:
+		return openZip(fsys, name, entry)
+	case tarFormat, tarGzFormat:
+		return openTar(fsys, name, entry)
+	default:
+		return nil, errors.New("archive format not supported")
+	}
+}
+
+const (
+	zipFormat   = "zip"
+	tarFormat   = "tar"
+	tarGzFormat = "tar.gz"
+)
+
+func format(name string) string {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return zipFormat
+	case strings.HasSuffix(name, ".tar"):
+		return tarFormat
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return tarGzFormat
+	default:
+		return ""
+	}
+}
+
+// clean normalises entry names so that "./dir/a.png" and "dir/a.png" point to the same entry.
+func clean(entry string) string {
+	return strings.TrimPrefix(path.Clean("/"+entry), "/")
+}
+
+// newZipReader opens a zip archive. Archives which don't support random access are read into memory unless they are
+// larger than maxBufferedSize.
+func newZipReader(fsys fs.FS, name string) (*zip.Reader, io.Closer, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, errors.Join(err, f.Close())
+	}
+
+	var r io.ReaderAt
+	var size int64
+	if ra, ok := f.(io.ReaderAt); ok {
+		r, size = ra, info.Size()
+	} else {
+		if info.Size() > maxBufferedSize {
+			err = fmt.Errorf("%v is too large to be read without random access: %v bytes", name, info.Size())
+			return nil, nil, errors.Join(err, f.Close())
+		}
+		data, err := io.ReadAll(io.LimitReader(f, maxBufferedSize+1))
+		if err != nil {
+			return nil, nil, errors.Join(err, f.Close())
+		}
+		if len(data) > maxBufferedSize {
+			err = fmt.Errorf(
+				"%v is too large to be read without random access: more than %v bytes", name, maxBufferedSize)
+			return nil, nil, errors.Join(err, f.Close())
+		}
+		r, size = bytes.NewReader(data), int64(len(data))
+	}
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, nil, errors.Join(err, f.Close())
+	}
+	return zr, f, nil
+}
+
+func walkZip(fsys fs.FS, name string, fn WalkFunc) (err error) {
+	r, closer, err := newZipReader(fsys, name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, closer.Close())
+	}()
+
+	for _, f := range r.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+
+		err = func() (err error) {
+			rc, err := f.Open()
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, rc.Close())
+			}()
+
+			return fn(clean(f.Name), f.FileInfo(), rc)
+		}()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func openZip(fsys fs.FS, name, entry string) (_ fs.File, err error) {
+	r, closer, err := newZipReader(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range r.File {
+		if clean(f.Name) != entry || !f.Mode().IsRegular() {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, errors.Join(err, closer.Close())
+		}
+		return &file{Reader: rc, info: f.FileInfo(), closers: []io.Closer{rc, closer}}, nil
+	}
+
+	if err = closer.Close(); err != nil {
+		return nil, err
+	}
+	return nil, notExist(name, entry)
+}
+
+func walkTar(fsys fs.FS, name string, fn WalkFunc) (err error) {
+	tr, closer, err := newTarReader(fsys, name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, closer.Close())
+	}()
+
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if err = fn(clean(h.Name), h.FileInfo(), tr); err != nil {
+			return err
+		}
+	}
+}
+
+func openTar(fsys fs.FS, name, entry string) (_ fs.File, err error) {
+	tr, closer, err := newTarReader(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			if err = closer.Close(); err != nil {
+				return nil, err
+			}
+			return nil, notExist(name, entry)
+		} else if err != nil {
+			return nil, errors.Join(err, closer.Close())
+		}
+		if h.Typeflag != tar.TypeReg || clean(h.Name) != entry {
+			continue
+		}
+
+		return &file{Reader: tr, info: h.FileInfo(), closers: []io.Closer{closer}}, nil
+	}
+}
+
+func newTarReader(fsys fs.FS, name string) (*tar.Reader, io.Closer, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if format(name) != tarGzFormat {
+		return tar.NewReader(f), f, nil
+	}
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, nil, errors.Join(err, f.Close())
+	}
+	return tar.NewReader(gr), &file{closers: []io.Closer{gr, f}}, nil
+}
+
+func notExist(name, entry string) error {
+	return &fs.PathError{Op: "open", Path: Join(name, entry), Err: fs.ErrNotExist}
+}
+
+// file is an entry opened from an archive. Closing it closes all the given closers in order.
+type file struct {
+	io.Reader
+	info    fs.FileInfo
+	closers []io.Closer
+}
+
+func (f *file) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *file) Close() (err error) {
+	for _, c := range f.closers {
+		err = errors.Join(err, c.Close())
+	}
+	return err
+}
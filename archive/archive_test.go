@@ -0,0 +1,287 @@
+// archive_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+var testEntries = map[string]string{
+	"a.png":       "image a",
+	"dir/b.webp":  "image b",
+	"dir/c/d.txt": "text d",
+}
+
+func createZip(t *testing.T, name string) {
+	t.Helper()
+
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for k, v := range testEntries {
+		e, err := w.Create(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = io.WriteString(e, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func createTar(t *testing.T, name string, compress bool) {
+	t.Helper()
+
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var out io.Writer = f
+	if compress {
+		gw := gzip.NewWriter(f)
+		defer gw.Close()
+		out = gw
+	}
+
+	w := tar.NewWriter(out)
+	err = w.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: "./dir/", Mode: 0755})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range testEntries {
+		err = w.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     "./" + k,
+			Mode:     0644,
+			Size:     int64(len(v)),
+			ModTime:  time.Now(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = io.WriteString(w, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testArchives(t *testing.T) (fs.FS, []string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	res := []string{"test.zip", "test.tar", "test.tar.gz"}
+	createZip(t, filepath.Join(dir, res[0]))
+	createTar(t, filepath.Join(dir, res[1]), false)
+	createTar(t, filepath.Join(dir, res[2]), true)
+	return os.DirFS(dir), res
+}
+
+func TestWalk(t *testing.T) {
+	fsys, names := testArchives(t)
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			res := make(map[string]string)
+			err := Walk(fsys, name, func(entry string, info fs.FileInfo, r io.Reader) error {
+				data, err := io.ReadAll(r)
+				if err != nil {
+					return err
+				}
+				if info.Size() != int64(len(data)) {
+					t.Errorf("expect %v, got %v", len(data), info.Size())
+				}
+				res[entry] = string(data)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(res) != len(testEntries) {
+				t.Errorf("expect %v, got %v", testEntries, res)
+			}
+			for k, v := range testEntries {
+				if res[k] != v {
+					t.Errorf("expect %q, got %q", v, res[k])
+				}
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	var expect []string
+	for k := range testEntries {
+		expect = append(expect, k)
+	}
+	sort.Strings(expect)
+
+	fsys, names := testArchives(t)
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			entries, err := List(fsys, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var res []string
+			for k := range entries {
+				res = append(res, k)
+			}
+			sort.Strings(res)
+			if len(res) != len(expect) {
+				t.Fatalf("expect %v, got %v", expect, res)
+			}
+			for i := range expect {
+				if res[i] != expect[i] {
+					t.Errorf("expect %v, got %v", expect[i], res[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	fsys, names := testArchives(t)
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range testEntries {
+				r, err := Open(fsys, name, k)
+				if err != nil {
+					t.Fatal(err)
+				}
+				info, err := r.Stat()
+				if err != nil {
+					t.Fatal(err)
+				}
+				data, err := io.ReadAll(r)
+				if err != nil {
+					t.Error(err)
+				}
+				if err = r.Close(); err != nil {
+					t.Error(err)
+				}
+				if string(data) != v {
+					t.Errorf("expect %q, got %q", v, data)
+				}
+				if info.Size() != int64(len(v)) {
+					t.Errorf("expect %v, got %v", len(v), info.Size())
+				}
+			}
+
+			_, err := Open(fsys, name, "not-exist.png")
+			if !os.IsNotExist(err) {
+				t.Errorf("expect a not exist error, got %v", err)
+			}
+		})
+	}
+}
+
+// streamFile is a file without random access, e.g. an object in a remote storage, which reports the given size.
+type streamFile struct {
+	fs.File
+	size int64
+}
+
+func (f *streamFile) Stat() (fs.FileInfo, error) {
+	info, err := f.File.Stat()
+	if err != nil || f.size == 0 {
+		return info, err
+	}
+	return &sizedInfo{FileInfo: info, size: f.size}, nil
+}
+
+type sizedInfo struct {
+	fs.FileInfo
+	size int64
+}
+
+func (i *sizedInfo) Size() int64 {
+	return i.size
+}
+
+// streamFS opens files as streamFile.
+type streamFS struct {
+	fs.FS
+	size int64
+}
+
+func (s streamFS) Open(name string) (fs.File, error) {
+	f, err := s.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &streamFile{File: f, size: s.size}, nil
+}
+
+func TestOpenZipWithoutRandomAccess(t *testing.T) {
+	dir := t.TempDir()
+	createZip(t, filepath.Join(dir, "test.zip"))
+
+	f, err := Open(streamFS{FS: os.DirFS(dir)}, "test.zip", "a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Error(err)
+	}
+	if err = f.Close(); err != nil {
+		t.Error(err)
+	}
+	if string(data) != testEntries["a.png"] {
+		t.Errorf("expect %q, got %q", testEntries["a.png"], data)
+	}
+
+	// large archives are refused instead of being read into memory.
+	if _, err = Open(streamFS{FS: os.DirFS(dir), size: maxBufferedSize + 1}, "test.zip", "a.png"); err == nil {
+		t.Error("expect a large archive without random access is refused")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	cases := []struct {
+		id      string
+		archive string
+		entry   string
+		ok      bool
+	}{
+		{id: "/root/a.zip!/b.png", archive: "/root/a.zip", entry: "b.png", ok: true},
+		{id: "/root/a.tar.gz!/dir/b.png", archive: "/root/a.tar.gz", entry: "dir/b.png", ok: true},
+		{id: "/root/a!/b.zip!/c.png", archive: "/root/a!/b.zip", entry: "c.png", ok: true},
+		{id: "/root/a.png"},
+		{id: "/root/a!/b.png"},
+	}
+	for _, c := range cases {
+		t.Run(c.id, func(t *testing.T) {
+			name, entry, ok := Split(c.id)
+			if name != c.archive || entry != c.entry || ok != c.ok {
+				t.Errorf("expect (%v, %v, %v), got (%v, %v, %v)", c.archive, c.entry, c.ok, name, entry, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// signed.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Purposes of signed values, which are signed together so that a value can't be used for another purpose.
+const (
+	sessionPurpose = "session"
+	sharePurpose   = "share"
+)
+
+// Principal is an authenticated client. Share is set if the client is authenticated by a share link, which grants
+// read access to the shared image or search only.
+type Principal struct {
+	User  string
+	Share *Share
+}
+
+// Share is the target of a share link, which is either one image or the images matching one search.
+type Share struct {
+	// Image is the ID of the shared image.
+	Image string `json:"image,omitempty"`
+	// Search is the query string of the shared search.
+	Search string `json:"search,omitempty"`
+	// User is the user who created the share link.
+	User    string    `json:"user"`
+	Expires time.Time `json:"expires"`
+}
+
+type session struct {
+	User string `json:"user"`
+	// Password is a fingerprint of the password hash so that changing the password invalidates sessions.
+	Password string    `json:"password"`
+	Expires  time.Time `json:"expires"`
+}
+
+// NewSession returns a signed session value of the given user, which expires after the given duration.
+func (s *Store) NewSession(name string, ttl time.Duration) (string, error) {
+	u := s.user(name)
+	if u == nil {
+		return "", fmt.Errorf("%w: %v", ErrUserNotFound, name)
+	}
+	return s.sign(sessionPurpose, &session{
+		User:     name,
+		Password: fingerprint(u.PasswordHash),
+		Expires:  time.Now().Add(ttl),
+	})
+}
+
+// VerifySession returns the user of the given session value. Sessions are invalid after they expire, or the user
+// changes the password or is removed.
+func (s *Store) VerifySession(v string) (string, error) {
+	var ss session
+	if err := s.verify(sessionPurpose, v, &ss); err != nil {
+		return "", err
+	}
+	if time.Now().After(ss.Expires) {
+		return "", ErrUnauthorized
+	}
+	u := s.user(ss.User)
+	if u == nil || !hmac.Equal([]byte(fingerprint(u.PasswordHash)), []byte(ss.Password)) {
+		return "", ErrUnauthorized
+	}
+	return ss.User, nil
+}
+
+// NewShare returns a signed share link token of the given share. Either the image or the search must be given.
+func (s *Store) NewShare(share *Share) (string, error) {
+	if (share.Image == "") == (share.Search == "") {
+		return "", errors.New("either an image or a search is required")
+	}
+	if s.user(share.User) == nil {
+		return "", fmt.Errorf("%w: %v", ErrUserNotFound, share.User)
+	}
+	return s.sign(sharePurpose, share)
+}
+
+// VerifyShare returns the share of the given token. Shares are invalid after they expire or the users who created
+// them are removed.
+func (s *Store) VerifyShare(v string) (*Share, error) {
+	var share Share
+	if err := s.verify(sharePurpose, v, &share); err != nil {
+		return nil, err
+	}
+	if time.Now().After(share.Expires) || s.user(share.User) == nil {
+		return nil, ErrUnauthorized
+	}
+	return &share, nil
+}
+
+// sign encodes the given value in JSON, and returns it with the signature, which are encoded in base64.
+func (s *Store) sign(purpose string, v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(s.mac(purpose, payload)), nil
+}
+
+// verify checks the signature of the given signed value, and decodes it to dst.
+func (s *Store) verify(purpose, v string, dst any) error {
+	payload, sig, ok := strings.Cut(v, ".")
+	if !ok {
+		return ErrUnauthorized
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, s.mac(purpose, payload)) {
+		return ErrUnauthorized
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil || json.Unmarshal(b, dst) != nil {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+func (s *Store) mac(purpose, payload string) []byte {
+	h := hmac.New(sha256.New, s.key())
+	h.Write([]byte(purpose))
+	h.Write([]byte{0})
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
+
+// fingerprint returns a short hash of the given password hash.
+func fingerprint(hash []byte) string {
+	h := sha256.Sum256(hash)
+	return hex.EncodeToString(h[:8])
+}
@@ -0,0 +1,134 @@
+// signed_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSession(t *testing.T) {
+	s, name := openTestStore(t)
+	if err := s.AddUser("alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.NewSession("bob", time.Hour); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expect %v, got %v", ErrUserNotFound, err)
+	}
+
+	v, err := s.NewSession("alice", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user, err := s.VerifySession(v); err != nil || user != "alice" {
+		t.Errorf("expect alice, got %v (%v)", user, err)
+	}
+
+	expired, err := s.NewSession("alice", -time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, sig, _ := strings.Cut(v, ".")
+	other, err := OpenStore(name + ".other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	share, err := s.NewShare(&Share{Image: "a.png", User: "alice", Expires: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the same session signed with another key.
+	forged, err := other.sign(sessionPurpose, &session{
+		User:     "alice",
+		Password: fingerprint(s.user("alice").PasswordHash),
+		Expires:  time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := map[string]string{
+		"expired":             expired,
+		"no signature":        payload,
+		"tampered payload":    "e30." + sig,
+		"tampered signature":  payload + ".AAAA",
+		"signed by other key": forged,
+		"share link":          share,
+	}
+	for k, v := range invalid {
+		if _, err := s.VerifySession(v); !errors.Is(err, ErrUnauthorized) {
+			t.Errorf("%v: expect %v, got %v", k, ErrUnauthorized, err)
+		}
+	}
+
+	// changing the password invalidates sessions.
+	if err = s.SetPassword("alice", "changed"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.VerifySession(v); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expect %v, got %v", ErrUnauthorized, err)
+	}
+}
+
+func TestShare(t *testing.T) {
+	s, _ := openTestStore(t)
+	if err := s.AddUser("alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	expires := time.Now().Add(time.Hour)
+
+	invalid := []*Share{
+		{User: "alice", Expires: expires},
+		{Image: "a.png", Search: "cat", User: "alice", Expires: expires},
+		{Image: "a.png", User: "bob", Expires: expires},
+	}
+	for _, v := range invalid {
+		if _, err := s.NewShare(v); err == nil {
+			t.Errorf("expect %+v is rejected", v)
+		}
+	}
+
+	for _, v := range []*Share{
+		{Image: "dir/a.png", User: "alice", Expires: expires},
+		{Search: "checkpoint:model-a cat", User: "alice", Expires: expires},
+	} {
+		token, err := s.NewShare(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := s.VerifyShare(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Image != v.Image || res.Search != v.Search || res.User != v.User || !res.Expires.Equal(v.Expires) {
+			t.Errorf("expect %+v, got %+v", v, res)
+		}
+	}
+
+	token, err := s.NewShare(&Share{Image: "a.png", User: "alice", Expires: time.Now().Add(-time.Second)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.VerifyShare(token); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expect an expired link is rejected, got %v", err)
+	}
+
+	// removing the user revokes the links.
+	token, err = s.NewShare(&Share{Image: "a.png", User: "alice", Expires: expires})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.RemoveUser("alice"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.VerifyShare(token); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expect %v, got %v", ErrUnauthorized, err)
+	}
+}
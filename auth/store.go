@@ -0,0 +1,406 @@
+// store.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+// Package auth manages users of the server, and authenticates them with passwords, API tokens, session cookies and
+// share links.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// keySize is the size of the key signing session cookies and share links.
+	keySize = 32
+	// tokenSize is the size of random bytes of API tokens.
+	tokenSize = 32
+	// tokenPrefix is prefixed to API tokens so that they can be found in scripts and logs.
+	tokenPrefix = "sdv_"
+	// tokenIDSize is the number of characters of IDs of API tokens.
+	tokenIDSize = 8
+	// refreshInterval is the interval of checking whether the file is modified.
+	refreshInterval = 2 * time.Second
+)
+
+var (
+	// ErrUnauthorized is returned when credentials are wrong, expired or revoked.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrUserExists is returned when adding a user whose name is already used.
+	ErrUserExists = errors.New("user already exists")
+	// ErrUserNotFound is returned when the given user doesn't exist.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrTokenNotFound is returned when revoking a token which doesn't exist.
+	ErrTokenNotFound = errors.New("token not found")
+)
+
+// User is a user of the server.
+type User struct {
+	Name string `json:"name"`
+	// PasswordHash is the bcrypt hash of the password.
+	PasswordHash []byte   `json:"password-hash"`
+	Tokens       []*Token `json:"tokens,omitempty"`
+}
+
+// Token is an API token of a user, which is given as a bearer token by scripts.
+type Token struct {
+	// ID identifies the token without revealing it. It is the beginning of the hash.
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	// Hash is the hex encoded SHA-256 hash of the token. Tokens themselves are not stored.
+	Hash    string    `json:"hash"`
+	Created time.Time `json:"created"`
+}
+
+// Store is a local user store saved in a JSON file. The file is reloaded when it is modified, e.g. by another
+// process adding users, so that changes take effect without restarting the server. Modifications are checked at most
+// once in refreshInterval.
+type Store struct {
+	name string
+	// checked is the time in Unix nanoseconds when the file was checked last.
+	checked atomic.Int64
+
+	mu      sync.RWMutex
+	modTime time.Time
+	data    *storeData
+}
+
+type storeData struct {
+	// Key signs session cookies and share links. Changing it invalidates all of them.
+	Key   []byte           `json:"key"`
+	Users map[string]*User `json:"users"`
+}
+
+// OpenStore opens the user store saved in the file of the given name. The file is created with a new key if it
+// doesn't exist.
+func OpenStore(name string) (*Store, error) {
+	s := &Store{name: name}
+	if err := s.reload(); errors.Is(err, fs.ErrNotExist) {
+		key := make([]byte, keySize)
+		if _, err = rand.Read(key); err != nil {
+			return nil, err
+		}
+		s.data = &storeData{Key: key, Users: make(map[string]*User)}
+		if err = s.save(); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Enabled returns true if at least one user is registered. Authentication isn't required otherwise.
+func (s *Store) Enabled() bool {
+	s.refresh()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.data.Users) != 0
+}
+
+// Users returns names of the registered users in sorted order.
+func (s *Store) Users() []string {
+	s.refresh()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]string, 0, len(s.data.Users))
+	for name := range s.data.Users {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// AddUser registers a new user with the given password.
+func (s *Store) AddUser(name, password string) error {
+	if name == "" || strings.ContainsAny(name, ":\x00") {
+		return fmt.Errorf("invalid user name: %q", name)
+	}
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	return s.update(func(d *storeData) error {
+		if _, ok := d.Users[name]; ok {
+			return fmt.Errorf("%w: %v", ErrUserExists, name)
+		}
+		d.Users[name] = &User{Name: name, PasswordHash: hash}
+		return nil
+	})
+}
+
+// SetPassword changes the password of the given user, which invalidates sessions of the user.
+func (s *Store) SetPassword(name, password string) error {
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	return s.update(func(d *storeData) error {
+		u, ok := d.Users[name]
+		if !ok {
+			return fmt.Errorf("%w: %v", ErrUserNotFound, name)
+		}
+		u.PasswordHash = hash
+		return nil
+	})
+}
+
+// RemoveUser removes the given user. Sessions, API tokens and share links of the user are invalidated.
+func (s *Store) RemoveUser(name string) error {
+	return s.update(func(d *storeData) error {
+		if _, ok := d.Users[name]; !ok {
+			return fmt.Errorf("%w: %v", ErrUserNotFound, name)
+		}
+		delete(d.Users, name)
+		return nil
+	})
+}
+
+// Authenticate checks the password of the given user.
+func (s *Store) Authenticate(name, password string) error {
+	u := s.user(name)
+	if u == nil {
+		// a hash is compared anyway not to reveal which users exist by response times.
+		_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+		return ErrUnauthorized
+	}
+	if bcrypt.CompareHashAndPassword(u.PasswordHash, []byte(password)) != nil {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// CreateToken creates a new API token of the given user. The returned token is shown only once since it isn't
+// stored.
+func (s *Store) CreateToken(name, description string) (string, *Token, error) {
+	b := make([]byte, tokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, err
+	}
+	token := tokenPrefix + hex.EncodeToString(b)
+	hash := hashToken(token)
+	t := &Token{ID: hash[:tokenIDSize], Description: description, Hash: hash, Created: time.Now()}
+
+	err := s.update(func(d *storeData) error {
+		u, ok := d.Users[name]
+		if !ok {
+			return fmt.Errorf("%w: %v", ErrUserNotFound, name)
+		}
+		u.Tokens = append(u.Tokens, t)
+		return nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return token, t, nil
+}
+
+// Tokens returns API tokens of the given user.
+func (s *Store) Tokens(name string) ([]*Token, error) {
+	u := s.user(name)
+	if u == nil {
+		return nil, fmt.Errorf("%w: %v", ErrUserNotFound, name)
+	}
+	return u.Tokens, nil
+}
+
+// RevokeToken removes the API token of the given ID from the given user.
+func (s *Store) RevokeToken(name, id string) error {
+	return s.update(func(d *storeData) error {
+		u, ok := d.Users[name]
+		if !ok {
+			return fmt.Errorf("%w: %v", ErrUserNotFound, name)
+		}
+		for i, t := range u.Tokens {
+			if t.ID == id {
+				u.Tokens = append(u.Tokens[:i], u.Tokens[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: %v", ErrTokenNotFound, id)
+	})
+}
+
+// VerifyToken returns the name of the user who owns the given API token.
+func (s *Store) VerifyToken(token string) (string, error) {
+	s.refresh()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hash := []byte(hashToken(token))
+	for _, u := range s.data.Users {
+		for _, t := range u.Tokens {
+			if subtle.ConstantTimeCompare([]byte(t.Hash), hash) == 1 {
+				return u.Name, nil
+			}
+		}
+	}
+	return "", ErrUnauthorized
+}
+
+// user returns the given user or nil if the user doesn't exist.
+func (s *Store) user(name string) *User {
+	s.refresh()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data.Users[name]
+}
+
+// key returns the key signing session cookies and share links.
+func (s *Store) key() []byte {
+	s.refresh()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data.Key
+}
+
+// refresh reloads the file if it has been modified and refreshInterval has passed since the last check.
+func (s *Store) refresh() {
+	now, last := time.Now().UnixNano(), s.checked.Load()
+	if now-last < int64(refreshInterval) || !s.checked.CompareAndSwap(last, now) {
+		return
+	}
+
+	info, err := os.Stat(s.name)
+	if err != nil {
+		return
+	}
+	s.mu.RLock()
+	modified := !info.ModTime().Equal(s.modTime)
+	s.mu.RUnlock()
+	if modified {
+		// the current data are kept if the file is broken.
+		_ = s.reload()
+	}
+}
+
+// reload reads the file.
+func (s *Store) reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// load reads the file. s.mu must be locked.
+func (s *Store) load() error {
+	info, err := os.Stat(s.name)
+	if err != nil {
+		return err
+	}
+	b, err := os.ReadFile(s.name)
+	if err != nil {
+		return err
+	}
+	var d storeData
+	if err = json.Unmarshal(b, &d); err != nil {
+		return fmt.Errorf("failed to parse %v: %w", s.name, err)
+	}
+	if len(d.Key) < keySize {
+		return fmt.Errorf("invalid key in %v", s.name)
+	}
+	if d.Users == nil {
+		d.Users = make(map[string]*User)
+	}
+	s.data, s.modTime = &d, info.ModTime()
+	s.checked.Store(time.Now().UnixNano())
+	return nil
+}
+
+// update reads the latest file, applies fn, and saves the result.
+func (s *Store) update(fn func(d *storeData) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	if err := fn(s.data); err != nil {
+		return err
+	}
+	return s.save()
+}
+
+// save writes the data to the file, which is readable only by the owner. s.mu must be locked unless the store is
+// being opened.
+func (s *Store) save() (err error) {
+	b, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(s.name), 0700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.name), filepath.Base(s.name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, os.Remove(tmp.Name()))
+		}
+	}()
+	if _, err = tmp.Write(b); err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), s.name); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(s.name)
+	if err != nil {
+		return err
+	}
+	s.modTime = info.ModTime()
+	s.checked.Store(time.Now().UnixNano())
+	return nil
+}
+
+var (
+	dummyHashOnce  sync.Once
+	dummyHashValue []byte
+)
+
+// dummyHash returns a hash compared with passwords of unknown users.
+func dummyHash() []byte {
+	dummyHashOnce.Do(func() {
+		dummyHashValue, _ = bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	})
+	return dummyHashValue
+}
+
+func hashPassword(password string) ([]byte, error) {
+	if password == "" {
+		return nil, errors.New("empty password")
+	}
+	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+}
+
+func hashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
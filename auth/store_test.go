@@ -0,0 +1,188 @@
+// store_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func openTestStore(t *testing.T) (*Store, string) {
+	t.Helper()
+
+	name := filepath.Join(t.TempDir(), "users")
+	s, err := OpenStore(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, name
+}
+
+func TestStore(t *testing.T) {
+	s, name := openTestStore(t)
+	if s.Enabled() {
+		t.Error("expect authentication is disabled without users")
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("expect 0600, got %v", perm)
+	}
+
+	if err = s.AddUser("alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.AddUser("alice", "another"); !errors.Is(err, ErrUserExists) {
+		t.Errorf("expect %v, got %v", ErrUserExists, err)
+	}
+	for _, v := range []string{"", "a:b"} {
+		if err = s.AddUser(v, "secret"); err == nil {
+			t.Errorf("expect user name %q is rejected", v)
+		}
+	}
+	if err = s.AddUser("bob", ""); err == nil {
+		t.Error("expect an empty password is rejected")
+	}
+	if !s.Enabled() {
+		t.Error("expect authentication is enabled")
+	}
+
+	cases := []struct {
+		name     string
+		password string
+		expect   error
+	}{
+		{name: "alice", password: "secret"},
+		{name: "alice", password: "wrong", expect: ErrUnauthorized},
+		{name: "bob", password: "secret", expect: ErrUnauthorized},
+	}
+	for _, c := range cases {
+		if err = s.Authenticate(c.name, c.password); !errors.Is(err, c.expect) {
+			t.Errorf("expect %v, got %v", c.expect, err)
+		}
+	}
+
+	// users are saved in the file.
+	s, err = OpenStore(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.SetPassword("alice", "changed"); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Authenticate("alice", "changed"); err != nil {
+		t.Errorf("expect the new password is accepted: %v", err)
+	}
+	if err = s.SetPassword("bob", "secret"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expect %v, got %v", ErrUserNotFound, err)
+	}
+	if err = s.AddUser("bob", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if res := s.Users(); !reflect.DeepEqual(res, []string{"alice", "bob"}) {
+		t.Errorf("expect [alice bob], got %v", res)
+	}
+	if err = s.RemoveUser("alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Authenticate("alice", "changed"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expect %v, got %v", ErrUnauthorized, err)
+	}
+}
+
+func TestStoreTokens(t *testing.T) {
+	s, _ := openTestStore(t)
+	if err := s.AddUser("alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.CreateToken("bob", ""); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expect %v, got %v", ErrUserNotFound, err)
+	}
+
+	token, info, err := s.CreateToken("alice", "backup script")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(token, tokenPrefix) {
+		t.Errorf("expect a token starting with %v, got %v", tokenPrefix, token)
+	}
+	if user, err := s.VerifyToken(token); err != nil || user != "alice" {
+		t.Errorf("expect alice, got %v (%v)", user, err)
+	}
+	if _, err = s.VerifyToken(token + "x"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expect %v, got %v", ErrUnauthorized, err)
+	}
+
+	tokens, err := s.Tokens("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 1 || tokens[0].ID != info.ID || tokens[0].Description != "backup script" {
+		t.Errorf("expect %v, got %v", info, tokens)
+	}
+	if strings.Contains(tokens[0].Hash, strings.TrimPrefix(token, tokenPrefix)) {
+		t.Error("expect the token isn't stored")
+	}
+
+	if err = s.RevokeToken("alice", "unknown"); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("expect %v, got %v", ErrTokenNotFound, err)
+	}
+	if err = s.RevokeToken("alice", info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.VerifyToken(token); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expect %v, got %v", ErrUnauthorized, err)
+	}
+}
+
+func TestStoreReload(t *testing.T) {
+	s, name := openTestStore(t)
+	other, err := OpenStore(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = other.AddUser("alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	// modification times may not change within the resolution of the file system.
+	now := time.Now().Add(time.Second)
+	if err = os.Chtimes(name, now, now); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Authenticate("alice", "secret"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expect the file isn't checked until the interval passes, got %v", err)
+	}
+	s.checked.Store(0)
+	if err = s.Authenticate("alice", "secret"); err != nil {
+		t.Errorf("expect users added by another process are loaded: %v", err)
+	}
+
+	// broken files are ignored.
+	if err = os.WriteFile(name, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chtimes(name, now.Add(time.Second), now.Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	s.checked.Store(0)
+	if err = s.Authenticate("alice", "secret"); err != nil {
+		t.Errorf("expect the loaded users are kept: %v", err)
+	}
+	if _, err = OpenStore(name); err == nil {
+		t.Error("expect a broken file can't be opened")
+	}
+}
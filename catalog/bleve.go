@@ -0,0 +1,1055 @@
+// bleve.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/standard"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/single"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/query"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/jkawamoto/sd-image-viewer/image"
+)
+
+const (
+	// bleveSchemaKey is the key of metadata recording the schema of documents in a bleve index, and bleveSchema is the
+	// current schema, which identifies the mapping and the fields of documents. Indexes of other schemas are searched
+	// with their own mappings until they are reset and their documents are indexed again.
+	bleveSchemaKey = "bleve-schema"
+	bleveSchema    = "8"
+
+	// lockTimeout is the time to wait for a lock of an index held by another process.
+	lockTimeout = time.Second
+
+	// paramAnalyzer is the name of the analyzer of parameters in metadata, which are matched case-insensitively as
+	// whole values.
+	paramAnalyzer = "param"
+)
+
+// ErrLocked is returned by OpenBleveReadOnly when the index is used by another process, e.g. a running server.
+var ErrLocked = errors.New("index is in use by another process; query the running server instead")
+
+type bleveCatalog struct {
+	index bleve.Index
+	// path is the path of the index, which is empty if it is in memory.
+	path       string
+	stats      resultCache[*Stats]
+	duplicates resultCache[[]duplicateGroup]
+}
+
+// imageDocument is an image stored in a bleve index. Parameters in metadata are also stored with names given by
+// ParamName to search them in query strings, and numeric parameters are also stored as numbers to compare them.
+// The sampler and LoRAs are stored as they are to count them in facets. Annotations are stored as top-level fields,
+// which images without annotations also have, so that images can be sorted by ratings. Perceptual hashes are also
+// indexed in blocks given by hashBlocks to find similar images, and tags of prompts given by promptTags are indexed to
+// count them in facets.
+type imageDocument struct {
+	*image.Image `json:""`
+	Params       map[string]string  `json:"params"`
+	Numbers      map[string]float64 `json:"numbers"`
+	SamplerName  string             `json:"sampler"`
+	LoRANames    []string           `json:"lora"`
+	Favorite     bool               `json:"favorite"`
+	Rating       int                `json:"rating"`
+	Tags         []string           `json:"tags"`
+	Note         string             `json:"note"`
+	HashBlocks   []string           `json:"hash-block"`
+	PromptTags   []string           `json:"prompt-tag"`
+}
+
+func newImageDocument(img *image.Image) *imageDocument {
+	doc := &imageDocument{
+		Image:       img,
+		Params:      make(map[string]string, len(img.Metadata)),
+		Numbers:     make(map[string]float64),
+		SamplerName: img.Sampler(),
+		LoRANames:   img.LoRAs(),
+		HashBlocks:  hashBlocks(img.Hash),
+		PromptTags:  promptTags(img.Prompt),
+	}
+	if a := img.Annotation; a != nil {
+		doc.Favorite, doc.Rating, doc.Tags, doc.Note = a.Favorite, a.Rating, a.Tags, a.Note
+	}
+	for k, v := range img.Metadata {
+		name := ParamName(k)
+		doc.Params[name] = v
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			doc.Numbers[name] = n
+		}
+	}
+	return doc
+}
+
+// OpenBleve opens a catalog backed by the bleve index of the given path. The index is created if it doesn't exist.
+// An existing index keeps its mapping, and its schema stays as it is until it is reset.
+func OpenBleve(name string) (_ Catalog, created bool, err error) {
+	index, err := bleve.Open(name)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		index, err = newBleveIndex(name)
+		created = true
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &bleveCatalog{index: index, path: name}, created, nil
+}
+
+// newBleveIndex creates a bleve index of the current schema in the given path, or in memory if it is empty.
+func newBleveIndex(name string) (index bleve.Index, err error) {
+	if name == "" {
+		index, err = bleve.NewMemOnly(newIndexMapping())
+	} else {
+		index, err = bleve.New(name, newIndexMapping())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = index.SetInternal([]byte(bleveSchemaKey), []byte(bleveSchema)); err != nil {
+		return nil, errors.Join(err, index.Close())
+	}
+	return index, nil
+}
+
+// OpenBleveReadOnly opens a catalog backed by the existing bleve index of the given path without modifying it.
+//
+// While another process, e.g. a running server, writes the index, it is locked and can't be opened even in read-only
+// mode. In that case, ErrLocked is returned.
+func OpenBleveReadOnly(name string) (Catalog, error) {
+	index, err := bleve.OpenUsing(name, map[string]any{
+		"read_only":    true,
+		"bolt_timeout": lockTimeout.String(),
+	})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, ErrLocked
+	} else if err != nil {
+		return nil, err
+	}
+	return &bleveCatalog{index: index}, nil
+}
+
+// NewBleveMemory creates a catalog backed by a bleve index in memory.
+func NewBleveMemory() (Catalog, error) {
+	index, err := newBleveIndex("")
+	if err != nil {
+		return nil, err
+	}
+	return &bleveCatalog{index: index}, nil
+}
+
+func (c *bleveCatalog) Index(_ context.Context, docs map[string]Document) error {
+	b := c.index.NewBatch()
+	for id, doc := range docs {
+		if img, ok := doc.(*image.Image); ok {
+			doc = newImageDocument(img)
+		}
+		if err := b.Index(id, doc); err != nil {
+			return err
+		}
+	}
+	defer c.clearCaches()
+	return c.index.Batch(b)
+}
+
+func (c *bleveCatalog) Delete(_ context.Context, ids ...string) error {
+	b := c.index.NewBatch()
+	for _, id := range ids {
+		b.Delete(id)
+	}
+	defer c.clearCaches()
+	return c.index.Batch(b)
+}
+
+// clearCaches removes results cached from the index, which is called whenever the index changes.
+func (c *bleveCatalog) clearCaches() {
+	c.stats.clear()
+	c.duplicates.clear()
+}
+
+func (c *bleveCatalog) Search(ctx context.Context, req *SearchRequest) (*SearchResult, error) {
+	q := imageQuery(&req.Filter)
+	r := bleve.NewSearchRequestOptions(q, req.Limit, req.Offset, false)
+	r.Fields = []string{"*"}
+	r.SortBy(sortOrder(req.Sort, req.Order))
+	offset := req.Offset
+	if req.Sort == SortPosition {
+		// all the matching images are found to be sorted by their positions, and the page is read later. Cursors are
+		// the positions of the next pages.
+		r = bleve.NewSearchRequestOptions(q, len(req.Filter.IDs), 0, false)
+		if len(req.After) != 0 {
+			var err error
+			if offset, err = strconv.Atoi(req.After[0]); err != nil || len(req.After) != 1 {
+				return nil, fmt.Errorf("invalid cursor: %q", req.After)
+			}
+		}
+	} else if len(req.After) != 0 {
+		r.From = 0
+		r.SetSearchAfter(req.After)
+	}
+	for _, f := range req.Facets {
+		fr, err := c.facetRequest(ctx, q, f)
+		if err != nil {
+			return nil, err
+		}
+		if fr != nil {
+			r.AddFacet(f.Name, fr)
+		}
+	}
+
+	res, err := c.index.SearchInContext(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		hits []*Hit
+		next []string
+	)
+	if req.Sort == SortPosition {
+		ids := page(inOrder(req.Filter.IDs, res.Hits), offset, req.Limit)
+		if hits, err = c.getAll(ctx, ids); err != nil {
+			return nil, err
+		}
+		if len(ids) != 0 {
+			next = []string{strconv.Itoa(offset + len(ids))}
+		}
+	} else {
+		hits = make([]*Hit, len(res.Hits))
+		for i, v := range res.Hits {
+			hits[i] = &Hit{ID: v.ID, Image: toImage(v.Fields)}
+		}
+		if len(res.Hits) != 0 {
+			next = res.Hits[len(res.Hits)-1].Sort
+		}
+	}
+	var facets map[string][]*Facet
+	if len(req.Facets) != 0 {
+		facets = make(map[string][]*Facet, len(req.Facets))
+		for _, f := range req.Facets {
+			facets[f.Name] = toFacets(f.Name, res.Facets[f.Name])
+		}
+	}
+	return &SearchResult{Total: int(res.Total), Hits: hits, Facets: facets, Next: next}, nil
+}
+
+func (c *bleveCatalog) Get(ctx context.Context, id string) (*image.Image, error) {
+	r := bleve.NewSearchRequestOptions(withID(imageQuery(&Filter{}), id), 1, 0, false)
+	r.Fields = []string{"*"}
+	res, err := c.index.SearchInContext(ctx, r)
+	if err != nil || len(res.Hits) == 0 {
+		return nil, err
+	}
+	return toImage(res.Hits[0].Fields), nil
+}
+
+func (c *bleveCatalog) Lookup(ctx context.Context, id string, req *SearchRequest) (*LookupResult, error) {
+	img, err := c.Get(ctx, id)
+	if err != nil || img == nil {
+		return nil, err
+	}
+	result := &LookupResult{Image: img}
+
+	// sort keys of the image are found only if it matches the request.
+	q := imageQuery(&req.Filter)
+	if req.Sort == SortPosition {
+		r := bleve.NewSearchRequestOptions(q, len(req.Filter.IDs), 0, false)
+		res, err := c.index.SearchInContext(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		ids := inOrder(req.Filter.IDs, res.Hits)
+		for i, v := range ids {
+			if v != id {
+				continue
+			}
+			if i > 0 {
+				result.Previous = ids[i-1]
+			}
+			if i < len(ids)-1 {
+				result.Next = ids[i+1]
+			}
+			break
+		}
+		return result, nil
+	}
+	r := bleve.NewSearchRequestOptions(withID(q, id), 1, 0, false)
+	r.SortBy(sortOrder(req.Sort, req.Order))
+	res, err := c.index.SearchInContext(ctx, r)
+	if err != nil || len(res.Hits) == 0 {
+		return result, err
+	}
+	keys := res.Hits[0].Sort
+
+	r = bleve.NewSearchRequestOptions(q, 1, 0, false)
+	r.SortBy(sortOrder(req.Sort, req.Order))
+	r.SetSearchBefore(keys)
+	res, err = c.index.SearchInContext(ctx, r)
+	if err != nil {
+		return nil, err
+	} else if len(res.Hits) != 0 {
+		result.Previous = res.Hits[0].ID
+	}
+
+	r = bleve.NewSearchRequestOptions(q, 1, 0, false)
+	r.SortBy(sortOrder(req.Sort, req.Order))
+	r.SetSearchAfter(keys)
+	res, err = c.index.SearchInContext(ctx, r)
+	if err != nil {
+		return nil, err
+	} else if len(res.Hits) != 0 {
+		result.Next = res.Hits[0].ID
+	}
+	return result, nil
+}
+
+// sortOrder returns sort fields of images sorted by the given key in the given order. Images having the same key are
+// sorted by their creation times and then their IDs so that neighbours of an image are determined.
+func sortOrder(key string, order Order) []string {
+	fields := []string{"creation-time", "_id"}
+	if key == SortRating {
+		fields = append([]string{"rating"}, fields...)
+	}
+	if order == Descending {
+		for i, v := range fields {
+			fields[i] = "-" + v
+		}
+	}
+	return fields
+}
+
+// inOrder returns IDs of the given hits in the order of ids. Duplicated IDs are returned only once.
+func inOrder(ids []string, hits search.DocumentMatchCollection) []string {
+	found := make(map[string]bool, len(hits))
+	for _, v := range hits {
+		found[v.ID] = true
+	}
+	res := make([]string, 0, len(hits))
+	for _, id := range ids {
+		if found[id] {
+			res = append(res, id)
+			delete(found, id)
+		}
+	}
+	return res
+}
+
+// page returns the given range of ids. A non-positive limit means no limits.
+func page(ids []string, offset, limit int) []string {
+	if offset >= len(ids) {
+		return nil
+	}
+	ids = ids[offset:]
+	if limit > 0 && limit < len(ids) {
+		ids = ids[:limit]
+	}
+	return ids
+}
+
+// getAll returns images of the given IDs in the same order. Images which don't exist are omitted.
+func (c *bleveCatalog) getAll(ctx context.Context, ids []string) ([]*Hit, error) {
+	if len(ids) == 0 {
+		return []*Hit{}, nil
+	}
+	r := bleve.NewSearchRequestOptions(imageQuery(&Filter{IDs: ids}), len(ids), 0, false)
+	r.Fields = []string{"*"}
+	res, err := c.index.SearchInContext(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make(map[string]*image.Image, len(res.Hits))
+	for _, v := range res.Hits {
+		images[v.ID] = toImage(v.Fields)
+	}
+	hits := make([]*Hit, 0, len(ids))
+	for _, id := range ids {
+		if img, ok := images[id]; ok {
+			hits = append(hits, &Hit{ID: id, Image: img})
+		}
+	}
+	return hits, nil
+}
+
+// withID restricts the given query to the document of the given ID.
+func withID(q query.Query, id string) query.Query {
+	return query.NewConjunctionQuery([]query.Query{query.NewDocIDQuery([]string{id}), q})
+}
+
+// facetRequest returns a bleve facet request of the given facet of images matching the given query. It returns nil
+// if there are no terms to count.
+func (c *bleveCatalog) facetRequest(ctx context.Context, q query.Query, f *FacetRequest) (*bleve.FacetRequest, error) {
+	size := f.Size
+	if size == 0 {
+		size = math.MaxInt32
+	}
+
+	switch f.Name {
+	case FacetCheckpoint, FacetSampler, FacetLoRA, FacetPromptTag:
+		return bleve.NewFacetRequest(f.Name, size), nil
+
+	case FacetResolution:
+		return bleve.NewFacetRequest("params.size", size), nil
+
+	case FacetSize:
+		fr := bleve.NewFacetRequest("pixel", len(sizeClasses))
+		for _, v := range sizeClasses {
+			// numeric range facets include minimums and exclude maximums unlike size classes, and pixels are integers.
+			var min, max *float64
+			if v.min != 0 {
+				n := float64(v.min + 1)
+				min = &n
+			}
+			if v.max != 0 {
+				n := float64(v.max + 1)
+				max = &n
+			}
+			fr.AddNumericRange(v.name, min, max)
+		}
+		return fr, nil
+
+	case FacetCreated:
+		periods, err := c.periods(ctx, q, f.Interval, f.Size)
+		if err != nil || len(periods) == 0 {
+			return nil, err
+		}
+		return createdFacetRequest(periods, f.Interval), nil
+
+	default:
+		return nil, fmt.Errorf("unknown facet: %v", f.Name)
+	}
+}
+
+// createdFacetRequest returns a bleve facet request counting images created in the given periods of the interval.
+func createdFacetRequest(periods []time.Time, interval string) *bleve.FacetRequest {
+	fr := bleve.NewFacetRequest("creation-time", len(periods))
+	for _, p := range periods {
+		fr.AddDateTimeRange(p.Format(time.DateOnly), p, nextPeriod(p, interval))
+	}
+	return fr
+}
+
+// periods returns the first times of periods of the given interval from the creation time of the oldest image matching
+// the given query to the newest one. If limit is positive, only the given number of the latest periods are returned.
+func (c *bleveCatalog) periods(ctx context.Context, q query.Query, interval string, limit int) ([]time.Time, error) {
+	if _, err := truncatePeriod(time.Time{}, interval); err != nil {
+		return nil, err
+	}
+
+	oldest, err := c.creationTime(ctx, q, "creation-time")
+	if err != nil || oldest.IsZero() {
+		return nil, err
+	}
+	newest, err := c.creationTime(ctx, q, "-creation-time")
+	if err != nil {
+		return nil, err
+	}
+	first, _ := truncatePeriod(oldest, interval)
+	last, _ := truncatePeriod(newest, interval)
+
+	var res []time.Time
+	for p := last; !p.Before(first) && (limit <= 0 || len(res) < limit); p = previousPeriod(p, interval) {
+		res = append(res, p)
+	}
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res, nil
+}
+
+// creationTime returns the creation time of the first image matching the given query in the given order. It returns
+// the zero time if no images match.
+func (c *bleveCatalog) creationTime(ctx context.Context, q query.Query, order string) (time.Time, error) {
+	r := bleve.NewSearchRequestOptions(q, 1, 0, false)
+	r.Fields = []string{"creation-time"}
+	r.SortBy([]string{order})
+
+	res, err := c.index.SearchInContext(ctx, r)
+	if err != nil || len(res.Hits) == 0 {
+		return time.Time{}, err
+	}
+	return getDateTime(res.Hits[0].Fields, "creation-time"), nil
+}
+
+// truncatePeriod returns the first time of the period of the given interval containing t in the local time zone.
+// Weeks start on Mondays. An empty interval means IntervalMonth.
+func truncatePeriod(t time.Time, interval string) (time.Time, error) {
+	t = t.In(time.Local)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	switch interval {
+	case IntervalDay:
+		return day, nil
+	case IntervalWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7), nil
+	case IntervalMonth, "":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local), nil
+	default:
+		return time.Time{}, fmt.Errorf("unknown interval: %v", interval)
+	}
+}
+
+// nextPeriod returns the first time of the period following the one starting at t.
+func nextPeriod(t time.Time, interval string) time.Time {
+	switch interval {
+	case IntervalDay:
+		return t.AddDate(0, 0, 1)
+	case IntervalWeek:
+		return t.AddDate(0, 0, 7)
+	default:
+		return t.AddDate(0, 1, 0)
+	}
+}
+
+// previousPeriod returns the first time of the period preceding the one starting at t.
+func previousPeriod(t time.Time, interval string) time.Time {
+	switch interval {
+	case IntervalDay:
+		return t.AddDate(0, 0, -1)
+	case IntervalWeek:
+		return t.AddDate(0, 0, -7)
+	default:
+		return t.AddDate(0, -1, 0)
+	}
+}
+
+// toFacets converts a bleve facet result of the given facet. It returns an empty list if there are no results.
+func toFacets(name string, r *search.FacetResult) []*Facet {
+	res := []*Facet{}
+	if r == nil {
+		return res
+	}
+
+	switch name {
+	case FacetSize:
+		for _, v := range sizeClasses {
+			for _, nr := range r.NumericRanges {
+				if nr.Name == v.name && nr.Count != 0 {
+					res = append(res, &Facet{Term: nr.Name, Count: nr.Count})
+				}
+			}
+		}
+	case FacetCreated:
+		for _, dr := range r.DateRanges {
+			res = append(res, &Facet{Term: dr.Name, Count: dr.Count})
+		}
+		// names of periods are dates, which are sorted chronologically as strings.
+		sort.Slice(res, func(i, j int) bool {
+			return res[i].Term < res[j].Term
+		})
+	default:
+		for _, v := range r.Terms.Terms() {
+			// images without values, e.g. those generated without LoRAs, have empty terms.
+			if v.Term != "" {
+				res = append(res, &Facet{Term: v.Term, Count: v.Count})
+			}
+		}
+	}
+	return res
+}
+
+func (c *bleveCatalog) SearchFailures(ctx context.Context, req *FailureRequest) (*FailureResult, error) {
+	r := bleve.NewSearchRequestOptions(failureQuery(req.Classes...), req.Limit, req.Offset, false)
+	r.Fields = []string{"*"}
+	r.SortBy([]string{"-failed-at", "-_id"})
+	if len(req.After) != 0 {
+		r.From = 0
+		r.SetSearchAfter(req.After)
+	}
+
+	res, err := c.index.SearchInContext(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]*FailureHit, len(res.Hits))
+	for i, v := range res.Hits {
+		hits[i] = &FailureHit{ID: v.ID, Failure: toFailure(v.Fields)}
+	}
+	var next []string
+	if len(res.Hits) != 0 {
+		next = res.Hits[len(res.Hits)-1].Sort
+	}
+	return &FailureResult{Total: int(res.Total), Hits: hits, Next: next}, nil
+}
+
+func (c *bleveCatalog) Facets(ctx context.Context, filter *Filter, field string, size int) ([]*Facet, error) {
+	r := bleve.NewSearchRequestOptions(imageQuery(filter), 0, 0, false)
+	r.AddFacet(field, bleve.NewFacetRequest(field, size))
+
+	res, err := c.index.SearchInContext(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	f, ok := res.Facets[field]
+	if !ok || f.Terms == nil {
+		return nil, nil
+	}
+
+	var facets []*Facet
+	for _, v := range f.Terms.Terms() {
+		facets = append(facets, &Facet{Term: v.Term, Count: v.Count})
+	}
+	return facets, nil
+}
+
+func (c *bleveCatalog) Terms(_ context.Context, field string) (_ []string, err error) {
+	fields, err := c.index.FieldDict(field)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, fields.Close())
+	}()
+
+	var res []string
+	for {
+		f, err := fields.Next()
+		if err != nil {
+			return nil, err
+		} else if f == nil {
+			return res, nil
+		}
+		res = append(res, f.Term)
+	}
+}
+
+func (c *bleveCatalog) IDs(ctx context.Context, offset, limit int) ([]string, error) {
+	res, err := c.index.SearchInContext(ctx, bleve.NewSearchRequestOptions(query.NewMatchAllQuery(), limit, offset, false))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(res.Hits))
+	for i, v := range res.Hits {
+		ids[i] = v.ID
+	}
+	return ids, nil
+}
+
+func (c *bleveCatalog) GetMeta(key string) ([]byte, error) {
+	return c.index.GetInternal([]byte(key))
+}
+
+func (c *bleveCatalog) SetMeta(key string, value []byte) error {
+	return c.index.SetInternal([]byte(key), value)
+}
+
+// Outdated returns true if the index has a schema other than the current one.
+func (c *bleveCatalog) Outdated() (bool, error) {
+	v, err := c.index.GetInternal([]byte(bleveSchemaKey))
+	if err != nil {
+		return false, err
+	}
+	return string(v) != bleveSchema, nil
+}
+
+// Reset removes the index and creates a new one of the current schema in the same path.
+func (c *bleveCatalog) Reset() error {
+	if err := c.index.Close(); err != nil {
+		return err
+	}
+	if c.path != "" {
+		if err := os.RemoveAll(c.path); err != nil {
+			return err
+		}
+	}
+	index, err := newBleveIndex(c.path)
+	if err != nil {
+		return err
+	}
+	c.index = index
+	c.clearCaches()
+	return nil
+}
+
+func (c *bleveCatalog) Close() error {
+	return c.index.Close()
+}
+
+// imageQuery returns a query matching images which satisfy the given filter.
+func imageQuery(filter *Filter) query.Query {
+	var queries []query.Query
+	if filter.ID != "" {
+		queries = append(queries, query.NewDocIDQuery([]string{filter.ID}))
+	}
+	if filter.IDs != nil {
+		queries = append(queries, query.NewDocIDQuery(filter.IDs))
+	}
+	if filter.Prompt != "" {
+		q := query.NewMatchPhraseQuery(filter.Prompt)
+		q.FieldVal = "prompt"
+		q.Analyzer = standard.Name
+
+		queries = append(queries, q)
+	}
+	if filter.Query != nil {
+		queries = append(queries, exprQuery(filter.Query))
+	}
+	if filter.MinPixel != 0 || filter.MaxPixel != 0 {
+		var min, max *float64
+		if filter.MinPixel != 0 {
+			v := float64(filter.MinPixel)
+			min = &v
+		}
+		if filter.MaxPixel != 0 {
+			v := float64(filter.MaxPixel)
+			max = &v
+		}
+		minInclusive, maxInclusive := false, true
+		q := query.NewNumericRangeInclusiveQuery(min, max, &minInclusive, &maxInclusive)
+		q.FieldVal = "pixel"
+
+		queries = append(queries, q)
+	}
+	if filter.Checkpoint != "" {
+		q := query.NewTermQuery(filter.Checkpoint)
+		q.FieldVal = "checkpoint"
+
+		queries = append(queries, q)
+	}
+	if !filter.After.IsZero() || !filter.Before.IsZero() {
+		startInclusive, endInclusive := true, false
+		q := query.NewDateRangeInclusiveQuery(filter.After, filter.Before, &startInclusive, &endInclusive)
+		q.FieldVal = "creation-time"
+
+		queries = append(queries, q)
+	}
+	if filter.Favorite {
+		q := query.NewBoolFieldQuery(true)
+		q.FieldVal = "favorite"
+
+		queries = append(queries, q)
+	}
+	if filter.MinRating != 0 {
+		v, inclusive := float64(filter.MinRating), true
+		q := query.NewNumericRangeInclusiveQuery(&v, nil, &inclusive, nil)
+		q.FieldVal = "rating"
+
+		queries = append(queries, q)
+	}
+	for _, tag := range filter.Tags {
+		q := query.NewTermQuery(tag)
+		q.FieldVal = "tags"
+
+		queries = append(queries, q)
+	}
+	if len(queries) == 0 {
+		queries = append(queries, query.NewMatchAllQuery())
+	}
+
+	// failure records share the index with images.
+	return query.NewBooleanQuery([]query.Query{query.NewConjunctionQuery(queries)}, nil, []query.Query{failureQuery()})
+}
+
+// exprQuery returns a query matching images which match the given expression of a query string.
+func exprQuery(e Expr) query.Query {
+	switch e := e.(type) {
+	case *AndExpr:
+		queries := make([]query.Query, len(e.Exprs))
+		for i, v := range e.Exprs {
+			queries[i] = exprQuery(v)
+		}
+		return query.NewConjunctionQuery(queries)
+
+	case *OrExpr:
+		queries := make([]query.Query, len(e.Exprs))
+		for i, v := range e.Exprs {
+			queries[i] = exprQuery(v)
+		}
+		return query.NewDisjunctionQuery(queries)
+
+	case *NotExpr:
+		// a boolean query having only must not clauses matches all the other documents.
+		return query.NewBooleanQuery(nil, nil, []query.Query{exprQuery(e.Expr)})
+
+	case *MatchExpr:
+		switch e.Field {
+		case FieldPrompt, FieldNegativePrompt:
+			if e.Phrase {
+				q := query.NewMatchPhraseQuery(e.Value)
+				q.FieldVal = e.Field
+				q.Analyzer = standard.Name
+				return q
+			}
+			q := query.NewMatchQuery(e.Value)
+			q.FieldVal = e.Field
+			q.Analyzer = standard.Name
+			q.SetOperator(query.MatchQueryOperatorAnd)
+			return q
+		case FieldCheckpoint, FieldLoRA:
+			q := query.NewTermQuery(e.Value)
+			q.FieldVal = e.Field
+			return q
+		case FieldTag:
+			q := query.NewTermQuery(e.Value)
+			q.FieldVal = "tags"
+			return q
+		case FieldNote:
+			if e.Phrase {
+				q := query.NewMatchPhraseQuery(e.Value)
+				q.FieldVal = e.Field
+				q.Analyzer = standard.Name
+				return q
+			}
+			q := query.NewMatchQuery(e.Value)
+			q.FieldVal = e.Field
+			q.Analyzer = standard.Name
+			q.SetOperator(query.MatchQueryOperatorAnd)
+			return q
+		case FieldFavorite:
+			q := query.NewBoolFieldQuery(e.Value == "true")
+			q.FieldVal = e.Field
+			return q
+		default:
+			q := query.NewMatchQuery(e.Value)
+			q.FieldVal = "params." + e.Field
+			q.Analyzer = paramAnalyzer
+			return q
+		}
+
+	case *RangeExpr:
+		if e.Field == FieldCreated {
+			var start, end time.Time
+			var inclusive bool
+			switch e.Op {
+			case OpGreater, OpGreaterEqual:
+				start, inclusive = e.Time, e.Op == OpGreaterEqual
+			default:
+				end, inclusive = e.Time, e.Op == OpLessEqual
+			}
+			q := query.NewDateRangeInclusiveQuery(start, end, &inclusive, &inclusive)
+			q.FieldVal = "creation-time"
+			return q
+		}
+
+		field := "numbers." + e.Field
+		if e.Field == FieldPixel || e.Field == FieldRating {
+			field = e.Field
+		}
+		v := e.Number
+		inclusive := e.Op == OpGreaterEqual || e.Op == OpLessEqual
+		var q *query.NumericRangeQuery
+		if e.Op == OpGreater || e.Op == OpGreaterEqual {
+			q = query.NewNumericRangeInclusiveQuery(&v, nil, &inclusive, nil)
+		} else {
+			q = query.NewNumericRangeInclusiveQuery(nil, &v, nil, &inclusive)
+		}
+		q.FieldVal = field
+		return q
+
+	default:
+		panic(fmt.Sprintf("unknown expression: %T", e))
+	}
+}
+
+// failureQuery returns a query matching failure records of the given classes.
+// If no classes are given, it matches failure records of any class.
+func failureQuery(classes ...string) query.Query {
+	if len(classes) == 0 {
+		classes = image.FailureClasses
+	}
+
+	queries := make([]query.Query, len(classes))
+	for i, c := range classes {
+		q := query.NewTermQuery(c)
+		q.FieldVal = "class"
+		queries[i] = q
+	}
+	return query.NewDisjunctionQuery(queries)
+}
+
+func toImage(fields map[string]any) *image.Image {
+	img := &image.Image{
+		Prompt:         getString(fields, "prompt"),
+		NegativePrompt: getString(fields, "negative-prompt"),
+		Checkpoint:     getString(fields, "checkpoint"),
+		Pixel:          int(getFloat(fields, "pixel")),
+		CreationTime:   getDateTime(fields, "creation-time"),
+		Metadata:       make(map[string]string),
+		Parameters:     getString(fields, "parameters"),
+		Hash:           getString(fields, "hash"),
+		Digest:         getString(fields, "digest"),
+	}
+	for k, v := range fields {
+		if k, ok := strings.CutPrefix(k, "metadata."); ok {
+			img.Metadata[k] = fmt.Sprint(v)
+		}
+	}
+
+	a := &image.Annotation{
+		Favorite: getBool(fields, "favorite"),
+		Rating:   int(getFloat(fields, "rating")),
+		Tags:     getStrings(fields, "tags"),
+		Note:     getString(fields, "note"),
+	}
+	if !a.IsZero() {
+		img.Annotation = a
+	}
+	return img
+}
+
+func toFailure(fields map[string]any) *image.Failure {
+	return &image.Failure{
+		Class:       getString(fields, "class"),
+		Message:     getString(fields, "message"),
+		Size:        int64(getFloat(fields, "size")),
+		ModTime:     getDateTime(fields, "mod-time"),
+		Fingerprint: getString(fields, "fingerprint"),
+		FailedAt:    getDateTime(fields, "failed-at"),
+	}
+}
+
+func getString(m map[string]any, key string) string {
+	v, _ := m[key].(string)
+	return v
+}
+
+// getStrings returns values of the given key, which are returned as a string if there is only one value.
+func getStrings(m map[string]any, key string) []string {
+	switch v := m[key].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		res := make([]string, 0, len(v))
+		for _, s := range v {
+			if s, ok := s.(string); ok {
+				res = append(res, s)
+			}
+		}
+		return res
+	default:
+		return nil
+	}
+}
+
+func getBool(m map[string]any, key string) bool {
+	v, _ := m[key].(bool)
+	return v
+}
+
+func getFloat(m map[string]any, key string) float64 {
+	v, _ := m[key].(float64)
+	return v
+}
+
+func getDateTime(m map[string]any, key string) time.Time {
+	v, _ := m[key].(string)
+	t, _ := time.Parse(time.RFC3339, v)
+	return t
+}
+
+func newIndexMapping() mapping.IndexMapping {
+	indexMapping := bleve.NewIndexMapping()
+	// the custom analyzer is valid; an error means a bug.
+	if err := indexMapping.AddCustomAnalyzer(paramAnalyzer, map[string]any{
+		"type":          custom.Name,
+		"tokenizer":     single.Name,
+		"token_filters": []string{lowercase.Name},
+	}); err != nil {
+		panic(err)
+	}
+	indexMapping.AddDocumentMapping(image.DocType, imageDocumentMapping())
+	indexMapping.AddDocumentMapping(image.FailureDocType, failureDocumentMapping())
+	return indexMapping
+}
+
+func imageDocumentMapping() *mapping.DocumentMapping {
+	textFieldMapping := bleve.NewTextFieldMapping()
+	textFieldMapping.Analyzer = standard.Name
+
+	keywordFieldMapping := bleve.NewKeywordFieldMapping()
+
+	intFieldMapping := bleve.NewNumericFieldMapping()
+
+	dateTimeFieldMapping := bleve.NewDateTimeFieldMapping()
+
+	boolFieldMapping := bleve.NewBooleanFieldMapping()
+
+	docMapping := bleve.NewDocumentMapping()
+	docMapping.AddFieldMappingsAt("prompt", textFieldMapping)
+	docMapping.AddFieldMappingsAt("negative-prompt", textFieldMapping)
+	docMapping.AddFieldMappingsAt("checkpoint", keywordFieldMapping)
+	docMapping.AddFieldMappingsAt("sampler", keywordFieldMapping)
+	docMapping.AddFieldMappingsAt("lora", keywordFieldMapping)
+	docMapping.AddFieldMappingsAt("pixel", intFieldMapping)
+	docMapping.AddFieldMappingsAt("creation-time", dateTimeFieldMapping)
+	docMapping.AddSubDocumentMapping("metadata", bleve.NewDocumentMapping())
+	docMapping.AddFieldMappingsAt("favorite", boolFieldMapping)
+	docMapping.AddFieldMappingsAt("rating", intFieldMapping)
+	docMapping.AddFieldMappingsAt("tags", keywordFieldMapping)
+	docMapping.AddFieldMappingsAt("note", textFieldMapping)
+	docMapping.AddFieldMappingsAt("hash", keywordFieldMapping)
+
+	// blocks of hashes are only indexed to find candidates of similar images.
+	hashBlockMapping := bleve.NewKeywordFieldMapping()
+	hashBlockMapping.Store = false
+	hashBlockMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("hash-block", hashBlockMapping)
+
+	// tags of prompts are only indexed to count them in facets.
+	promptTagMapping := bleve.NewKeywordFieldMapping()
+	promptTagMapping.Store = false
+	promptTagMapping.IncludeInAll = false
+	docMapping.AddFieldMappingsAt("prompt-tag", promptTagMapping)
+
+	// annotations are indexed as the top-level fields above.
+	annotationMapping := bleve.NewDocumentMapping()
+	annotationMapping.Enabled = false
+	docMapping.AddSubDocumentMapping("annotation", annotationMapping)
+
+	// raw parameters are only stored since their contents are indexed as the other fields, and so are digests, which
+	// are only read.
+	storedFieldMapping := bleve.NewTextFieldMapping()
+	storedFieldMapping.Index = false
+	docMapping.AddFieldMappingsAt("parameters", storedFieldMapping)
+	docMapping.AddFieldMappingsAt("digest", storedFieldMapping)
+
+	paramMapping := bleve.NewDocumentMapping()
+	paramMapping.DefaultAnalyzer = paramAnalyzer
+	docMapping.AddSubDocumentMapping("params", paramMapping)
+	docMapping.AddSubDocumentMapping("numbers", bleve.NewDocumentMapping())
+
+	return docMapping
+}
+
+func failureDocumentMapping() *mapping.DocumentMapping {
+	textFieldMapping := bleve.NewTextFieldMapping()
+	textFieldMapping.Analyzer = standard.Name
+
+	keywordFieldMapping := bleve.NewKeywordFieldMapping()
+
+	intFieldMapping := bleve.NewNumericFieldMapping()
+
+	dateTimeFieldMapping := bleve.NewDateTimeFieldMapping()
+
+	docMapping := bleve.NewDocumentMapping()
+	docMapping.AddFieldMappingsAt("class", keywordFieldMapping)
+	docMapping.AddFieldMappingsAt("message", textFieldMapping)
+	docMapping.AddFieldMappingsAt("size", intFieldMapping)
+	docMapping.AddFieldMappingsAt("mod-time", dateTimeFieldMapping)
+	docMapping.AddFieldMappingsAt("fingerprint", keywordFieldMapping)
+	docMapping.AddFieldMappingsAt("failed-at", dateTimeFieldMapping)
+
+	return docMapping
+}
@@ -0,0 +1,159 @@
+// bleve_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package catalog_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/catalog/catalogtest"
+	"github.com/jkawamoto/sd-image-viewer/image"
+)
+
+func TestBleve(t *testing.T) {
+	catalogtest.Run(t, func(t *testing.T) catalog.Catalog {
+		c, created, err := catalog.OpenBleve(filepath.Join(t.TempDir(), "index"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !created {
+			t.Error("expect a new index is created")
+		}
+		return c
+	})
+}
+
+func TestBleveMemory(t *testing.T) {
+	catalogtest.Run(t, func(t *testing.T) catalog.Catalog {
+		c, err := catalog.NewBleveMemory()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	})
+}
+
+func TestOpenBleveReadOnly(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "index")
+	writer, _, err := catalog.OpenBleve(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = writer.Index(context.Background(), map[string]catalog.Document{
+		"a.png": &image.Image{Prompt: "a city", CreationTime: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	search := func(t *testing.T) {
+		t.Helper()
+		c, err := catalog.OpenBleveReadOnly(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			if err := c.Close(); err != nil {
+				t.Error(err)
+			}
+		}()
+
+		res, err := c.Search(context.Background(), &catalog.SearchRequest{Limit: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Total != 1 || res.Hits[0].ID != "a.png" {
+			t.Errorf("expect a.png, got %v", res.Hits)
+		}
+	}
+
+	t.Run("locked", func(t *testing.T) {
+		if _, err := catalog.OpenBleveReadOnly(name); !errors.Is(err, catalog.ErrLocked) {
+			t.Errorf("expect %v, got %v", catalog.ErrLocked, err)
+		}
+	})
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	t.Run("unlocked", search)
+}
+
+func TestBleveReset(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "index")
+	c, _, err := catalog.OpenBleve(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Index(context.Background(), map[string]catalog.Document{"old.png": &image.Image{}}); err != nil {
+		t.Fatal(err)
+	}
+	// imitates an index created by an older version.
+	if err = c.SetMeta("bleve-schema", []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, created, err := catalog.OpenBleve(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	if created {
+		t.Error("expect the index isn't created again")
+	}
+	if outdated, err := c.Outdated(); err != nil {
+		t.Fatal(err)
+	} else if !outdated {
+		t.Error("expect the index is outdated until it is reset")
+	}
+
+	if err = c.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	ids, err := c.IDs(context.Background(), 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("expect documents are removed, got %v", ids)
+	}
+	if outdated, err := c.Outdated(); err != nil {
+		t.Fatal(err)
+	} else if outdated {
+		t.Error("expect the reset index isn't outdated")
+	}
+
+	// checkpoints are matched as whole values by the current mapping.
+	err = c.Index(context.Background(), map[string]catalog.Document{
+		"new.png": &image.Image{Checkpoint: "Model-A", CreationTime: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := c.Search(context.Background(), &catalog.SearchRequest{
+		Filter: catalog.Filter{Checkpoint: "Model-A"},
+		Limit:  10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Hits) != 1 || res.Hits[0].ID != "new.png" {
+		t.Errorf("expect new.png is found with the current mapping, got %v", res.Hits)
+	}
+}
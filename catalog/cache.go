@@ -0,0 +1,51 @@
+// cache.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package catalog
+
+import "sync"
+
+// maxCachedResults is the maximum number of results kept in memory by each cache.
+const maxCachedResults = 128
+
+// resultCache memoizes results computed from the index until the index changes. The zero value is an empty cache.
+type resultCache[T any] struct {
+	mu sync.Mutex
+	// generation is incremented whenever the index changes so that results computed from an older index aren't cached.
+	generation int
+	entries    map[string]T
+}
+
+// get returns the result of the given key, whether it is cached, and the current generation.
+func (c *resultCache[T]) get(key string) (T, bool, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	res, ok := c.entries[key]
+	return res, ok, c.generation
+}
+
+// put caches the result of the given key computed in the given generation unless the index has changed since then.
+func (c *resultCache[T]) put(key string, generation int, res T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if c.entries == nil || len(c.entries) >= maxCachedResults {
+		c.entries = make(map[string]T)
+	}
+	c.entries[key] = res
+}
+
+// clear removes all the cached results since the index has changed.
+func (c *resultCache[T]) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.entries = nil
+}
@@ -0,0 +1,329 @@
+// catalog.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+// Package catalog defines the interface of search backends storing indexed images and failure records.
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/image"
+)
+
+// Names of fields which can be used for facets and term listings.
+const (
+	FieldCheckpoint = "checkpoint"
+	FieldSampler    = "sampler"
+	FieldLoRA       = "lora"
+)
+
+// Catalog stores images and failure records keyed by IDs, which are names of files in a library.
+type Catalog interface {
+	// Index adds or replaces the given documents, which are *image.Image or *image.Failure, keyed by their IDs.
+	Index(ctx context.Context, docs map[string]Document) error
+	// Delete removes documents of the given IDs.
+	Delete(ctx context.Context, ids ...string) error
+
+	// Search returns images matching the given filter.
+	Search(ctx context.Context, req *SearchRequest) (*SearchResult, error)
+	// Get returns the image of the given ID. It returns nil if the image doesn't exist.
+	Get(ctx context.Context, id string) (*image.Image, error)
+	// Lookup returns the image of the given ID with its neighbours in results of the given request, whose offset,
+	// limit and facets are ignored. It returns nil if the image doesn't exist.
+	Lookup(ctx context.Context, id string, req *SearchRequest) (*LookupResult, error)
+	// SearchFailures returns failure records matching the given request. Records are sorted in descending order of the
+	// times they failed.
+	SearchFailures(ctx context.Context, req *FailureRequest) (*FailureResult, error)
+	// Facets returns the most frequent terms of the given field in images matching the filter.
+	Facets(ctx context.Context, filter *Filter, field string, size int) ([]*Facet, error)
+	// Terms returns all terms of the given field in sorted order.
+	Terms(ctx context.Context, field string) ([]string, error)
+	// Similar returns images whose perceptual hashes are within the given Hamming distance, which is at most
+	// MaxHashDistance, from the hash of the image of the given ID in ascending order of distances. The image itself
+	// isn't included. It returns nil if the image doesn't exist, and an empty list if the image doesn't have a hash.
+	Similar(ctx context.Context, id string, distance int) ([]*SimilarHit, error)
+	// Duplicates returns groups of images whose perceptual hashes are within the given Hamming distance, which is at
+	// most MaxHashDistance, from the hash of the oldest image in each group. Groups are sorted in descending order of
+	// their sizes, and images in each group are sorted in ascending order of distances from the oldest one. Groups are
+	// cached until documents are indexed or deleted.
+	Duplicates(ctx context.Context, distance, offset, limit int) (*DuplicateResult, error)
+	// Related returns images whose prompts share terms with the prompt of the image of the given ID in descending order
+	// of relevance, where rare terms weigh more than common ones and boilerplate tags such as "masterpiece" are
+	// ignored. The image itself isn't included. It returns nil if the image doesn't exist.
+	Related(ctx context.Context, id string, offset, limit int) (*SearchResult, error)
+	// Suggest returns at most size terms of the given field starting with the prefix in descending order of the
+	// numbers of images having them. The field is FieldPrompt, whose terms are lower-case words, FieldCheckpoint,
+	// FieldSampler, or FieldLoRA. Zero size means no limits.
+	Suggest(ctx context.Context, field, prefix string, size int) ([]*Facet, error)
+	// Corrections returns at most size terms of the given field within a small edit distance from the term, e.g.
+	// candidates of "did you mean" for a misspelt word, in the same order as Suggest. The term itself isn't included,
+	// and terms shorter than three characters aren't corrected.
+	Corrections(ctx context.Context, field, term string, size int) ([]*Facet, error)
+	// Stats returns statistics of images matching the filter of the given request. Results are cached until documents
+	// are indexed or deleted.
+	Stats(ctx context.Context, req *StatsRequest) (*Stats, error)
+	// IDs returns IDs of documents of any types in the given range.
+	IDs(ctx context.Context, offset, limit int) ([]string, error)
+
+	// GetMeta returns metadata associated with the given key. It returns nil if the key doesn't exist.
+	GetMeta(key string) ([]byte, error)
+	// SetMeta associates the given metadata with the key.
+	SetMeta(key string, value []byte) error
+
+	// Outdated returns true if documents are stored with an older schema, e.g. created by an older version, which
+	// requires the catalog to be reset and documents to be indexed again.
+	Outdated() (bool, error)
+	// Reset removes all documents and metadata so that documents are indexed again with the current schema. It must
+	// not be called while the catalog is used by others.
+	Reset() error
+	Close() error
+}
+
+// Document is a document stored in a catalog, i.e. *image.Image or *image.Failure.
+type Document interface {
+	Type() string
+}
+
+// Order is the order of search results.
+type Order int
+
+const (
+	// Descending sorts images from the newest to the oldest.
+	Descending Order = iota
+	// Ascending sorts images from the oldest to the newest.
+	Ascending
+)
+
+// Sort keys of search results. Images having the same rating are sorted by their creation times.
+const (
+	SortCreated = "created"
+	SortRating  = "rating"
+	// SortPosition sorts images in the order of Filter.IDs, e.g. positions in a collection, regardless of Order.
+	SortPosition = "position"
+)
+
+// Filter restricts images to search. Zero values don't restrict anything.
+type Filter struct {
+	// ID restricts results to the image of the ID.
+	ID string
+	// IDs restricts results to images of the given IDs if it isn't nil. An empty slice matches no images.
+	IDs []string
+	// Prompt is a phrase contained in prompts.
+	Prompt string
+	// Query is a query string parsed by ParseQuery.
+	Query      Expr
+	Checkpoint string
+	// MinPixel and MaxPixel are the exclusive minimum and the inclusive maximum of the number of pixels.
+	MinPixel int
+	MaxPixel int
+	// After and Before are the inclusive start and the exclusive end of creation times.
+	After  time.Time
+	Before time.Time
+	// Favorite restricts results to favourite images if it is true.
+	Favorite bool
+	// MinRating is the inclusive minimum rating.
+	MinRating int
+	// Tags are tags which images must have all of.
+	Tags []string
+}
+
+// Size classes of images, which are ranges of the number of pixels.
+const (
+	SizeSmall  = "small"
+	SizeMedium = "medium"
+	SizeLarge  = "large"
+)
+
+// sizeClasses lists size classes in ascending order with the exclusive minimum and the inclusive maximum of the
+// number of pixels. Zero values mean no limits.
+var sizeClasses = []struct {
+	name     string
+	min, max int
+}{
+	{name: SizeSmall, max: 512 * 768},
+	{name: SizeMedium, min: 512 * 768, max: 512 * 768 * 4},
+	{name: SizeLarge, min: 512 * 768 * 4},
+}
+
+// SetSize restricts the number of pixels to the range of the given size class. Unknown classes don't restrict
+// anything.
+func (f *Filter) SetSize(size string) {
+	for _, c := range sizeClasses {
+		if c.name == size {
+			f.MinPixel, f.MaxPixel = c.min, c.max
+		}
+	}
+}
+
+// Names of facets which can be requested with search requests.
+const (
+	FacetCheckpoint = FieldCheckpoint
+	FacetSampler    = FieldSampler
+	FacetLoRA       = FieldLoRA
+	// FacetSize counts images in each size class.
+	FacetSize = "size"
+	// FacetCreated counts images created in each period, which is given by an interval.
+	FacetCreated = "created"
+	// FacetPromptTag counts tags of prompts separated by commas, which are in lower case without emphasis, weights,
+	// and extra networks such as LoRAs.
+	FacetPromptTag = "prompt-tag"
+	// FacetResolution counts resolutions given by the size parameter in metadata, e.g. 512x768.
+	FacetResolution = "resolution"
+)
+
+// Intervals of periods counted by FacetCreated.
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// FacetRequest requests counts of images matching a search request by terms of a facet.
+type FacetRequest struct {
+	// Name is the name of the facet.
+	Name string
+	// Size is the maximum number of the most frequent terms. For FacetCreated, it is the maximum number of the latest
+	// periods. Zero means no limits.
+	Size int
+	// Interval is the interval of periods for FacetCreated, which is IntervalMonth by default.
+	Interval string
+}
+
+// SearchRequest is a request to search images.
+type SearchRequest struct {
+	Filter Filter
+	// Sort is the sort key, which is SortCreated by default.
+	Sort   string
+	Order  Order
+	Offset int
+	Limit  int
+	// After is the Next cursor of the previous page to read the following page instead of Offset, which is ignored.
+	// Pages read with cursors neither skip nor repeat images even if images are indexed or removed between them, and
+	// reading a page costs the same regardless of its depth.
+	After []string
+	// Facets requests counts of all the matching images by terms of the given facets.
+	Facets []*FacetRequest
+}
+
+// SearchResult is a page of images.
+type SearchResult struct {
+	Total int
+	Hits  []*Hit
+	// Facets maps names of the requested facets to counts of terms. Term facets are sorted in descending order of
+	// counts, FacetSize in ascending order of sizes, and FacetCreated in chronological order of periods, which are
+	// represented by their first dates, e.g. 2026-01-01. Terms without images are omitted.
+	Facets map[string][]*Facet
+	// Next is the cursor given as After to read the page following this one. It is nil if the page is empty.
+	Next []string
+}
+
+// Hit is an image found in a catalog.
+type Hit struct {
+	ID    string
+	Image *image.Image
+}
+
+// LookupResult is an image and IDs of its neighbours in search results.
+type LookupResult struct {
+	Image *image.Image
+	// Previous and Next are IDs of the images before and after the image in the search results. They are empty if
+	// there are no such images or the image doesn't match the search request.
+	Previous string
+	Next     string
+}
+
+// FailureRequest is a request to search failure records.
+type FailureRequest struct {
+	// Classes are the classes of the records. Records of any class are returned if it is empty.
+	Classes []string
+	Offset  int
+	Limit   int
+	// After is the Next cursor of the previous page to read the following page instead of Offset, which is ignored.
+	After []string
+}
+
+// FailureResult is a page of failure records.
+type FailureResult struct {
+	Total int
+	Hits  []*FailureHit
+	// Next is the cursor given as After to read the page following this one. It is nil if the page is empty.
+	Next []string
+}
+
+// FailureHit is a failure record found in a catalog.
+type FailureHit struct {
+	ID      string
+	Failure *image.Failure
+}
+
+// MaxHashDistance is the maximum Hamming distance between perceptual hashes of similar images which can be searched.
+const MaxHashDistance = 7
+
+// SimilarHit is an image found by its perceptual hash.
+type SimilarHit struct {
+	ID    string
+	Image *image.Image
+	// Distance is the Hamming distance from the hash of the compared image.
+	Distance int
+}
+
+// DuplicateResult is a page of groups of duplicated images.
+type DuplicateResult struct {
+	Total int
+	// Groups are groups of duplicated images. The first image of each group is the oldest one, whose distance is zero,
+	// and the others have distances from it.
+	Groups [][]*SimilarHit
+}
+
+// StatsRequest is a request of statistics of images.
+type StatsRequest struct {
+	Filter Filter
+	// Interval is the interval of periods counting images, which is IntervalDay by default.
+	Interval string
+	// Periods is the maximum number of the latest periods. Zero means no limits.
+	Periods int
+	// Size is the maximum number of the most frequent terms of each list, e.g. checkpoints. Zero means no limits.
+	Size int
+}
+
+// Stats is statistics of images.
+type Stats struct {
+	// Total is the number of images.
+	Total int
+	// Created counts images created in each period in chronological order. Periods are represented by their first
+	// dates, e.g. 2026-01-01.
+	Created []*Facet
+	// Checkpoints are the most used checkpoints in descending order of the numbers of images.
+	Checkpoints []*CheckpointStats
+	// Samplers, PromptTags and Resolutions count the most frequent terms of FacetSampler, FacetPromptTag and
+	// FacetResolution in descending order of counts.
+	Samplers    []*Facet
+	PromptTags  []*Facet
+	Resolutions []*Facet
+	// Sizes counts images in each size class in ascending order of sizes.
+	Sizes []*Facet
+}
+
+// CheckpointStats is statistics of images generated with a checkpoint.
+type CheckpointStats struct {
+	Name  string
+	Count int
+	// AverageSteps and AverageCFGScale are averages of the parameters of images having them. They are zero if no
+	// images have them.
+	AverageSteps    float64
+	AverageCFGScale float64
+	// Created counts images generated with the checkpoint in the same periods as Stats.Created.
+	Created []*Facet
+}
+
+// Facet is a term and the number of images having it.
+type Facet struct {
+	Term  string
+	Count int
+}
@@ -0,0 +1,1148 @@
+// catalogtest.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+// Package catalogtest provides conformance tests which all catalog backends must pass.
+package catalogtest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/image"
+)
+
+var baseTime = time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+
+// testDocuments returns three images and a failure record. Two of the images are annotated.
+func testDocuments() map[string]catalog.Document {
+	return map[string]catalog.Document{
+		"a.png": &image.Image{
+			Prompt:       "a photo of a cat, best quality",
+			Checkpoint:   "model-a",
+			Pixel:        512 * 512,
+			CreationTime: baseTime,
+			Metadata:     map[string]string{"Steps": "20", "Sampler": "DPM++ 2M", "CFG scale": "7"},
+			Parameters:   "a photo of a cat, best quality\nSteps: 20, Sampler: DPM++ 2M, CFG scale: 7, Model: model-a",
+			Hash:         "0000000000000000",
+			Annotation: &image.Annotation{
+				Favorite: true,
+				Rating:   3,
+				Tags:     []string{"keeper", "to print"},
+				Note:     "print it for the wall",
+			},
+		},
+		"dir/b.png": &image.Image{
+			Prompt:         "a photo of a dog, <lora:fluffy:0.8>",
+			NegativePrompt: "cat",
+			Checkpoint:     "model-b",
+			Pixel:          1024 * 1024,
+			CreationTime:   baseTime.Add(time.Hour),
+			Metadata:       map[string]string{"Steps": "30", "Sampler": "Euler a", "CFG scale": "7.5"},
+			Hash:           "0000000000000003",
+			Annotation:     &image.Annotation{Rating: 5, Tags: []string{"keeper"}},
+		},
+		"old.zip!/c.webp": &image.Image{
+			Prompt:       "a painting of a cat, <lora:oil:0.6>",
+			Checkpoint:   "model-a",
+			Pixel:        2048 * 2048,
+			CreationTime: baseTime.Add(2 * time.Hour),
+			Metadata:     map[string]string{},
+			Hash:         "ffffffffffff0000",
+		},
+		"broken.png": &image.Failure{
+			Class:       image.ClassCorrupt,
+			Message:     "unexpected EOF",
+			Size:        123,
+			ModTime:     baseTime,
+			Fingerprint: "7b-0",
+			FailedAt:    baseTime.Add(time.Minute),
+		},
+	}
+}
+
+// Run runs the conformance tests. newCatalog must return an empty catalog, which is closed by the tests.
+func Run(t *testing.T, newCatalog func(t *testing.T) catalog.Catalog) {
+	open := func(t *testing.T) catalog.Catalog {
+		t.Helper()
+
+		c := newCatalog(t)
+		t.Cleanup(func() {
+			if err := c.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+		if err := c.Index(context.Background(), testDocuments()); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	t.Run("Search", func(t *testing.T) {
+		c := open(t)
+		cases := []struct {
+			name   string
+			req    catalog.SearchRequest
+			expect []string
+			total  int
+		}{
+			{
+				name:   "all",
+				req:    catalog.SearchRequest{Limit: 10},
+				expect: []string{"old.zip!/c.webp", "dir/b.png", "a.png"},
+			},
+			{
+				name:   "ascending",
+				req:    catalog.SearchRequest{Order: catalog.Ascending, Limit: 10},
+				expect: []string{"a.png", "dir/b.png", "old.zip!/c.webp"},
+			},
+			{
+				name:   "paging",
+				req:    catalog.SearchRequest{Offset: 1, Limit: 1},
+				expect: []string{"dir/b.png"},
+				total:  3,
+			},
+			{
+				name:   "prompt",
+				req:    catalog.SearchRequest{Filter: catalog.Filter{Prompt: "photo of"}, Limit: 10},
+				expect: []string{"dir/b.png", "a.png"},
+			},
+			{
+				name:   "checkpoint",
+				req:    catalog.SearchRequest{Filter: catalog.Filter{Checkpoint: "model-a"}, Limit: 10},
+				expect: []string{"old.zip!/c.webp", "a.png"},
+			},
+			{
+				name:   "id",
+				req:    catalog.SearchRequest{Filter: catalog.Filter{ID: "dir/b.png"}, Limit: 10},
+				expect: []string{"dir/b.png"},
+			},
+			{
+				name:   "id not matching",
+				req:    catalog.SearchRequest{Filter: catalog.Filter{ID: "dir/b.png", Checkpoint: "model-a"}, Limit: 10},
+				expect: []string{},
+			},
+			{
+				name:   "failure id",
+				req:    catalog.SearchRequest{Filter: catalog.Filter{ID: "broken.png"}, Limit: 10},
+				expect: []string{},
+			},
+			{
+				name: "pixel",
+				req: catalog.SearchRequest{
+					Filter: catalog.Filter{MinPixel: 512 * 512, MaxPixel: 2048 * 2048},
+					Limit:  10,
+				},
+				expect: []string{"old.zip!/c.webp", "dir/b.png"},
+			},
+			{
+				name: "creation time",
+				req: catalog.SearchRequest{
+					Filter: catalog.Filter{After: baseTime.Add(time.Hour), Before: baseTime.Add(2 * time.Hour)},
+					Limit:  10,
+				},
+				expect: []string{"dir/b.png"},
+			},
+			{
+				name: "combined",
+				req: catalog.SearchRequest{
+					Filter: catalog.Filter{Prompt: "cat", Checkpoint: "model-a", After: baseTime.Add(time.Minute)},
+					Limit:  10,
+				},
+				expect: []string{"old.zip!/c.webp"},
+			},
+			{
+				name:   "favorite",
+				req:    catalog.SearchRequest{Filter: catalog.Filter{Favorite: true}, Limit: 10},
+				expect: []string{"a.png"},
+			},
+			{
+				name:   "rating",
+				req:    catalog.SearchRequest{Filter: catalog.Filter{MinRating: 4}, Limit: 10},
+				expect: []string{"dir/b.png"},
+			},
+			{
+				name:   "tags",
+				req:    catalog.SearchRequest{Filter: catalog.Filter{Tags: []string{"keeper", "to print"}}, Limit: 10},
+				expect: []string{"a.png"},
+			},
+			{
+				name:   "sorted by rating",
+				req:    catalog.SearchRequest{Sort: catalog.SortRating, Limit: 10},
+				expect: []string{"dir/b.png", "a.png", "old.zip!/c.webp"},
+			},
+			{
+				name:   "sorted by rating in ascending order",
+				req:    catalog.SearchRequest{Sort: catalog.SortRating, Order: catalog.Ascending, Limit: 10},
+				expect: []string{"old.zip!/c.webp", "a.png", "dir/b.png"},
+			},
+			{
+				name: "ids",
+				req: catalog.SearchRequest{
+					Filter: catalog.Filter{IDs: []string{"a.png", "old.zip!/c.webp", "missing.png"}},
+					Limit:  10,
+				},
+				expect: []string{"old.zip!/c.webp", "a.png"},
+			},
+			{
+				name:   "empty ids",
+				req:    catalog.SearchRequest{Filter: catalog.Filter{IDs: []string{}}, Limit: 10},
+				expect: []string{},
+			},
+			{
+				name: "sorted by position",
+				req: catalog.SearchRequest{
+					Filter: catalog.Filter{IDs: []string{"dir/b.png", "missing.png", "old.zip!/c.webp", "a.png"}},
+					Sort:   catalog.SortPosition,
+					Limit:  10,
+				},
+				expect: []string{"dir/b.png", "old.zip!/c.webp", "a.png"},
+			},
+			{
+				name: "sorted by position with paging",
+				req: catalog.SearchRequest{
+					Filter: catalog.Filter{
+						Checkpoint: "model-a",
+						IDs:        []string{"dir/b.png", "old.zip!/c.webp", "a.png"},
+					},
+					Sort:   catalog.SortPosition,
+					Offset: 1,
+					Limit:  1,
+				},
+				expect: []string{"a.png"},
+				total:  2,
+			},
+		}
+		for _, v := range cases {
+			t.Run(v.name, func(t *testing.T) {
+				res, err := c.Search(context.Background(), &v.req)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				total := v.total
+				if total == 0 {
+					total = len(v.expect)
+				}
+				if res.Total != total {
+					t.Errorf("expect %v, got %v", total, res.Total)
+				}
+
+				ids := make([]string, len(res.Hits))
+				for i, h := range res.Hits {
+					ids[i] = h.ID
+				}
+				if strings.Join(ids, ",") != strings.Join(v.expect, ",") {
+					t.Errorf("expect %v, got %v", v.expect, ids)
+				}
+			})
+		}
+	})
+
+	t.Run("Cursor", func(t *testing.T) {
+		c := open(t)
+		cases := []struct {
+			name   string
+			req    catalog.SearchRequest
+			expect []string
+		}{
+			{
+				name:   "descending",
+				expect: []string{"old.zip!/c.webp", "dir/b.png", "a.png"},
+			},
+			{
+				name:   "ascending",
+				req:    catalog.SearchRequest{Order: catalog.Ascending},
+				expect: []string{"a.png", "dir/b.png", "old.zip!/c.webp"},
+			},
+			{
+				name:   "sorted by rating",
+				req:    catalog.SearchRequest{Sort: catalog.SortRating},
+				expect: []string{"dir/b.png", "a.png", "old.zip!/c.webp"},
+			},
+			{
+				name: "sorted by position",
+				req: catalog.SearchRequest{
+					Filter: catalog.Filter{IDs: []string{"dir/b.png", "missing.png", "old.zip!/c.webp", "a.png"}},
+					Sort:   catalog.SortPosition,
+				},
+				expect: []string{"dir/b.png", "old.zip!/c.webp", "a.png"},
+			},
+		}
+		for _, v := range cases {
+			t.Run(v.name, func(t *testing.T) {
+				req := v.req
+				req.Limit = 1
+
+				var ids []string
+				for i := 0; i != len(v.expect)+1; i++ {
+					res, err := c.Search(context.Background(), &req)
+					if err != nil {
+						t.Fatal(err)
+					}
+					for _, h := range res.Hits {
+						ids = append(ids, h.ID)
+					}
+					if len(res.Hits) == 0 {
+						if res.Next != nil {
+							t.Errorf("expect no cursors after the last page, got %v", res.Next)
+						}
+						break
+					}
+					// offsets are ignored with cursors.
+					req.After, req.Offset = res.Next, 10
+				}
+				if strings.Join(ids, ",") != strings.Join(v.expect, ",") {
+					t.Errorf("expect %v, got %v", v.expect, ids)
+				}
+			})
+		}
+
+		t.Run("new images", func(t *testing.T) {
+			res, err := c.Search(context.Background(), &catalog.SearchRequest{Limit: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = c.Index(context.Background(), map[string]catalog.Document{
+				"new.png": &image.Image{Prompt: "a new cat", CreationTime: baseTime.Add(time.Hour * 24)},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err = c.Search(context.Background(), &catalog.SearchRequest{Limit: 1, After: res.Next})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(res.Hits) != 1 || res.Hits[0].ID != "dir/b.png" {
+				t.Errorf("expect the second image not shifted by the new one, got %v", res.Hits)
+			}
+		})
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		c := open(t)
+		cases := []struct {
+			query  string
+			expect []string
+		}{
+			{query: "cat", expect: []string{"old.zip!/c.webp", "a.png"}},
+			{query: `"photo of a"`, expect: []string{"dir/b.png", "a.png"}},
+			{query: "photo -cat", expect: []string{"dir/b.png"}},
+			{query: "negative:cat", expect: []string{"dir/b.png"}},
+			{query: "dog OR painting", expect: []string{"old.zip!/c.webp", "dir/b.png"}},
+			{query: `sampler:"dpm++ 2m"`, expect: []string{"a.png"}},
+			{query: "steps:>20", expect: []string{"dir/b.png"}},
+			{query: "steps:>=20 cfg:<7.5", expect: []string{"a.png"}},
+			{query: "model:model-a pixel:>262144", expect: []string{"old.zip!/c.webp"}},
+			{query: "created:>=2023-04-01T01:00:00Z", expect: []string{"old.zip!/c.webp", "dir/b.png"}},
+			{query: "lora:oil", expect: []string{"old.zip!/c.webp"}},
+			{query: "NOT (cat OR dog)", expect: []string{}},
+			{query: "favorite:true", expect: []string{"a.png"}},
+			{query: "favorite:false", expect: []string{"old.zip!/c.webp", "dir/b.png"}},
+			{query: "rating:>=3", expect: []string{"dir/b.png", "a.png"}},
+			{query: "rating:0", expect: []string{"old.zip!/c.webp"}},
+			{query: `tag:keeper -tag:"to print"`, expect: []string{"dir/b.png"}},
+			{query: "note:wall", expect: []string{"a.png"}},
+		}
+		for _, v := range cases {
+			t.Run(v.query, func(t *testing.T) {
+				e, err := catalog.ParseQuery(v.query)
+				if err != nil {
+					t.Fatal(err)
+				}
+				res, err := c.Search(context.Background(), &catalog.SearchRequest{
+					Filter: catalog.Filter{Query: e},
+					Limit:  10,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				ids := make([]string, len(res.Hits))
+				for i, h := range res.Hits {
+					ids[i] = h.ID
+				}
+				if strings.Join(ids, ",") != strings.Join(v.expect, ",") {
+					t.Errorf("expect %v, got %v", v.expect, ids)
+				}
+			})
+		}
+	})
+
+	t.Run("SearchFacets", func(t *testing.T) {
+		c := open(t)
+
+		// periods are computed in the local time zone.
+		var days []string
+		counts := make(map[string]int)
+		for _, id := range []string{"a.png", "dir/b.png", "old.zip!/c.webp"} {
+			day := testDocuments()[id].(*image.Image).CreationTime.In(time.Local).Format(time.DateOnly)
+			if counts[day] == 0 {
+				days = append(days, day)
+			}
+			counts[day]++
+		}
+		var created []string
+		for _, day := range days {
+			created = append(created, fmt.Sprintf("%v:%v", day, counts[day]))
+		}
+
+		cases := []struct {
+			name   string
+			req    catalog.SearchRequest
+			expect map[string]string
+		}{
+			{
+				name: "all",
+				req: catalog.SearchRequest{
+					Limit: 1,
+					Facets: []*catalog.FacetRequest{
+						{Name: catalog.FacetCheckpoint, Size: 10},
+						{Name: catalog.FacetSampler, Size: 10},
+						{Name: catalog.FacetLoRA, Size: 10},
+						{Name: catalog.FacetSize},
+						{Name: catalog.FacetCreated, Interval: catalog.IntervalDay},
+					},
+				},
+				expect: map[string]string{
+					catalog.FacetCheckpoint: "model-a:2,model-b:1",
+					catalog.FacetSampler:    "DPM++ 2M:1,Euler a:1",
+					catalog.FacetLoRA:       "fluffy:1,oil:1",
+					catalog.FacetSize:       "small:1,medium:1,large:1",
+					catalog.FacetCreated:    strings.Join(created, ","),
+				},
+			},
+			{
+				name: "filtered",
+				req: catalog.SearchRequest{
+					Filter: catalog.Filter{Checkpoint: "model-a"},
+					Facets: []*catalog.FacetRequest{
+						{Name: catalog.FacetCheckpoint, Size: 10},
+						{Name: catalog.FacetLoRA, Size: 10},
+						{Name: catalog.FacetSize},
+					},
+				},
+				expect: map[string]string{
+					catalog.FacetCheckpoint: "model-a:2",
+					catalog.FacetLoRA:       "oil:1",
+					catalog.FacetSize:       "small:1,large:1",
+				},
+			},
+			{
+				name: "limited",
+				req: catalog.SearchRequest{
+					Facets: []*catalog.FacetRequest{
+						{Name: catalog.FacetCheckpoint, Size: 1},
+						{Name: catalog.FacetCreated, Size: 1, Interval: catalog.IntervalDay},
+					},
+				},
+				expect: map[string]string{
+					catalog.FacetCheckpoint: "model-a:2",
+					catalog.FacetCreated:    created[len(created)-1],
+				},
+			},
+			{
+				name: "no images",
+				req: catalog.SearchRequest{
+					Filter: catalog.Filter{Prompt: "fish"},
+					Facets: []*catalog.FacetRequest{
+						{Name: catalog.FacetCheckpoint, Size: 10},
+						{Name: catalog.FacetCreated, Interval: catalog.IntervalWeek},
+					},
+				},
+				expect: map[string]string{
+					catalog.FacetCheckpoint: "",
+					catalog.FacetCreated:    "",
+				},
+			},
+		}
+		for _, v := range cases {
+			t.Run(v.name, func(t *testing.T) {
+				res, err := c.Search(context.Background(), &v.req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(res.Facets) != len(v.expect) {
+					t.Errorf("expect %v facets, got %v", len(v.expect), len(res.Facets))
+				}
+				for name, expect := range v.expect {
+					var terms []string
+					for _, f := range res.Facets[name] {
+						terms = append(terms, fmt.Sprintf("%v:%v", f.Term, f.Count))
+					}
+					if strings.Join(terms, ",") != expect {
+						t.Errorf("expect %v of %v, got %v", expect, name, terms)
+					}
+				}
+			})
+		}
+
+		_, err := c.Search(context.Background(), &catalog.SearchRequest{
+			Facets: []*catalog.FacetRequest{{Name: catalog.FacetCreated, Interval: "year"}},
+		})
+		if err == nil {
+			t.Error("expect an error for an unknown interval")
+		}
+	})
+
+	t.Run("SearchReturnsImages", func(t *testing.T) {
+		c := open(t)
+		res, err := c.Search(context.Background(), &catalog.SearchRequest{Order: catalog.Ascending, Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Hits) != 1 {
+			t.Fatalf("expect 1 hit, got %v", len(res.Hits))
+		}
+
+		expect := testDocuments()["a.png"].(*image.Image)
+		img := res.Hits[0].Image
+		if img.Prompt != expect.Prompt {
+			t.Errorf("expect %v, got %v", expect.Prompt, img.Prompt)
+		}
+		if img.Checkpoint != expect.Checkpoint {
+			t.Errorf("expect %v, got %v", expect.Checkpoint, img.Checkpoint)
+		}
+		if img.Pixel != expect.Pixel {
+			t.Errorf("expect %v, got %v", expect.Pixel, img.Pixel)
+		}
+		if !img.CreationTime.Equal(expect.CreationTime) {
+			t.Errorf("expect %v, got %v", expect.CreationTime, img.CreationTime)
+		}
+		if img.Metadata["Steps"] != expect.Metadata["Steps"] {
+			t.Errorf("expect %v, got %v", expect.Metadata, img.Metadata)
+		}
+		if img.Hash != expect.Hash {
+			t.Errorf("expect %v, got %v", expect.Hash, img.Hash)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		c := open(t)
+		res, err := c.Get(context.Background(), "dir/b.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expect := testDocuments()["dir/b.png"].(*image.Image); res == nil || res.Prompt != expect.Prompt {
+			t.Errorf("expect %v, got %v", expect, res)
+		}
+		for _, id := range []string{"a.png", "old.zip!/c.webp"} {
+			res, err := c.Get(context.Background(), id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expect := testDocuments()[id].(*image.Image).Annotation; !reflect.DeepEqual(res.Annotation, expect) {
+				t.Errorf("expect %+v, got %+v", expect, res.Annotation)
+			}
+		}
+
+		// failures and files outside the library are not images.
+		for _, id := range []string{"missing.png", "broken.png", "../a.png"} {
+			res, err := c.Get(context.Background(), id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res != nil {
+				t.Errorf("expect %v isn't found, got %v", id, res)
+			}
+		}
+	})
+
+	t.Run("Lookup", func(t *testing.T) {
+		c := open(t)
+		cases := []struct {
+			name     string
+			id       string
+			req      catalog.SearchRequest
+			previous string
+			next     string
+		}{
+			{name: "descending", id: "dir/b.png", previous: "old.zip!/c.webp", next: "a.png"},
+			{
+				name:     "ascending",
+				id:       "dir/b.png",
+				req:      catalog.SearchRequest{Order: catalog.Ascending},
+				previous: "a.png",
+				next:     "old.zip!/c.webp",
+			},
+			{name: "first", id: "old.zip!/c.webp", next: "dir/b.png"},
+			{
+				name:     "filtered",
+				id:       "a.png",
+				req:      catalog.SearchRequest{Filter: catalog.Filter{Checkpoint: "model-a"}},
+				previous: "old.zip!/c.webp",
+			},
+			{
+				name: "not matching",
+				id:   "dir/b.png",
+				req:  catalog.SearchRequest{Filter: catalog.Filter{Checkpoint: "model-a"}},
+			},
+			{
+				name:     "sorted by rating",
+				id:       "a.png",
+				req:      catalog.SearchRequest{Sort: catalog.SortRating},
+				previous: "dir/b.png",
+				next:     "old.zip!/c.webp",
+			},
+			{
+				name: "sorted by position",
+				id:   "old.zip!/c.webp",
+				req: catalog.SearchRequest{
+					Filter: catalog.Filter{IDs: []string{"dir/b.png", "missing.png", "old.zip!/c.webp", "a.png"}},
+					Sort:   catalog.SortPosition,
+				},
+				previous: "dir/b.png",
+				next:     "a.png",
+			},
+		}
+		for _, v := range cases {
+			t.Run(v.name, func(t *testing.T) {
+				res, err := c.Lookup(context.Background(), v.id, &v.req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if res == nil {
+					t.Fatalf("expect %v is found", v.id)
+				}
+				if expect := testDocuments()[v.id].(*image.Image); res.Image.Prompt != expect.Prompt {
+					t.Errorf("expect %v, got %v", expect.Prompt, res.Image.Prompt)
+				}
+				if res.Previous != v.previous {
+					t.Errorf("expect previous %q, got %q", v.previous, res.Previous)
+				}
+				if res.Next != v.next {
+					t.Errorf("expect next %q, got %q", v.next, res.Next)
+				}
+			})
+		}
+
+		res, err := c.Lookup(context.Background(), "a.png", &catalog.SearchRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expect := testDocuments()["a.png"].(*image.Image); res.Image.Parameters != expect.Parameters {
+			t.Errorf("expect %q, got %q", expect.Parameters, res.Image.Parameters)
+		}
+
+		for _, id := range []string{"missing.png", "broken.png"} {
+			res, err := c.Lookup(context.Background(), id, &catalog.SearchRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res != nil {
+				t.Errorf("expect %v isn't found, got %v", id, res.Image)
+			}
+		}
+	})
+
+	t.Run("SearchFailures", func(t *testing.T) {
+		c := open(t)
+		res, err := c.SearchFailures(context.Background(), &catalog.FailureRequest{Limit: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Total != 1 || len(res.Hits) != 1 {
+			t.Fatalf("expect 1 failure, got %v", res.Total)
+		}
+
+		expect := testDocuments()["broken.png"].(*image.Failure)
+		hit := res.Hits[0]
+		if hit.ID != "broken.png" {
+			t.Errorf("expect %v, got %v", "broken.png", hit.ID)
+		}
+		if hit.Failure.Class != expect.Class {
+			t.Errorf("expect %v, got %v", expect.Class, hit.Failure.Class)
+		}
+		if hit.Failure.Size != expect.Size {
+			t.Errorf("expect %v, got %v", expect.Size, hit.Failure.Size)
+		}
+		if hit.Failure.Fingerprint != expect.Fingerprint {
+			t.Errorf("expect %v, got %v", expect.Fingerprint, hit.Failure.Fingerprint)
+		}
+		if !hit.Failure.FailedAt.Equal(expect.FailedAt) {
+			t.Errorf("expect %v, got %v", expect.FailedAt, hit.Failure.FailedAt)
+		}
+
+		res, err = c.SearchFailures(context.Background(), &catalog.FailureRequest{
+			Classes: []string{image.ClassIO}, Limit: 10,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Total != 0 {
+			t.Errorf("expect no failures, got %v", res.Total)
+		}
+
+		// records failed at the same time are also paged by cursors without skipping or repeating them.
+		docs := make(map[string]catalog.Document)
+		for _, id := range []string{"x.png", "y.png", "z.png"} {
+			docs[id] = &image.Failure{Class: image.ClassCorrupt, FailedAt: expect.FailedAt}
+		}
+		if err = c.Index(context.Background(), docs); err != nil {
+			t.Fatal(err)
+		}
+		seen := make(map[string]bool)
+		req := &catalog.FailureRequest{Limit: 1}
+		for {
+			res, err = c.SearchFailures(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(res.Hits) == 0 {
+				break
+			}
+			if seen[res.Hits[0].ID] {
+				t.Fatalf("expect each record is read once, got %v again", res.Hits[0].ID)
+			}
+			seen[res.Hits[0].ID] = true
+			req.After = res.Next
+		}
+		if len(seen) != 4 {
+			t.Errorf("expect 4 records, got %v", seen)
+		}
+	})
+
+	t.Run("Facets", func(t *testing.T) {
+		c := open(t)
+		res, err := c.Facets(context.Background(), &catalog.Filter{}, catalog.FieldCheckpoint, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res) != 2 {
+			t.Fatalf("expect 2 facets, got %v", len(res))
+		}
+		if res[0].Term != "model-a" || res[0].Count != 2 {
+			t.Errorf("expect model-a: 2, got %v: %v", res[0].Term, res[0].Count)
+		}
+		if res[1].Term != "model-b" || res[1].Count != 1 {
+			t.Errorf("expect model-b: 1, got %v: %v", res[1].Term, res[1].Count)
+		}
+
+		res, err = c.Facets(context.Background(), &catalog.Filter{Prompt: "dog"}, catalog.FieldCheckpoint, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res) != 1 || res[0].Term != "model-b" || res[0].Count != 1 {
+			t.Errorf("expect [model-b: 1], got %v", res)
+		}
+	})
+
+	t.Run("Similar", func(t *testing.T) {
+		c := open(t)
+		cases := []struct {
+			id       string
+			distance int
+			expect   string
+		}{
+			{id: "a.png", distance: catalog.MaxHashDistance, expect: "dir/b.png:2"},
+			{id: "dir/b.png", distance: 2, expect: "a.png:2"},
+			{id: "dir/b.png", distance: 1, expect: ""},
+			{id: "old.zip!/c.webp", distance: catalog.MaxHashDistance, expect: ""},
+		}
+		for _, v := range cases {
+			t.Run(fmt.Sprintf("%v-%v", v.id, v.distance), func(t *testing.T) {
+				res, err := c.Similar(context.Background(), v.id, v.distance)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var hits []string
+				for _, h := range res {
+					hits = append(hits, fmt.Sprintf("%v:%v", h.ID, h.Distance))
+				}
+				if strings.Join(hits, ",") != v.expect {
+					t.Errorf("expect %v, got %v", v.expect, hits)
+				}
+			})
+		}
+
+		if res, err := c.Similar(context.Background(), "missing.png", 0); err != nil || res != nil {
+			t.Errorf("expect nil, got %v, %v", res, err)
+		}
+		if _, err := c.Similar(context.Background(), "a.png", catalog.MaxHashDistance+1); err == nil {
+			t.Error("expect an error for a too large distance")
+		}
+	})
+
+	t.Run("Duplicates", func(t *testing.T) {
+		c := open(t)
+		// an exact duplicate of a.png is newer than b.png.
+		err := c.Index(context.Background(), map[string]catalog.Document{
+			"copy.png": &image.Image{
+				Prompt:       "copy",
+				CreationTime: baseTime.Add(3 * time.Hour),
+				Hash:         "0000000000000000",
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cases := []struct {
+			distance int
+			offset   int
+			limit    int
+			total    int
+			expect   string
+		}{
+			{distance: 2, limit: 10, total: 1, expect: "[a.png:0 copy.png:0 dir/b.png:2]"},
+			{distance: 0, limit: 10, total: 1, expect: "[a.png:0 copy.png:0]"},
+			{distance: 2, offset: 1, limit: 10, total: 1, expect: ""},
+		}
+		for _, v := range cases {
+			t.Run(fmt.Sprintf("%v-%v", v.distance, v.offset), func(t *testing.T) {
+				res, err := c.Duplicates(context.Background(), v.distance, v.offset, v.limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var groups []string
+				for _, g := range res.Groups {
+					var hits []string
+					for _, h := range g {
+						hits = append(hits, fmt.Sprintf("%v:%v", h.ID, h.Distance))
+					}
+					groups = append(groups, fmt.Sprint(hits))
+				}
+				if res.Total != v.total || strings.Join(groups, ",") != v.expect {
+					t.Errorf("expect %v groups %v, got %v groups %v", v.total, v.expect, res.Total, groups)
+				}
+			})
+		}
+
+		// cached groups are discarded when images are removed.
+		if err = c.Delete(context.Background(), "copy.png"); err != nil {
+			t.Fatal(err)
+		}
+		res, err := c.Duplicates(context.Background(), 0, 0, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Total != 0 || len(res.Groups) != 0 {
+			t.Errorf("expect no groups, got %v groups %v", res.Total, res.Groups)
+		}
+	})
+
+	t.Run("Related", func(t *testing.T) {
+		c := open(t)
+		// boilerplate tags shared with quality.png and a.png are ignored.
+		err := c.Index(context.Background(), map[string]catalog.Document{
+			"tabby.png": &image.Image{
+				Prompt:       "(masterpiece:1.2), best_quality, a photo of a tabby cat",
+				CreationTime: baseTime.Add(3 * time.Hour),
+			},
+			"quality.png": &image.Image{
+				Prompt:       "best quality, masterpiece, a landscape",
+				CreationTime: baseTime.Add(4 * time.Hour),
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cases := []struct {
+			id     string
+			offset int
+			limit  int
+			expect []string
+			total  int
+		}{
+			{id: "tabby.png", limit: 10, expect: []string{"a.png", "old.zip!/c.webp", "dir/b.png"}, total: 3},
+			{id: "tabby.png", offset: 1, limit: 1, expect: []string{"old.zip!/c.webp"}, total: 3},
+			{id: "dir/b.png", limit: 10, expect: []string{"a.png", "tabby.png"}, total: 2},
+			{id: "quality.png", limit: 10, total: 0},
+		}
+		for _, v := range cases {
+			t.Run(fmt.Sprintf("%v-%v", v.id, v.offset), func(t *testing.T) {
+				res, err := c.Related(context.Background(), v.id, v.offset, v.limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var ids []string
+				for _, h := range res.Hits {
+					ids = append(ids, h.ID)
+				}
+				if res.Total != v.total || !reflect.DeepEqual(ids, v.expect) {
+					t.Errorf("expect %v images %v, got %v images %v", v.total, v.expect, res.Total, ids)
+				}
+			})
+		}
+
+		if res, err := c.Related(context.Background(), "missing.png", 0, 10); err != nil || res != nil {
+			t.Errorf("expect nil, got %v, %v", res, err)
+		}
+	})
+
+	t.Run("Suggest", func(t *testing.T) {
+		c := open(t)
+		cases := []struct {
+			field  string
+			prefix string
+			size   int
+			expect string
+		}{
+			{field: catalog.FieldPrompt, prefix: "p", expect: "photo:2,painting:1"},
+			{field: catalog.FieldPrompt, prefix: "p", size: 1, expect: "photo:2"},
+			{field: catalog.FieldPrompt, prefix: "Ca", expect: "cat:2"},
+			{field: catalog.FieldPrompt, prefix: "x", expect: ""},
+			{field: catalog.FieldCheckpoint, prefix: "model", expect: "model-a:2,model-b:1"},
+			{field: catalog.FieldSampler, prefix: "Euler", expect: "Euler a:1"},
+			{field: catalog.FieldLoRA, prefix: "", expect: "fluffy:1,oil:1"},
+		}
+		for _, v := range cases {
+			t.Run(fmt.Sprintf("%v-%v-%v", v.field, v.prefix, v.size), func(t *testing.T) {
+				res, err := c.Suggest(context.Background(), v.field, v.prefix, v.size)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if s := joinFacets(res); s != v.expect {
+					t.Errorf("expect %v, got %v", v.expect, s)
+				}
+			})
+		}
+
+		if _, err := c.Suggest(context.Background(), "hash", "0", 10); err == nil {
+			t.Error("expect an error for an unsupported field")
+		}
+	})
+
+	t.Run("Stats", func(t *testing.T) {
+		c := open(t)
+
+		// periods are computed in the local time zone.
+		day := func(d time.Duration) string {
+			return baseTime.Add(d).In(time.Local).Format(time.DateOnly)
+		}
+		summary := func(s *catalog.Stats) map[string]string {
+			res := map[string]string{
+				"total":       fmt.Sprint(s.Total),
+				"created":     joinFacets(s.Created),
+				"samplers":    joinFacets(s.Samplers),
+				"prompt-tags": joinFacets(s.PromptTags),
+				"resolutions": joinFacets(s.Resolutions),
+				"sizes":       joinFacets(s.Sizes),
+			}
+			var checkpoints []string
+			for _, v := range s.Checkpoints {
+				checkpoints = append(checkpoints, fmt.Sprintf(
+					"%v:%v:%v:%v:[%v]", v.Name, v.Count, v.AverageSteps, v.AverageCFGScale, joinFacets(v.Created)),
+				)
+			}
+			res["checkpoints"] = strings.Join(checkpoints, ",")
+			return res
+		}
+		// counts returns the given days and their counts, which are merged if they are the same local date.
+		counts := func(days ...string) string {
+			var res []string
+			for i := 0; i < len(days); {
+				j := i
+				for j < len(days) && days[j] == days[i] {
+					j++
+				}
+				res = append(res, fmt.Sprintf("%v:%v", days[i], j-i))
+				i = j
+			}
+			return strings.Join(res, ",")
+		}
+
+		res, err := c.Stats(context.Background(), &catalog.StatsRequest{Size: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect := map[string]string{
+			"total":   "3",
+			"created": counts(day(0), day(time.Hour), day(2*time.Hour)),
+			"checkpoints": fmt.Sprintf(
+				"model-a:2:20:7:[%v],model-b:1:30:7.5:[%v]",
+				counts(day(0), day(2*time.Hour)), counts(day(time.Hour)),
+			),
+			"samplers":    "DPM++ 2M:1,Euler a:1",
+			"prompt-tags": "a painting of a cat:1,a photo of a cat:1,a photo of a dog:1,best quality:1",
+			"resolutions": "",
+			"sizes":       "small:1,medium:1,large:1",
+		}
+		if s := summary(res); !reflect.DeepEqual(s, expect) {
+			t.Errorf("expect %v, got %v", expect, s)
+		}
+		if cached, err := c.Stats(context.Background(), &catalog.StatsRequest{Size: 10}); err != nil || cached != res {
+			t.Errorf("expect cached statistics, got %v, %v", cached, err)
+		}
+
+		// cached statistics are discarded when images are indexed.
+		err = c.Index(context.Background(), map[string]catalog.Document{
+			"d.png": &image.Image{
+				Prompt:       "(masterpiece:1.2), a photo of a cat, <lora:fluffy:1>",
+				Checkpoint:   "model-b",
+				Pixel:        512 * 768,
+				CreationTime: baseTime.Add(24 * time.Hour),
+				Metadata: map[string]string{
+					"Steps": "40", "Sampler": "Euler a", "CFG scale": "5.5", "Size": "512x768",
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err = c.Stats(context.Background(), &catalog.StatsRequest{Periods: 1, Size: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect = map[string]string{
+			"total":       "4",
+			"created":     counts(day(24 * time.Hour)),
+			"checkpoints": fmt.Sprintf("model-a:2:20:7:[],model-b:2:35:6.5:[%v]", counts(day(24*time.Hour))),
+			"samplers":    "Euler a:2,DPM++ 2M:1",
+			"prompt-tags": "a photo of a cat:2,a painting of a cat:1",
+			"resolutions": "512x768:1",
+			"sizes":       "small:2,medium:1,large:1",
+		}
+		if s := summary(res); !reflect.DeepEqual(s, expect) {
+			t.Errorf("expect %v, got %v", expect, s)
+		}
+
+		res, err = c.Stats(context.Background(), &catalog.StatsRequest{
+			Filter:   catalog.Filter{Checkpoint: "model-a"},
+			Interval: catalog.IntervalMonth,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Total != 2 || len(res.Checkpoints) != 1 || res.Checkpoints[0].Name != "model-a" {
+			t.Errorf("expect statistics of model-a, got %v", summary(res))
+		}
+
+		_, err = c.Stats(context.Background(), &catalog.StatsRequest{Interval: "year"})
+		if err == nil {
+			t.Error("expect an error for an unknown interval")
+		}
+	})
+
+	t.Run("Corrections", func(t *testing.T) {
+		c := open(t)
+		cases := []struct {
+			field  string
+			term   string
+			expect string
+		}{
+			{field: catalog.FieldPrompt, term: "phto", expect: "photo:2"},
+			{field: catalog.FieldPrompt, term: "Paintng", expect: "painting:1"},
+			{field: catalog.FieldPrompt, term: "painting", expect: ""},
+			{field: catalog.FieldPrompt, term: "ct", expect: ""},
+			{field: catalog.FieldCheckpoint, term: "modl-a", expect: "model-a:2,model-b:1"},
+		}
+		for _, v := range cases {
+			t.Run(fmt.Sprintf("%v-%v", v.field, v.term), func(t *testing.T) {
+				res, err := c.Corrections(context.Background(), v.field, v.term, 10)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if s := joinFacets(res); s != v.expect {
+					t.Errorf("expect %v, got %v", v.expect, s)
+				}
+			})
+		}
+	})
+
+	t.Run("Terms", func(t *testing.T) {
+		c := open(t)
+		res, err := c.Terms(context.Background(), catalog.FieldCheckpoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(res, ",") != "model-a,model-b" {
+			t.Errorf("expect [model-a model-b], got %v", res)
+		}
+	})
+
+	t.Run("IDsAndDelete", func(t *testing.T) {
+		c := open(t)
+		ids, err := c.IDs(context.Background(), 0, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != len(testDocuments()) {
+			t.Errorf("expect %v, got %v", len(testDocuments()), ids)
+		}
+
+		if err = c.Delete(context.Background(), "a.png", "broken.png"); err != nil {
+			t.Fatal(err)
+		}
+		ids, err = c.IDs(context.Background(), 0, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != len(testDocuments())-2 {
+			t.Errorf("expect %v, got %v", len(testDocuments())-2, ids)
+		}
+		for _, id := range ids {
+			if id == "a.png" || id == "broken.png" {
+				t.Errorf("expect %v is deleted", id)
+			}
+		}
+	})
+
+	t.Run("ReplaceFailure", func(t *testing.T) {
+		c := open(t)
+		err := c.Index(context.Background(), map[string]catalog.Document{
+			"broken.png": &image.Image{Prompt: "fixed", CreationTime: baseTime.Add(3 * time.Hour)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		failures, err := c.SearchFailures(context.Background(), &catalog.FailureRequest{Limit: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if failures.Total != 0 {
+			t.Errorf("expect no failures, got %v", failures.Total)
+		}
+
+		res, err := c.Search(context.Background(), &catalog.SearchRequest{Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Total != 4 || res.Hits[0].ID != "broken.png" {
+			t.Errorf("expect broken.png is an image, got %v", res.Hits)
+		}
+	})
+
+	t.Run("Meta", func(t *testing.T) {
+		c := open(t)
+		v, err := c.GetMeta("key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != nil {
+			t.Errorf("expect nil, got %v", v)
+		}
+
+		if err = c.SetMeta("key", []byte("value")); err != nil {
+			t.Fatal(err)
+		}
+		v, err = c.GetMeta("key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(v) != "value" {
+			t.Errorf("expect %v, got %v", "value", string(v))
+		}
+	})
+}
+
+// joinFacets returns the given terms and their counts in the form of "term:count" separated by commas.
+func joinFacets(facets []*catalog.Facet) string {
+	res := make([]string, len(facets))
+	for i, f := range facets {
+		res[i] = fmt.Sprintf("%v:%v", f.Term, f.Count)
+	}
+	return strings.Join(res, ",")
+}
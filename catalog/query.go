@@ -0,0 +1,413 @@
+// query.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package catalog
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Names of fields which can be used in query strings in addition to FieldCheckpoint and FieldLoRA. The other names
+// refer to parameters in metadata, whose names are given by ParamName, e.g. "sampler" and "cfg-scale".
+const (
+	FieldPrompt         = "prompt"
+	FieldNegativePrompt = "negative-prompt"
+	FieldPixel          = "pixel"
+	FieldCreated        = "created"
+	// FieldFavorite, FieldRating, FieldTag and FieldNote refer to annotations, e.g. `favorite:true` and `rating:>=4`.
+	FieldFavorite = "favorite"
+	FieldRating   = "rating"
+	FieldTag      = "tag"
+	FieldNote     = "note"
+)
+
+// fieldAliases maps short names of fields to their names.
+var fieldAliases = map[string]string{
+	"negative": FieldNegativePrompt,
+	"model":    FieldCheckpoint,
+	"cfg":      ParamName("CFG scale"),
+}
+
+var fieldNamePattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// ParamName returns the name of a parameter in metadata used in query strings, which is the lower-cased key with
+// spaces replaced by hyphens, e.g. "cfg-scale" for "CFG scale".
+func ParamName(key string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), " ", "-")
+}
+
+// Expr is an expression of a query string.
+type Expr interface {
+	expr()
+}
+
+// AndExpr matches images matching all the expressions.
+type AndExpr struct {
+	Exprs []Expr
+}
+
+// OrExpr matches images matching any of the expressions.
+type OrExpr struct {
+	Exprs []Expr
+}
+
+// NotExpr matches images not matching the expression.
+type NotExpr struct {
+	Expr Expr
+}
+
+// MatchExpr matches images whose field contains the value. Phrase is true if the value was quoted.
+type MatchExpr struct {
+	Field  string
+	Value  string
+	Phrase bool
+}
+
+// Range operators.
+const (
+	OpLess         = "<"
+	OpLessEqual    = "<="
+	OpGreater      = ">"
+	OpGreaterEqual = ">="
+)
+
+// RangeExpr matches images whose field compares with the value by the operator. Time is used for FieldCreated, and
+// Number is used for the others.
+type RangeExpr struct {
+	Field  string
+	Op     string
+	Number float64
+	Time   time.Time
+}
+
+func (*AndExpr) expr()   {}
+func (*OrExpr) expr()    {}
+func (*NotExpr) expr()   {}
+func (*MatchExpr) expr() {}
+func (*RangeExpr) expr() {}
+
+// QueryError is an error in a query string. Pos is the 0-based index of the character where the problem is.
+type QueryError struct {
+	Pos int
+	Msg string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("%v at position %v", e.Msg, e.Pos+1)
+}
+
+// ParseQuery parses a query string.
+//
+// A query is a list of terms, which an image must match all of. A term is a word or a quoted phrase, optionally
+// prefixed with a field name and a colon, e.g. `prompt:"red dress"` and `sampler:euler`. Terms without field names
+// search prompts. Numeric fields and the creation time can be compared with <, <=, > and >=, e.g. `steps:>30` and
+// `created:>=2026-01-01`. Terms can be combined with OR, negated with a leading - or NOT, and grouped with parentheses.
+func ParseQuery(s string) (Expr, error) {
+	tokens, err := tokenize([]rune(s))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, end: len([]rune(s))}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t != nil {
+		return nil, &QueryError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+	}
+	if e == nil {
+		return nil, &QueryError{Pos: 0, Msg: "empty query"}
+	}
+	return e, nil
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenPhrase
+	tokenOpen
+	tokenClose
+	tokenMinus
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// pos and end are the indices of the first character and the character after the token.
+	pos, end int
+}
+
+func tokenize(s []rune) ([]*token, error) {
+	var res []*token
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(' || c == ')':
+			kind := tokenOpen
+			if c == ')' {
+				kind = tokenClose
+			}
+			res = append(res, &token{kind: kind, text: string(c), pos: i, end: i + 1})
+			i++
+		case c == '-' && (i == 0 || isBoundary(s[i-1])) && i+1 < len(s) && !unicode.IsSpace(s[i+1]):
+			res = append(res, &token{kind: tokenMinus, text: "-", pos: i, end: i + 1})
+			i++
+		case c == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				b.WriteRune(s[j])
+			}
+			if j == len(s) {
+				return nil, &QueryError{Pos: i, Msg: "unterminated quoted phrase"}
+			}
+			res = append(res, &token{kind: tokenPhrase, text: b.String(), pos: i, end: j + 1})
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && !unicode.IsSpace(s[j]) && s[j] != '(' && s[j] != ')' && s[j] != '"' {
+				j++
+			}
+			res = append(res, &token{kind: tokenWord, text: string(s[i:j]), pos: i, end: j})
+			i = j
+		}
+	}
+	return res, nil
+}
+
+func isBoundary(c rune) bool {
+	return unicode.IsSpace(c) || c == '('
+}
+
+type parser struct {
+	tokens []*token
+	i      int
+	// end is the length of the query, which is the position of errors at the end.
+	end int
+}
+
+func (p *parser) peek() *token {
+	if p.i == len(p.tokens) {
+		return nil
+	}
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() *token {
+	t := p.peek()
+	if t != nil {
+		p.i++
+	}
+	return t
+}
+
+func isKeyword(t *token, keyword string) bool {
+	return t != nil && t.kind == tokenWord && t.text == keyword
+}
+
+// parseOr parses terms combined with OR. It returns nil if there are no terms.
+func (p *parser) parseOr() (Expr, error) {
+	var exprs []Expr
+	for {
+		e, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if e == nil {
+			if len(exprs) != 0 {
+				return nil, &QueryError{Pos: p.pos(), Msg: "missing a term after OR"}
+			}
+			return nil, nil
+		}
+		exprs = append(exprs, e)
+
+		if !isKeyword(p.peek(), "OR") {
+			break
+		}
+		p.next()
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return &OrExpr{Exprs: exprs}, nil
+}
+
+// parseAnd parses a list of terms, optionally separated by AND. It returns nil if there are no terms.
+func (p *parser) parseAnd() (Expr, error) {
+	var exprs []Expr
+	for {
+		t := p.peek()
+		if t == nil || t.kind == tokenClose || isKeyword(t, "OR") {
+			break
+		}
+		if isKeyword(t, "AND") {
+			if len(exprs) == 0 {
+				return nil, &QueryError{Pos: t.pos, Msg: "missing a term before AND"}
+			}
+			p.next()
+			if t := p.peek(); t == nil || t.kind == tokenClose || isKeyword(t, "OR") || isKeyword(t, "AND") {
+				return nil, &QueryError{Pos: p.pos(), Msg: "missing a term after AND"}
+			}
+			continue
+		}
+
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+
+	switch len(exprs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return exprs[0], nil
+	default:
+		return &AndExpr{Exprs: exprs}, nil
+	}
+}
+
+// parseUnary parses a negated term, a group or a term.
+func (p *parser) parseUnary() (Expr, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenMinus || isKeyword(t, "NOT"):
+		if n := p.peek(); n == nil || n.kind == tokenClose || isKeyword(n, "OR") || isKeyword(n, "AND") {
+			return nil, &QueryError{Pos: p.pos(), Msg: fmt.Sprintf("missing a term after %v", t.text)}
+		}
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &NotExpr{Expr: e}, nil
+
+	case t.kind == tokenOpen:
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); c == nil || c.kind != tokenClose {
+			return nil, &QueryError{Pos: t.pos, Msg: "unbalanced parenthesis"}
+		}
+		if e == nil {
+			return nil, &QueryError{Pos: t.pos, Msg: "empty group"}
+		}
+		return e, nil
+
+	case t.kind == tokenClose:
+		return nil, &QueryError{Pos: t.pos, Msg: "unbalanced parenthesis"}
+
+	case t.kind == tokenPhrase:
+		return &MatchExpr{Field: FieldPrompt, Value: t.text, Phrase: true}, nil
+
+	default:
+		return p.parseTerm(t)
+	}
+}
+
+// parseTerm parses a word, which can be a field name followed by a value or a quoted phrase.
+func (p *parser) parseTerm(t *token) (Expr, error) {
+	name, value, ok := strings.Cut(t.text, ":")
+	if !ok || !fieldNamePattern.MatchString(strings.ToLower(name)) {
+		return &MatchExpr{Field: FieldPrompt, Value: t.text}, nil
+	}
+	field := strings.ToLower(name)
+	if v, ok := fieldAliases[field]; ok {
+		field = v
+	}
+	valuePos := t.pos + len([]rune(name)) + 1
+
+	if value == "" {
+		// a quoted phrase must follow the colon immediately.
+		n := p.peek()
+		if n == nil || n.kind != tokenPhrase || n.pos != t.end {
+			return nil, &QueryError{Pos: valuePos, Msg: fmt.Sprintf("missing a value of %v", name)}
+		}
+		p.next()
+		switch field {
+		case FieldCreated:
+			return nil, &QueryError{Pos: valuePos, Msg: "creation times must be compared with <, <=, > or >="}
+		case FieldFavorite, FieldRating:
+			return nil, &QueryError{Pos: valuePos, Msg: fmt.Sprintf("%v can't be a quoted phrase", name)}
+		}
+		return &MatchExpr{Field: field, Value: n.text, Phrase: true}, nil
+	}
+
+	for _, op := range []string{OpLessEqual, OpGreaterEqual, OpLess, OpGreater} {
+		v, ok := strings.CutPrefix(value, op)
+		if !ok {
+			continue
+		}
+		return parseRange(field, op, v, valuePos+len(op))
+	}
+	switch field {
+	case FieldCreated:
+		return nil, &QueryError{Pos: valuePos, Msg: "creation times must be compared with <, <=, > or >="}
+	case FieldFavorite:
+		if value != "true" && value != "false" {
+			return nil, &QueryError{Pos: valuePos, Msg: fmt.Sprintf("%v must be true or false", name)}
+		}
+	case FieldRating:
+		// a rating matches images having exactly the rating.
+		ge, err := parseRange(field, OpGreaterEqual, value, valuePos)
+		if err != nil {
+			return nil, err
+		}
+		le := *ge.(*RangeExpr)
+		le.Op = OpLessEqual
+		return &AndExpr{Exprs: []Expr{ge, &le}}, nil
+	}
+	return &MatchExpr{Field: field, Value: value}, nil
+}
+
+func parseRange(field, op, value string, pos int) (Expr, error) {
+	if value == "" {
+		return nil, &QueryError{Pos: pos, Msg: fmt.Sprintf("missing a value after %v", op)}
+	}
+
+	if field == FieldCreated {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t, err = time.ParseInLocation(time.DateOnly, value, time.Local)
+		}
+		if err != nil {
+			return nil, &QueryError{Pos: pos, Msg: fmt.Sprintf("invalid date %q", value)}
+		}
+		return &RangeExpr{Field: field, Op: op, Time: t}, nil
+	}
+
+	switch field {
+	case FieldPrompt, FieldNegativePrompt, FieldCheckpoint, FieldFavorite, FieldTag, FieldNote:
+		return nil, &QueryError{Pos: pos - len(op), Msg: fmt.Sprintf("%v can't be compared with %v", field, op)}
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, &QueryError{Pos: pos, Msg: fmt.Sprintf("invalid number %q", value)}
+	}
+	return &RangeExpr{Field: field, Op: op, Number: n}, nil
+}
+
+// pos returns the position of the next token, or the end of the query if there are no more tokens.
+func (p *parser) pos() int {
+	if t := p.peek(); t != nil {
+		return t.pos
+	}
+	return p.end
+}
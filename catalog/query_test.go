@@ -0,0 +1,136 @@
+// query_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package catalog_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+)
+
+func TestParseQuery(t *testing.T) {
+	cases := []struct {
+		query  string
+		expect catalog.Expr
+	}{
+		{
+			query:  "city",
+			expect: &catalog.MatchExpr{Field: catalog.FieldPrompt, Value: "city"},
+		},
+		{
+			query:  `"red dress"`,
+			expect: &catalog.MatchExpr{Field: catalog.FieldPrompt, Value: "red dress", Phrase: true},
+		},
+		{
+			query: `prompt:"red dress" -negative:blurry sampler:"DPM++ 2M" steps:>30 seed:1234`,
+			expect: &catalog.AndExpr{Exprs: []catalog.Expr{
+				&catalog.MatchExpr{Field: catalog.FieldPrompt, Value: "red dress", Phrase: true},
+				&catalog.NotExpr{Expr: &catalog.MatchExpr{Field: catalog.FieldNegativePrompt, Value: "blurry"}},
+				&catalog.MatchExpr{Field: "sampler", Value: "DPM++ 2M", Phrase: true},
+				&catalog.RangeExpr{Field: "steps", Op: catalog.OpGreater, Number: 30},
+				&catalog.MatchExpr{Field: "seed", Value: "1234"},
+			}},
+		},
+		{
+			query: "(cat OR dog) AND NOT Model:sdxl",
+			expect: &catalog.AndExpr{Exprs: []catalog.Expr{
+				&catalog.OrExpr{Exprs: []catalog.Expr{
+					&catalog.MatchExpr{Field: catalog.FieldPrompt, Value: "cat"},
+					&catalog.MatchExpr{Field: catalog.FieldPrompt, Value: "dog"},
+				}},
+				&catalog.NotExpr{Expr: &catalog.MatchExpr{Field: catalog.FieldCheckpoint, Value: "sdxl"}},
+			}},
+		},
+		{
+			query: "created:>=2026-01-02 cfg:<=7.5",
+			expect: &catalog.AndExpr{Exprs: []catalog.Expr{
+				&catalog.RangeExpr{
+					Field: catalog.FieldCreated,
+					Op:    catalog.OpGreaterEqual,
+					Time:  time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local),
+				},
+				&catalog.RangeExpr{Field: "cfg-scale", Op: catalog.OpLessEqual, Number: 7.5},
+			}},
+		},
+		{
+			query: `favorite:true rating:4 tag:"to print" -note:draft`,
+			expect: &catalog.AndExpr{Exprs: []catalog.Expr{
+				&catalog.MatchExpr{Field: catalog.FieldFavorite, Value: "true"},
+				&catalog.AndExpr{Exprs: []catalog.Expr{
+					&catalog.RangeExpr{Field: catalog.FieldRating, Op: catalog.OpGreaterEqual, Number: 4},
+					&catalog.RangeExpr{Field: catalog.FieldRating, Op: catalog.OpLessEqual, Number: 4},
+				}},
+				&catalog.MatchExpr{Field: catalog.FieldTag, Value: "to print", Phrase: true},
+				&catalog.NotExpr{Expr: &catalog.MatchExpr{Field: catalog.FieldNote, Value: "draft"}},
+			}},
+		},
+		{
+			// hyphens and colons in words and unknown field syntax are searched as they are.
+			query: "semi-realistic <lora:add_detail:0.8>",
+			expect: &catalog.AndExpr{Exprs: []catalog.Expr{
+				&catalog.MatchExpr{Field: catalog.FieldPrompt, Value: "semi-realistic"},
+				&catalog.MatchExpr{Field: catalog.FieldPrompt, Value: "<lora:add_detail:0.8>"},
+			}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			res, err := catalog.ParseQuery(c.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(res, c.expect) {
+				t.Errorf("expect %#v, got %#v", c.expect, res)
+			}
+		})
+	}
+}
+
+func TestParseQueryError(t *testing.T) {
+	cases := []struct {
+		query string
+		pos   int
+	}{
+		{query: "", pos: 0},
+		{query: `prompt:"red dress`, pos: 7},
+		{query: "steps:>abc", pos: 7},
+		{query: "steps:>", pos: 7},
+		{query: "prompt:>3", pos: 7},
+		{query: "created:2026-01-01", pos: 8},
+		{query: "created:>yesterday", pos: 9},
+		{query: "(cat OR dog", pos: 0},
+		{query: "cat)", pos: 3},
+		{query: "cat OR", pos: 6},
+		{query: "AND cat", pos: 0},
+		{query: "cat NOT", pos: 7},
+		{query: "sampler: euler", pos: 8},
+		{query: "日本 steps:>x", pos: 10},
+		{query: "favorite:yes", pos: 9},
+		{query: `favorite:"true"`, pos: 9},
+		{query: "rating:high", pos: 7},
+		{query: "tag:>3", pos: 4},
+	}
+
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			_, err := catalog.ParseQuery(c.query)
+			var qerr *catalog.QueryError
+			if !errors.As(err, &qerr) {
+				t.Fatalf("expect a query error, got %v", err)
+			}
+			if qerr.Pos != c.pos {
+				t.Errorf("expect position %v, got %v (%v)", c.pos, qerr.Pos, qerr)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+// related.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package catalog
+
+import (
+	"context"
+	"errors"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/standard"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+// maxRelatedTerms is the maximum number of terms of a prompt used to search related images.
+const maxRelatedTerms = 25
+
+// boilerplateTags are tags put in many prompts regardless of their subjects, which are ignored to find related images.
+var boilerplateTags = map[string]bool{
+	"masterpiece":            true,
+	"best quality":           true,
+	"high quality":           true,
+	"highest quality":        true,
+	"top quality":            true,
+	"amazing quality":        true,
+	"ultra detailed":         true,
+	"ultra-detailed":         true,
+	"extremely detailed":     true,
+	"highly detailed":        true,
+	"very detailed":          true,
+	"intricate details":      true,
+	"detailed":               true,
+	"high resolution":        true,
+	"highres":                true,
+	"absurdres":              true,
+	"ultra high res":         true,
+	"8k":                     true,
+	"4k":                     true,
+	"uhd":                    true,
+	"hdr":                    true,
+	"8k wallpaper":           true,
+	"official art":           true,
+	"beautiful":              true,
+	"sharp focus":            true,
+	"award winning":          true,
+	"trending on artstation": true,
+}
+
+var (
+	// extraNetworkRegexp matches extra networks in prompts, e.g. <lora:name:0.8>.
+	extraNetworkRegexp = regexp.MustCompile(`<[^>]*>`)
+	// tagWeightRegexp matches weights of tags, e.g. ":1.2" of "(masterpiece:1.2)".
+	tagWeightRegexp = regexp.MustCompile(`:\s*[\d.]+$`)
+	// tagSpaceRegexp matches sequences of spaces and underscores in tags.
+	tagSpaceRegexp = regexp.MustCompile(`[\s_]+`)
+)
+
+// promptTags returns tags of the given prompt separated by commas without duplicates. Tags are in lower case without
+// emphasis and weights, and extra networks such as LoRAs aren't tags.
+func promptTags(prompt string) []string {
+	prompt = extraNetworkRegexp.ReplaceAllString(prompt, ",")
+
+	var res []string
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(prompt, ",") {
+		tag = strings.Trim(tag, "()[]{} \t\r\n")
+		tag = strings.Trim(tagWeightRegexp.ReplaceAllString(tag, ""), "()[]{} ")
+		tag = strings.ToLower(tagSpaceRegexp.ReplaceAllString(tag, " "))
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			res = append(res, tag)
+		}
+	}
+	return res
+}
+
+// subjectText returns tags of the given prompt given by promptTags except boilerplate tags.
+func subjectText(prompt string) string {
+	var res []string
+	for _, tag := range promptTags(prompt) {
+		if !boilerplateTags[tag] {
+			res = append(res, tag)
+		}
+	}
+	return strings.Join(res, ", ")
+}
+
+func (c *bleveCatalog) Related(ctx context.Context, id string, offset, limit int) (*SearchResult, error) {
+	img, err := c.Get(ctx, id)
+	if err != nil || img == nil {
+		return nil, err
+	}
+	terms, err := c.weightedTerms(subjectText(img.Prompt))
+	if err != nil {
+		return nil, err
+	}
+	if len(terms) == 0 {
+		return &SearchResult{Hits: []*Hit{}}, nil
+	}
+
+	queries := make([]query.Query, len(terms))
+	for i, t := range terms {
+		q := query.NewTermQuery(t.term)
+		q.FieldVal = "prompt"
+		q.SetBoost(t.weight)
+		queries[i] = q
+	}
+	q := query.NewBooleanQuery(
+		[]query.Query{imageQuery(&Filter{}), query.NewDisjunctionQuery(queries)},
+		nil,
+		[]query.Query{query.NewDocIDQuery([]string{id})},
+	)
+	r := bleve.NewSearchRequestOptions(q, limit, offset, false)
+	r.Fields = []string{"*"}
+	// images of the same score are sorted from the newest one.
+	r.SortBy([]string{"-_score", "-creation-time", "-_id"})
+
+	res, err := c.index.SearchInContext(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]*Hit, len(res.Hits))
+	for i, v := range res.Hits {
+		hits[i] = &Hit{ID: v.ID, Image: toImage(v.Fields)}
+	}
+	return &SearchResult{Total: int(res.Total), Hits: hits}, nil
+}
+
+// weightedTerm is a term of a prompt weighted by TF-IDF.
+type weightedTerm struct {
+	term   string
+	weight float64
+}
+
+// weightedTerms returns at most maxRelatedTerms terms of the given text analyzed in the same way as prompts, which are
+// weighted by their frequencies in the text and inverse document frequencies in prompts of indexed images.
+func (c *bleveCatalog) weightedTerms(text string) ([]weightedTerm, error) {
+	analyzer := c.index.Mapping().AnalyzerNamed(standard.Name)
+	if analyzer == nil {
+		return nil, errors.New("analyzer of prompts is not found")
+	}
+	tf := make(map[string]int)
+	for _, t := range analyzer.Analyze([]byte(text)) {
+		tf[string(t.Term)]++
+	}
+
+	total, err := c.index.DocCount()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]weightedTerm, 0, len(tf))
+	for term, n := range tf {
+		df, err := c.docFreq("prompt", term)
+		if err != nil {
+			return nil, err
+		}
+		if df == 0 {
+			// the term is dropped while being indexed, e.g. a stop word.
+			continue
+		}
+		res = append(res, weightedTerm{
+			term:   term,
+			weight: float64(n) * math.Log(1+float64(total)/float64(df)),
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].weight != res[j].weight {
+			return res[i].weight > res[j].weight
+		}
+		return res[i].term < res[j].term
+	})
+	if len(res) > maxRelatedTerms {
+		res = res[:maxRelatedTerms]
+	}
+	return res, nil
+}
+
+// docFreq returns the number of documents having the given term in the given field.
+func (c *bleveCatalog) docFreq(field, term string) (_ int, err error) {
+	dict, err := c.index.FieldDictRange(field, []byte(term), []byte(term))
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = errors.Join(err, dict.Close())
+	}()
+
+	// some index types also return terms having the given term as a prefix, which follow the term.
+	entry, err := dict.Next()
+	if err != nil || entry == nil || entry.Term != term {
+		return 0, err
+	}
+	return int(entry.Count), nil
+}
@@ -0,0 +1,252 @@
+// similar.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+
+	"github.com/jkawamoto/sd-image-viewer/image"
+)
+
+// hashPageSize is the number of hashes read from the index at once.
+const hashPageSize = 1000
+
+// hashBlocks splits the given perceptual hash into MaxHashDistance+1 blocks prefixed with their positions, e.g. "0ab".
+// Since hashes within MaxHashDistance differ in at most MaxHashDistance blocks, they share at least one block. It
+// returns nil if the hash is invalid.
+func hashBlocks(hash string) []string {
+	const n = MaxHashDistance + 1
+	if len(hash) != image.HashLength {
+		return nil
+	}
+	size := image.HashLength / n
+	res := make([]string, n)
+	for i := range res {
+		res[i] = strconv.Itoa(i) + hash[i*size:(i+1)*size]
+	}
+	return res
+}
+
+// hashEntry is an image having a perceptual hash.
+type hashEntry struct {
+	id   string
+	hash string
+}
+
+// hashes returns IDs and perceptual hashes of images matching the given query from the oldest one. Images without
+// hashes are omitted. Hashes are read page by page with cursors so that reading each page costs the same.
+func (c *bleveCatalog) hashes(ctx context.Context, q query.Query) ([]hashEntry, error) {
+	var (
+		res   []hashEntry
+		after []string
+	)
+	for {
+		r := bleve.NewSearchRequestOptions(q, hashPageSize, 0, false)
+		r.Fields = []string{"hash"}
+		r.SortBy(sortOrder(SortCreated, Ascending))
+		if after != nil {
+			r.SetSearchAfter(after)
+		}
+		found, err := c.index.SearchInContext(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range found.Hits {
+			if hash := getString(v.Fields, "hash"); hash != "" {
+				res = append(res, hashEntry{id: v.ID, hash: hash})
+			}
+		}
+		if len(found.Hits) < hashPageSize {
+			return res, nil
+		}
+		after = found.Hits[len(found.Hits)-1].Sort
+	}
+}
+
+func (c *bleveCatalog) Similar(ctx context.Context, id string, distance int) ([]*SimilarHit, error) {
+	if distance < 0 || distance > MaxHashDistance {
+		return nil, fmt.Errorf("distance must be from 0 to %v: %v", MaxHashDistance, distance)
+	}
+	img, err := c.Get(ctx, id)
+	if err != nil || img == nil {
+		return nil, err
+	}
+	blocks := hashBlocks(img.Hash)
+	if blocks == nil {
+		return []*SimilarHit{}, nil
+	}
+
+	// candidates share at least one block with the image.
+	queries := make([]query.Query, len(blocks))
+	for i, b := range blocks {
+		q := query.NewTermQuery(b)
+		q.FieldVal = "hash-block"
+		queries[i] = q
+	}
+	candidates, err := c.hashes(ctx, query.NewConjunctionQuery([]query.Query{
+		imageQuery(&Filter{}), query.NewDisjunctionQuery(queries),
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ids       []string
+		distances = make(map[string]int)
+	)
+	for _, v := range candidates {
+		if v.id == id {
+			continue
+		}
+		if d, err := image.HashDistance(img.Hash, v.hash); err == nil && d <= distance {
+			ids = append(ids, v.id)
+			distances[v.id] = d
+		}
+	}
+	hits, err := c.getAll(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*SimilarHit, len(hits))
+	for i, v := range hits {
+		res[i] = &SimilarHit{ID: v.ID, Image: v.Image, Distance: distances[v.ID]}
+	}
+	// images of the same distance are kept from the oldest one.
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Distance < res[j].Distance
+	})
+	return res, nil
+}
+
+// duplicateMember is an image in a group of duplicates with its distance from the oldest image of the group.
+type duplicateMember struct {
+	id       string
+	distance int
+}
+
+// duplicateGroup is a group of duplicates sorted in ascending order of distances from the oldest image.
+type duplicateGroup []duplicateMember
+
+func (c *bleveCatalog) Duplicates(ctx context.Context, distance, offset, limit int) (*DuplicateResult, error) {
+	if distance < 0 || distance > MaxHashDistance {
+		return nil, fmt.Errorf("distance must be from 0 to %v: %v", MaxHashDistance, distance)
+	}
+	key := strconv.Itoa(distance)
+	groups, ok, generation := c.duplicates.get(key)
+	if !ok {
+		var err error
+		if groups, err = c.duplicateGroups(ctx, distance); err != nil {
+			return nil, err
+		}
+		c.duplicates.put(key, generation, groups)
+	}
+
+	res := &DuplicateResult{Total: len(groups), Groups: [][]*SimilarHit{}}
+	if offset >= len(groups) {
+		return res, nil
+	}
+	groups = groups[offset:]
+	if limit > 0 && limit < len(groups) {
+		groups = groups[:limit]
+	}
+
+	var ids []string
+	for _, g := range groups {
+		for _, m := range g {
+			ids = append(ids, m.id)
+		}
+	}
+	hits, err := c.getAll(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	images := make(map[string]*image.Image, len(hits))
+	for _, v := range hits {
+		images[v.ID] = v.Image
+	}
+	for _, g := range groups {
+		group := make([]*SimilarHit, 0, len(g))
+		for _, m := range g {
+			// images removed while reading them are omitted.
+			if img, ok := images[m.id]; ok {
+				group = append(group, &SimilarHit{ID: m.id, Image: img, Distance: m.distance})
+			}
+		}
+		res.Groups = append(res.Groups, group)
+	}
+	return res, nil
+}
+
+// duplicateGroups returns all the groups of duplicates within the given distance in descending order of their sizes.
+func (c *bleveCatalog) duplicateGroups(ctx context.Context, distance int) ([]duplicateGroup, error) {
+	entries, err := c.hashes(ctx, imageQuery(&Filter{}))
+	if err != nil {
+		return nil, err
+	}
+
+	// blocks maps each block to indexes of entries having it.
+	blocks := make(map[string][]int)
+	for i, e := range entries {
+		for _, b := range hashBlocks(e.hash) {
+			blocks[b] = append(blocks[b], i)
+		}
+	}
+
+	// each group consists of the oldest image which isn't in other groups and newer images similar to it.
+	type member struct {
+		index, distance int
+	}
+	var groups []duplicateGroup
+	grouped := make([]bool, len(entries))
+	for i, e := range entries {
+		if grouped[i] {
+			continue
+		}
+		group := []member{{index: i}}
+		seen := map[int]bool{i: true}
+		for _, b := range hashBlocks(e.hash) {
+			for _, j := range blocks[b] {
+				if j < i || grouped[j] || seen[j] {
+					continue
+				}
+				seen[j] = true
+				if d, err := image.HashDistance(e.hash, entries[j].hash); err == nil && d <= distance {
+					group = append(group, member{index: j, distance: d})
+				}
+			}
+		}
+		if len(group) == 1 {
+			continue
+		}
+		sort.SliceStable(group, func(a, b int) bool {
+			if group[a].distance != group[b].distance {
+				return group[a].distance < group[b].distance
+			}
+			return group[a].index < group[b].index
+		})
+
+		g := make(duplicateGroup, len(group))
+		for k, m := range group {
+			grouped[m.index] = true
+			g[k] = duplicateMember{id: entries[m.index].id, distance: m.distance}
+		}
+		groups = append(groups, g)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i]) > len(groups[j])
+	})
+	return groups, nil
+}
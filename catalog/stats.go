@@ -0,0 +1,150 @@
+// stats.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+// Names of fields of parameters averaged in statistics of checkpoints.
+var (
+	stepsField    = "params." + ParamName("Steps")
+	cfgScaleField = "params." + ParamName("CFG scale")
+)
+
+func (c *bleveCatalog) Stats(ctx context.Context, req *StatsRequest) (*Stats, error) {
+	interval := req.Interval
+	if interval == "" {
+		interval = IntervalDay
+	}
+	q := imageQuery(&req.Filter)
+
+	// requests are identified by their bleve queries, which can be encoded in JSON unlike filters.
+	key, err := json.Marshal(struct {
+		Query    query.Query
+		Interval string
+		Periods  int
+		Size     int
+	}{q, interval, req.Periods, req.Size})
+	if err != nil {
+		return nil, err
+	}
+	cached, ok, generation := c.stats.get(string(key))
+	if ok {
+		return cached, nil
+	}
+
+	periods, err := c.periods(ctx, q, interval, req.Periods)
+	if err != nil {
+		return nil, err
+	}
+	// one more term is counted since images without values, e.g. those without samplers, have an empty term, which is
+	// omitted.
+	size := req.Size
+	if size > 0 {
+		size++
+	}
+	r := bleve.NewSearchRequestOptions(q, 0, 0, false)
+	for _, name := range []string{FacetCheckpoint, FacetSampler, FacetPromptTag, FacetResolution, FacetSize} {
+		fr, err := c.facetRequest(ctx, q, &FacetRequest{Name: name, Size: size})
+		if err != nil {
+			return nil, err
+		}
+		r.AddFacet(name, fr)
+	}
+	if len(periods) != 0 {
+		r.AddFacet(FacetCreated, createdFacetRequest(periods, interval))
+	}
+
+	res, err := c.index.SearchInContext(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	stats := &Stats{
+		Total:       int(res.Total),
+		Created:     toFacets(FacetCreated, res.Facets[FacetCreated]),
+		Checkpoints: []*CheckpointStats{},
+		Samplers:    topFacets(toFacets(FacetSampler, res.Facets[FacetSampler]), req.Size),
+		PromptTags:  topFacets(toFacets(FacetPromptTag, res.Facets[FacetPromptTag]), req.Size),
+		Resolutions: topFacets(toFacets(FacetResolution, res.Facets[FacetResolution]), req.Size),
+		Sizes:       toFacets(FacetSize, res.Facets[FacetSize]),
+	}
+	for _, f := range topFacets(toFacets(FacetCheckpoint, res.Facets[FacetCheckpoint]), req.Size) {
+		s, err := c.checkpointStats(ctx, req.Filter, f, periods, interval)
+		if err != nil {
+			return nil, err
+		}
+		stats.Checkpoints = append(stats.Checkpoints, s)
+	}
+
+	c.stats.put(string(key), generation, stats)
+	return stats, nil
+}
+
+// checkpointStats returns statistics of images generated with the checkpoint of the given facet in images matching
+// the filter. Images are counted in the given periods.
+func (c *bleveCatalog) checkpointStats(
+	ctx context.Context, filter Filter, checkpoint *Facet, periods []time.Time, interval string,
+) (*CheckpointStats, error) {
+	filter.Checkpoint = checkpoint.Term
+	r := bleve.NewSearchRequestOptions(imageQuery(&filter), 0, 0, false)
+	r.AddFacet(stepsField, bleve.NewFacetRequest(stepsField, math.MaxInt32))
+	r.AddFacet(cfgScaleField, bleve.NewFacetRequest(cfgScaleField, math.MaxInt32))
+	if len(periods) != 0 {
+		r.AddFacet(FacetCreated, createdFacetRequest(periods, interval))
+	}
+
+	res, err := c.index.SearchInContext(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &CheckpointStats{
+		Name:            checkpoint.Term,
+		Count:           checkpoint.Count,
+		AverageSteps:    average(res.Facets[stepsField]),
+		AverageCFGScale: average(res.Facets[cfgScaleField]),
+		Created:         toFacets(FacetCreated, res.Facets[FacetCreated]),
+	}, nil
+}
+
+// topFacets returns the first size facets of the given ones. Zero size means no limits.
+func topFacets(facets []*Facet, size int) []*Facet {
+	if size > 0 && len(facets) > size {
+		return facets[:size]
+	}
+	return facets
+}
+
+// average returns the average of numeric terms of the given facet weighted by their counts. Terms which aren't
+// numbers are ignored. It returns zero if there are no numeric terms.
+func average(r *search.FacetResult) float64 {
+	if r == nil || r.Terms == nil {
+		return 0
+	}
+
+	var sum, count float64
+	for _, v := range r.Terms.Terms() {
+		if n, err := strconv.ParseFloat(v.Term, 64); err == nil {
+			sum += n * float64(v.Count)
+			count += float64(v.Count)
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / count
+}
@@ -0,0 +1,147 @@
+// suggest.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/blevesearch/bleve/v2/search"
+	index "github.com/blevesearch/bleve_index_api"
+)
+
+// maxFuzziness is the maximum edit distance of corrections, which is the maximum fuzziness bleve supports.
+const maxFuzziness = 2
+
+// suggestFields lists fields whose terms can be suggested.
+var suggestFields = map[string]bool{
+	FieldPrompt:     true,
+	FieldCheckpoint: true,
+	FieldSampler:    true,
+	FieldLoRA:       true,
+}
+
+// suggestTerm returns the given term in the form stored in the index of the given field. Terms of prompts are
+// lower-case words while the other fields store values as they are.
+func suggestTerm(field, term string) (string, error) {
+	if !suggestFields[field] {
+		return "", fmt.Errorf("terms of %v can't be suggested", field)
+	}
+	if field == FieldPrompt {
+		return strings.ToLower(term), nil
+	}
+	return term, nil
+}
+
+// fuzziness returns the edit distance allowed to correct the given term, which grows with the length of the term so
+// that short terms aren't corrected to unrelated ones.
+func fuzziness(term string) int {
+	switch n := utf8.RuneCountInString(term); {
+	case n < 3:
+		return 0
+	case n < 6:
+		return 1
+	default:
+		return maxFuzziness
+	}
+}
+
+func (c *bleveCatalog) Suggest(_ context.Context, field, prefix string, size int) ([]*Facet, error) {
+	prefix, err := suggestTerm(field, prefix)
+	if err != nil {
+		return nil, err
+	}
+	var dict index.FieldDict
+	if prefix == "" {
+		// some index types don't return any terms for the empty prefix.
+		dict, err = c.index.FieldDict(field)
+	} else {
+		dict, err = c.index.FieldDictPrefix(field, []byte(prefix))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return topTerms(dict, size, func(string) bool { return true })
+}
+
+func (c *bleveCatalog) Corrections(_ context.Context, field, term string, size int) (_ []*Facet, err error) {
+	term, err = suggestTerm(field, term)
+	if err != nil {
+		return nil, err
+	}
+	distance := fuzziness(term)
+	if distance == 0 {
+		return []*Facet{}, nil
+	}
+
+	idx, err := c.index.Advanced()
+	if err != nil {
+		return nil, err
+	}
+	r, err := idx.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, r.Close())
+	}()
+
+	// the term itself isn't a correction.
+	other := func(t string) bool { return t != term }
+	if fr, ok := r.(index.IndexReaderFuzzy); ok {
+		dict, err := fr.FieldDictFuzzy(field, term, distance, "")
+		if err != nil {
+			return nil, err
+		}
+		return topTerms(dict, size, other)
+	}
+
+	// readers without automatons, e.g. in-memory indexes, compute the distance to every term.
+	dict, err := r.FieldDict(field)
+	if err != nil {
+		return nil, err
+	}
+	return topTerms(dict, size, func(t string) bool {
+		d, exceeded := search.LevenshteinDistanceMax(term, t, distance)
+		return !exceeded && d <= distance && other(t)
+	})
+}
+
+// topTerms returns at most size terms read from the given dictionary which satisfy fn in descending order of the
+// number of documents having them. Zero size means no limits. The dictionary is closed.
+func topTerms(dict index.FieldDict, size int, fn func(term string) bool) (_ []*Facet, err error) {
+	defer func() {
+		err = errors.Join(err, dict.Close())
+	}()
+
+	res := []*Facet{}
+	for {
+		entry, err := dict.Next()
+		if err != nil {
+			return nil, err
+		} else if entry == nil {
+			break
+		}
+		// images without values, e.g. those generated without LoRAs, have empty terms.
+		if entry.Term != "" && entry.Count != 0 && fn(entry.Term) {
+			res = append(res, &Facet{Term: entry.Term, Count: int(entry.Count)})
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Count > res[j].Count
+	})
+	if size > 0 && len(res) > size {
+		res = res[:size]
+	}
+	return res, nil
+}
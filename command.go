@@ -0,0 +1,226 @@
+// command.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/source"
+)
+
+// options are options shared by all commands.
+type options struct {
+	Config   string `long:"config" value-name:"FILE" description:"path to a configuration file"`
+	Index    string `long:"index" value-name:"DIR" description:"path to the index (default: sd-image-viewer in the user cache directory)"`
+	LogLevel string `long:"log-level" choice:"info" choice:"warn" description:"log level (default: info)"`
+	Version  bool   `short:"v" long:"version" description:"print current version"`
+}
+
+// apply overwrites the configuration with the options given explicitly.
+func (o *options) apply(cfg *Config) {
+	if o.Index != "" {
+		cfg.Index = o.Index
+	}
+	if o.LogLevel != "" {
+		cfg.LogLevel = o.LogLevel
+	}
+}
+
+// legacyFlags are the flags of versions without commands, which were given with a single dash, e.g. -port 8080, and
+// whether they take values.
+var legacyFlags = map[string]bool{
+	"config":         true,
+	"host":           true,
+	"port":           true,
+	"index":          true,
+	"index-duration": true,
+	"log-level":      true,
+	"force":          false,
+	"prune":          false,
+}
+
+// legacyArgs converts arguments of versions without commands, e.g. -host X -port Y dir, to the arguments of the serve
+// command, i.e. serve --host X --port Y dir. It returns false if the arguments have a command, or have neither a
+// directory nor flags with a single dash, which are handled by the parser as they are.
+func legacyArgs(parser *flags.Parser, args []string) ([]string, bool) {
+	res := []string{"serve"}
+	legacy := false
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || !strings.HasPrefix(arg, "-") || arg == "-" {
+			if parser.Find(arg) != nil {
+				return nil, false
+			}
+			// the flag package stopped parsing flags at the first positional argument, which is the directory.
+			legacy = legacy || arg != "--" || i+1 < len(args)
+			res = append(res, args[i:]...)
+			break
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		takesValue, ok := legacyFlags[name]
+		if !ok {
+			return nil, false
+		}
+		legacy = legacy || !strings.HasPrefix(arg, "--")
+		switch {
+		case takesValue && hasValue:
+			res = append(res, "--"+name+"="+value)
+		case takesValue && i+1 < len(args):
+			res = append(res, "--"+name, args[i+1])
+			i++
+		case takesValue:
+			return nil, false
+		default:
+			// boolean flags could be given values, e.g. -force=false, which the serve command doesn't take.
+			if v, err := strconv.ParseBool(value); !hasValue || err == nil && v {
+				res = append(res, "--"+name)
+			}
+		}
+	}
+	if !legacy {
+		return nil, false
+	}
+	return res, true
+}
+
+// optionSet returns a function reporting whether the option of the given long name is given to the command of the given
+// name explicitly, so that zero values, e.g. --port 0, can also override the configuration file.
+func optionSet(parser *flags.Parser, command string) func(string) bool {
+	return func(name string) bool {
+		c := parser.Find(command)
+		if c == nil {
+			return false
+		}
+		o := c.FindOptionByLongName(name)
+		return o != nil && o.IsSet()
+	}
+}
+
+// flagBool is a boolean option which also takes a value, e.g. --read-only=false, to disable a setting enabled by the
+// configuration file. It requires the optional and optional-value tags so that it doesn't consume the next argument.
+type flagBool bool
+
+func (b *flagBool) UnmarshalFlag(value string) error {
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	*b = flagBool(v)
+	return nil
+}
+
+// libraryArgs is the positional argument of commands reading libraries. A library given as an argument replaces the
+// libraries in the configuration file.
+type libraryArgs struct {
+	Library string `positional-arg-name:"library" description:"path to a directory or an URL of an S3 bucket"`
+}
+
+func (a *libraryArgs) apply(cfg *Config) {
+	if a.Library != "" {
+		cfg.Libraries = []LibraryConfig{{Path: a.Library}}
+	}
+}
+
+// defaultConfig returns the configuration used if neither the configuration file nor options give values.
+func defaultConfig() *Config {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		log.Printf("Failed to get the cache directory: %v", err)
+	}
+	return &Config{
+		Host:               "localhost",
+		Index:              filepath.Join(cacheDir, AppName),
+		IndexDuration:      time.Hour,
+		LogLevel:           logLevelInfo,
+		ThumbnailCacheSize: 1024,
+		TLS:                TLSConfig{Port: 8443},
+		TrashRetention:     30 * 24 * time.Hour,
+	}
+}
+
+// loadConfig reads the configuration file of the given name if it isn't empty, applies override, which gives options
+// taking precedence over the file, and validates the result.
+func loadConfig(name string, defaults *Config, override func(*Config)) (*Config, error) {
+	cfg := *defaults
+	if name != "" {
+		c, err := readConfig(name, defaults)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the configuration: %w", err)
+		}
+		cfg = *c
+	}
+	override(&cfg)
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return &cfg, nil
+}
+
+// openLibraries opens the libraries in the configuration. It also returns a source containing all of them, where
+// names of files are IDs of images.
+func openLibraries(cfg *Config) ([]*library, source.Source, error) {
+	if len(cfg.Libraries) == 0 {
+		return nil, nil, errors.New("one directory path or S3 URL is required")
+	}
+
+	libs := make([]*library, len(cfg.Libraries))
+	mounts := make(map[string]source.Source, len(cfg.Libraries))
+	for i, l := range cfg.Libraries {
+		src, err := source.New(l.Path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open %v: %w", l.Path, err)
+		}
+		libs[i] = &library{Name: l.Name, Source: src}
+		mounts[l.Name] = src
+	}
+	if len(libs) == 1 && libs[0].Name == "" {
+		return libs, libs[0].Source, nil
+	}
+	return libs, source.Mount(mounts), nil
+}
+
+// newVerboseLogger returns a logger which writes messages to the same writer as the given logger only if the log level
+// of the configuration is info. The returned levelWriter changes whether messages are written.
+func newVerboseLogger(logger *log.Logger, cfg *Config) (*log.Logger, *levelWriter) {
+	w := &levelWriter{Writer: logger.Writer()}
+	w.enabled.Store(cfg.LogLevel == logLevelInfo)
+	return log.New(w, logger.Prefix(), logger.Flags()), w
+}
+
+// openExistingIndex opens the index of the given name without creating a new one.
+func openExistingIndex(name string) (catalog.Catalog, error) {
+	if _, err := os.Stat(name); err != nil {
+		return nil, fmt.Errorf("failed to open the index: %w", err)
+	}
+	c, _, err := catalog.OpenBleve(name)
+	return c, err
+}
+
+// parseDate parses a time in RFC 3339 or a date in YYYY-MM-DD, which means the start of the day in local time.
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: expect RFC 3339 or YYYY-MM-DD", s)
+	}
+	return t, nil
+}
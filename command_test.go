@@ -0,0 +1,280 @@
+// command_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/export"
+	"github.com/jkawamoto/sd-image-viewer/image"
+)
+
+func TestParseDate(t *testing.T) {
+	cases := []struct {
+		value  string
+		expect time.Time
+		err    bool
+	}{
+		{value: "2026-01-02", expect: time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)},
+		{value: "2026-01-02T03:04:05Z", expect: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{value: "2026-13-01", err: true},
+		{value: "yesterday", err: true},
+	}
+	for _, c := range cases {
+		res, err := parseDate(c.value)
+		if c.err {
+			if err == nil {
+				t.Errorf("%v: expect an error", c.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: %v", c.value, err)
+		} else if !res.Equal(c.expect) {
+			t.Errorf("expect %v, got %v", c.expect, res)
+		}
+	}
+}
+
+func TestLegacyArgs(t *testing.T) {
+	parser, err := newParser(&options{}, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		args   []string
+		expect []string
+	}{
+		{
+			args:   []string{"-host", "0.0.0.0", "-port=8080", "-force", "/data"},
+			expect: []string{"serve", "--host", "0.0.0.0", "--port=8080", "--force", "/data"},
+		},
+		{
+			args:   []string{"--index", "/tmp/index", "-prune=false", "/data", "-port", "8080"},
+			expect: []string{"serve", "--index", "/tmp/index", "/data", "-port", "8080"},
+		},
+		{args: []string{"-config", "config.yaml"}, expect: []string{"serve", "--config", "config.yaml"}},
+		{args: []string{"/data"}, expect: []string{"serve", "/data"}},
+		{args: []string{"--config", "config.yaml"}},
+		{args: []string{"--config", "config.yaml", "serve", "/data"}},
+		{args: []string{"index", "/data"}},
+		{args: []string{"-v"}},
+		{args: []string{"--help"}},
+		{args: []string{"-port"}},
+		{},
+	}
+	for _, c := range cases {
+		res, ok := legacyArgs(parser, c.args)
+		if ok != (c.expect != nil) || !reflect.DeepEqual(res, c.expect) {
+			t.Errorf("%v: expect %q, got %q (%v)", c.args, c.expect, res, ok)
+		}
+	}
+}
+
+func TestOpenLibraries(t *testing.T) {
+	if _, _, err := openLibraries(&Config{}); err == nil {
+		t.Error("expect an error if no libraries are given")
+	}
+
+	libs, src, err := openLibraries(&Config{Libraries: []LibraryConfig{
+		{Name: "a", Path: t.TempDir()},
+		{Name: "b", Path: t.TempDir()},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(libs) != 2 || libs[0].Name != "a" || libs[1].Name != "b" {
+		t.Errorf("expect libraries a and b, got %v", libs)
+	}
+	if _, err = src.Stat("b"); err != nil {
+		t.Errorf("expect b is mounted: %v", err)
+	}
+}
+
+func TestSearchCommandRequest(t *testing.T) {
+	cmd := &searchCommand{
+		filterOptions: filterOptions{
+			Checkpoint: "model",
+			Size:       catalog.SizeSmall,
+			After:      "2026-01-01",
+		},
+		Order: "asc",
+		Limit: 10,
+		Page:  2,
+	}
+	cmd.Args.Query = "city"
+
+	req, err := cmd.request()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := &catalog.SearchRequest{
+		Filter: catalog.Filter{
+			Prompt:     "city",
+			Checkpoint: "model",
+			MaxPixel:   512 * 768,
+			After:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local),
+		},
+		Order:  catalog.Ascending,
+		Offset: 20,
+		Limit:  10,
+	}
+	if !reflect.DeepEqual(req, expect) {
+		t.Errorf("expect %+v, got %+v", expect, req)
+	}
+
+	cmd.Limit = 0
+	if _, err = cmd.request(); err == nil {
+		t.Error("expect an error for a non positive limit")
+	}
+}
+
+func TestExportDocuments(t *testing.T) {
+	c, err := catalog.NewBleveMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	docs := map[string]catalog.Document{
+		"failure.png": &image.Failure{Class: image.ClassCorrupt, FailedAt: now},
+	}
+	for i := 0; i != export.PageSize+1; i++ {
+		docs[time.Duration(i).String()] = &image.Image{
+			Prompt:       "prompt",
+			CreationTime: now.Add(time.Duration(i) * time.Second),
+		}
+	}
+	if err = c.Index(context.Background(), docs); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		failures bool
+		expect   int
+	}{
+		{failures: false, expect: export.PageSize + 1},
+		{failures: true, expect: export.PageSize + 2},
+	}
+	for _, tc := range cases {
+		var buf bytes.Buffer
+		req := &catalog.SearchRequest{Order: catalog.Ascending}
+		if err = exportDocuments(context.Background(), &buf, c, req, export.FormatJSONL, nil, tc.failures); err != nil {
+			t.Fatal(err)
+		}
+
+		var types []string
+		s := bufio.NewScanner(&buf)
+		for s.Scan() {
+			var line struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+			}
+			if err = json.Unmarshal(s.Bytes(), &line); err != nil {
+				t.Fatal(err)
+			}
+			types = append(types, line.Type)
+		}
+		if len(types) != tc.expect {
+			t.Errorf("expect %v lines, got %v", tc.expect, len(types))
+		} else if types[0] != image.DocType {
+			t.Errorf("expect images are exported first, got %v", types[0])
+		} else if tc.failures && types[len(types)-1] != image.FailureDocType {
+			t.Errorf("expect a failure at last, got %v", types[len(types)-1])
+		}
+	}
+}
+
+func TestOpenExistingIndex(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "index")
+	if _, err := openExistingIndex(name); err == nil {
+		t.Error("expect an error for a missing index")
+	}
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		t.Errorf("expect no index is created, got %v", err)
+	}
+
+	logger := log.New(io.Discard, "", 0)
+	c, _, err := newIndex(name, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Index(context.Background(), map[string]catalog.Document{"a.png": &image.Image{}}); err != nil {
+		t.Fatal(err)
+	}
+	// imitates an index whose documents have fields of an older schema, which the bleve catalog records as metadata.
+	if err = c.SetMeta("bleve-schema", []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// documents are kept by commands which don't index images.
+	c, err = openExistingIndex(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids, err := c.IDs(context.Background(), 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 {
+		t.Errorf("expect documents are kept, got %v", ids)
+	}
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the index is rebuilt before indexing images.
+	c, created, err := newIndex(name, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	if !created {
+		t.Error("expect the index is reported as created")
+	}
+	if ids, err = c.IDs(context.Background(), 0, 10); err != nil {
+		t.Fatal(err)
+	} else if len(ids) != 0 {
+		t.Errorf("expect documents are removed, got %v", ids)
+	}
+	if outdated, err := c.Outdated(); err != nil {
+		t.Fatal(err)
+	} else if outdated {
+		t.Error("expect the rebuilt index has the current schema")
+	}
+	if outdated, err := isOutdated(c); err != nil {
+		t.Fatal(err)
+	} else if outdated {
+		t.Error("expect the rebuilt index isn't outdated")
+	}
+}
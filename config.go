@@ -0,0 +1,336 @@
+// config.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jkawamoto/sd-image-viewer/archive"
+)
+
+const (
+	logLevelInfo = "info"
+	// logLevelWarn omits messages about each indexed file and each request.
+	logLevelWarn = "warn"
+
+	// configPollInterval is the interval of checking modifications of the configuration file.
+	configPollInterval = 5 * time.Second
+)
+
+// Config is the configuration of the application, which can be read from a YAML file.
+//
+// Host, Port, Index, ThumbnailCacheSize, TLS, ReadOnly and Libraries are structural settings, which require restarting
+// the application to change. The others are reloaded on SIGHUP or modifications of the file.
+type Config struct {
+	Host          string        `yaml:"host"`
+	Port          int           `yaml:"port"`
+	Index         string        `yaml:"index"`
+	IndexDuration time.Duration `yaml:"index-duration"`
+	LogLevel      string        `yaml:"log-level"`
+	// ThumbnailCacheSize is the maximum total size of cached thumbnails in megabytes. Zero means no limits.
+	ThumbnailCacheSize int64 `yaml:"thumbnail-cache-size"`
+	// Ignore is a list of patterns of files which are not indexed in any libraries.
+	Ignore []string  `yaml:"ignore"`
+	TLS    TLSConfig `yaml:"tls"`
+	// ReadOnly disables deleting and restoring images.
+	ReadOnly bool `yaml:"read-only"`
+	// TrashRetention is the duration deleted images are kept in the trash before being purged. Zero keeps them until
+	// they are restored.
+	TrashRetention time.Duration   `yaml:"trash-retention"`
+	Libraries      []LibraryConfig `yaml:"libraries"`
+}
+
+// TLSConfig is the configuration of HTTPS. Requests over HTTP are redirected to HTTPS if it is enabled.
+type TLSConfig struct {
+	// Enabled enables HTTPS. It is also enabled by giving a certificate.
+	Enabled bool `yaml:"enabled"`
+	// Port is the port to listen on for HTTPS, which defaults to 8443. Zero means a random port.
+	Port int `yaml:"port"`
+	// Certificate and Key are paths to a certificate and its private key, which are reloaded when modified.
+	// A self-signed certificate is created if they are omitted.
+	Certificate string `yaml:"certificate"`
+	Key         string `yaml:"key"`
+	// Names are host names and IP addresses of the server added to self-signed certificates.
+	Names []string `yaml:"names"`
+}
+
+// enabled returns true if HTTPS is enabled.
+func (c *TLSConfig) enabled() bool {
+	return c.Enabled || c.Certificate != ""
+}
+
+// LibraryConfig is the configuration of a library.
+type LibraryConfig struct {
+	// Name is prefixed to IDs of images in the library. It can be omitted if there is only one library.
+	Name string `yaml:"name"`
+	// Path is a path to a local directory or an URL of an S3 compatible bucket.
+	Path string `yaml:"path"`
+	// Ignore is a list of patterns of files which are not indexed in this library.
+	Ignore []string `yaml:"ignore"`
+}
+
+// readConfig reads the configuration file of the given name and fills missing values with the given defaults.
+func readConfig(name string, defaults *Config) (_ *Config, err error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	cfg := *defaults
+	cfg.Libraries = nil
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err = dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse %v: %w", name, err)
+	}
+	if len(cfg.Libraries) == 0 {
+		cfg.Libraries = defaults.Libraries
+	}
+	return &cfg, nil
+}
+
+// validate checks the configuration.
+func (c *Config) validate() error {
+	names := make(map[string]struct{})
+	for _, l := range c.Libraries {
+		if l.Path == "" {
+			return fmt.Errorf("library %q doesn't have a path", l.Name)
+		}
+		if len(c.Libraries) > 1 && l.Name == "" {
+			return fmt.Errorf("library %v requires a name since there are multiple libraries", l.Path)
+		}
+		if l.Name != "" && (strings.Contains(l.Name, "/") || l.Name == "." || l.Name == ".." ||
+			strings.Contains(l.Name, archive.Separator)) {
+			return fmt.Errorf("invalid library name: %q", l.Name)
+		}
+		if _, ok := names[l.Name]; ok {
+			return fmt.Errorf("duplicated library name: %q", l.Name)
+		}
+		names[l.Name] = struct{}{}
+
+		for _, p := range append(l.Ignore, c.Ignore...) {
+			if _, err := path.Match(p, ""); err != nil {
+				return fmt.Errorf("invalid ignore pattern %q: %w", p, err)
+			}
+		}
+	}
+
+	switch c.LogLevel {
+	case logLevelInfo, logLevelWarn:
+	default:
+		return fmt.Errorf("invalid log level: %q", c.LogLevel)
+	}
+	if c.ThumbnailCacheSize < 0 {
+		return fmt.Errorf("invalid thumbnail cache size: %v", c.ThumbnailCacheSize)
+	}
+	if (c.TLS.Certificate == "") != (c.TLS.Key == "") {
+		return errors.New("both a TLS certificate and its key are required")
+	}
+	if c.TLS.Port < 0 {
+		return fmt.Errorf("invalid TLS port: %v", c.TLS.Port)
+	}
+	if c.TrashRetention < 0 {
+		return fmt.Errorf("invalid trash retention: %v", c.TrashRetention)
+	}
+	return nil
+}
+
+// structuralChanges returns names of structural settings which differ between the two configurations.
+func (c *Config) structuralChanges(o *Config) []string {
+	var res []string
+	if c.Host != o.Host {
+		res = append(res, "host")
+	}
+	if c.Port != o.Port {
+		res = append(res, "port")
+	}
+	if c.Index != o.Index {
+		res = append(res, "index")
+	}
+	if c.ThumbnailCacheSize != o.ThumbnailCacheSize {
+		res = append(res, "thumbnail-cache-size")
+	}
+	if !reflect.DeepEqual(c.TLS, o.TLS) {
+		res = append(res, "tls")
+	}
+	if c.ReadOnly != o.ReadOnly {
+		res = append(res, "read-only")
+	}
+	if len(c.Libraries) != len(o.Libraries) {
+		res = append(res, "libraries")
+	} else {
+		for i := range c.Libraries {
+			if c.Libraries[i].Name != o.Libraries[i].Name || c.Libraries[i].Path != o.Libraries[i].Path {
+				res = append(res, "libraries")
+				break
+			}
+		}
+	}
+	return res
+}
+
+// ignorePatterns returns patterns of files which are not indexed in the library of the given name.
+func (c *Config) ignorePatterns(library string) []string {
+	res := append([]string(nil), c.Ignore...)
+	for _, l := range c.Libraries {
+		if l.Name == library {
+			res = append(res, l.Ignore...)
+		}
+	}
+	return res
+}
+
+// locate returns the location of the image of the given ID, i.e. a path to a local file or an URL of an S3 object.
+// Images in archives are located by the locations of the archives followed by the names in them, e.g.
+// "/path/to/a.zip!/b.png". It returns the ID as is if no libraries contain the image.
+func (c *Config) locate(id string) string {
+	for _, l := range c.Libraries {
+		name := id
+		if l.Name != "" {
+			var ok bool
+			if name, ok = strings.CutPrefix(id, l.Name+"/"); !ok {
+				continue
+			}
+		}
+		if strings.Contains(l.Path, "://") {
+			return strings.TrimSuffix(l.Path, "/") + "/" + name
+		}
+		return filepath.Join(l.Path, filepath.FromSlash(name))
+	}
+	return id
+}
+
+// libraryRoots returns root directories of libraries mapped from their names. Libraries which aren't in local
+// directories, e.g. S3 buckets, have empty roots.
+func (c *Config) libraryRoots() map[string]string {
+	res := make(map[string]string)
+	for _, l := range c.Libraries {
+		res[l.Name] = ""
+		if !strings.Contains(l.Path, "://") {
+			res[l.Name] = l.Path
+		}
+	}
+	return res
+}
+
+// ignored returns true if the file of the given name matches any of the patterns. Patterns containing slashes are
+// matched against the whole name; the others are matched against the base name.
+func ignored(patterns []string, name string) bool {
+	for _, p := range patterns {
+		target := path.Base(name)
+		if strings.Contains(p, "/") {
+			target = name
+		}
+		if ok, _ := path.Match(p, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// settings holds the current configuration, which can be replaced while running.
+type settings struct {
+	mu      sync.RWMutex
+	cfg     *Config
+	verbose *levelWriter
+}
+
+func newSettings(cfg *Config, verbose *levelWriter) *settings {
+	s := &settings{verbose: verbose}
+	s.set(cfg)
+	return s
+}
+
+func (s *settings) get() *Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cfg
+}
+
+func (s *settings) set(cfg *Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg = cfg
+	s.verbose.enabled.Store(cfg.LogLevel == logLevelInfo)
+}
+
+// reload reads the configuration file again and applies changes of non-structural settings.
+// override applies command line flags, which take precedence over the file.
+func (s *settings) reload(name string, defaults *Config, override func(*Config), logger *log.Logger) {
+	cfg, err := readConfig(name, defaults)
+	if err == nil {
+		override(cfg)
+		err = cfg.validate()
+	}
+	if err != nil {
+		logger.Printf("Failed to reload the configuration: %v", err)
+		return
+	}
+
+	cur := s.get()
+	if changes := cur.structuralChanges(cfg); len(changes) != 0 {
+		logger.Printf("Changes of %v require restarting the application", strings.Join(changes, ", "))
+		cfg.Host, cfg.Port, cfg.Index, cfg.Libraries = cur.Host, cur.Port, cur.Index, cur.Libraries
+		cfg.ThumbnailCacheSize, cfg.TLS, cfg.ReadOnly = cur.ThumbnailCacheSize, cur.TLS, cur.ReadOnly
+	}
+	s.set(cfg)
+	logger.Println("Reloaded the configuration")
+}
+
+// watchConfig calls reload when the configuration file of the given name is modified until done is closed.
+func watchConfig(name string, done <-chan struct{}, reload func()) {
+	var modTime time.Time
+	if info, err := os.Stat(name); err == nil {
+		modTime = info.ModTime()
+	}
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-time.After(configPollInterval):
+		}
+
+		info, err := os.Stat(name)
+		if err != nil || info.ModTime().Equal(modTime) {
+			continue
+		}
+		modTime = info.ModTime()
+		reload()
+	}
+}
+
+// levelWriter writes messages only if it is enabled.
+type levelWriter struct {
+	io.Writer
+	enabled atomic.Bool
+}
+
+func (w *levelWriter) Write(p []byte) (int, error) {
+	if !w.enabled.Load() {
+		return len(p), nil
+	}
+	return w.Writer.Write(p)
+}
@@ -0,0 +1,324 @@
+// config_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, body string) string {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(name, []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestReadConfig(t *testing.T) {
+	defaults := &Config{
+		Host:          "localhost",
+		Index:         "/tmp/index",
+		IndexDuration: time.Hour,
+		LogLevel:      logLevelInfo,
+	}
+
+	cases := []struct {
+		name   string
+		body   string
+		expect *Config
+		err    bool
+	}{
+		{
+			name:   "empty",
+			body:   "",
+			expect: defaults,
+		},
+		{
+			name: "full",
+			body: `
+host: 0.0.0.0
+port: 8080
+index-duration: 10m
+log-level: warn
+thumbnail-cache-size: 512
+ignore: ["*.tmp"]
+tls:
+  enabled: true
+  port: 8443
+  names: [nas.local, 192.168.1.2]
+read-only: true
+trash-retention: 168h
+libraries:
+  - name: outputs
+    path: /data/outputs
+    ignore: ["grids/*"]
+  - name: archive
+    path: s3://bucket/archive
+`,
+			expect: &Config{
+				Host:               "0.0.0.0",
+				Port:               8080,
+				Index:              "/tmp/index",
+				IndexDuration:      10 * time.Minute,
+				LogLevel:           logLevelWarn,
+				ThumbnailCacheSize: 512,
+				Ignore:             []string{"*.tmp"},
+				TLS: TLSConfig{
+					Enabled: true,
+					Port:    8443,
+					Names:   []string{"nas.local", "192.168.1.2"},
+				},
+				ReadOnly:       true,
+				TrashRetention: 7 * 24 * time.Hour,
+				Libraries: []LibraryConfig{
+					{Name: "outputs", Path: "/data/outputs", Ignore: []string{"grids/*"}},
+					{Name: "archive", Path: "s3://bucket/archive"},
+				},
+			},
+		},
+		{
+			name: "unknown field",
+			body: "hosts: 0.0.0.0\n",
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			res, err := readConfig(writeConfig(t, c.body), defaults)
+			if c.err {
+				if err == nil {
+					t.Error("expect an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(res, c.expect) {
+				t.Errorf("expect %+v, got %+v", c.expect, res)
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cases := []struct {
+		name      string
+		libraries []LibraryConfig
+		ignore    []string
+		logLevel  string
+		cacheSize int64
+		tls       TLSConfig
+		retention time.Duration
+		err       bool
+	}{
+		{
+			name:      "single unnamed library",
+			libraries: []LibraryConfig{{Path: "/data"}},
+			logLevel:  logLevelInfo,
+		},
+		{
+			name:      "multiple libraries",
+			libraries: []LibraryConfig{{Name: "a", Path: "/a"}, {Name: "b", Path: "/b"}},
+			logLevel:  logLevelWarn,
+		},
+		{
+			// commands reading only the index don't require libraries.
+			name:     "no libraries",
+			logLevel: logLevelInfo,
+		},
+		{
+			name:      "unnamed library in multiple libraries",
+			libraries: []LibraryConfig{{Name: "a", Path: "/a"}, {Path: "/b"}},
+			logLevel:  logLevelInfo,
+			err:       true,
+		},
+		{
+			name:      "duplicated names",
+			libraries: []LibraryConfig{{Name: "a", Path: "/a"}, {Name: "a", Path: "/b"}},
+			logLevel:  logLevelInfo,
+			err:       true,
+		},
+		{
+			name:      "name with a slash",
+			libraries: []LibraryConfig{{Name: "a/b", Path: "/a"}},
+			logLevel:  logLevelInfo,
+			err:       true,
+		},
+		{
+			name:      "invalid ignore pattern",
+			libraries: []LibraryConfig{{Path: "/a"}},
+			ignore:    []string{"[a-"},
+			logLevel:  logLevelInfo,
+			err:       true,
+		},
+		{
+			name:      "invalid log level",
+			libraries: []LibraryConfig{{Path: "/a"}},
+			logLevel:  "debug",
+			err:       true,
+		},
+		{
+			name:      "negative thumbnail cache size",
+			libraries: []LibraryConfig{{Path: "/a"}},
+			logLevel:  logLevelInfo,
+			cacheSize: -1,
+			err:       true,
+		},
+		{
+			name:      "TLS certificate",
+			libraries: []LibraryConfig{{Path: "/a"}},
+			logLevel:  logLevelInfo,
+			tls:       TLSConfig{Certificate: "server.crt", Key: "server.key"},
+		},
+		{
+			name:      "TLS certificate without a key",
+			libraries: []LibraryConfig{{Path: "/a"}},
+			logLevel:  logLevelInfo,
+			tls:       TLSConfig{Certificate: "server.crt"},
+			err:       true,
+		},
+		{
+			name:      "negative TLS port",
+			libraries: []LibraryConfig{{Path: "/a"}},
+			logLevel:  logLevelInfo,
+			tls:       TLSConfig{Enabled: true, Port: -1},
+			err:       true,
+		},
+		{
+			name:      "negative trash retention",
+			libraries: []LibraryConfig{{Path: "/a"}},
+			logLevel:  logLevelInfo,
+			retention: -time.Hour,
+			err:       true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := &Config{
+				Libraries:          c.libraries,
+				Ignore:             c.ignore,
+				LogLevel:           c.logLevel,
+				ThumbnailCacheSize: c.cacheSize,
+				TLS:                c.tls,
+				TrashRetention:     c.retention,
+			}
+			if err := cfg.validate(); (err != nil) != c.err {
+				t.Errorf("expect error %v, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestConfigLocate(t *testing.T) {
+	cfg := &Config{Libraries: []LibraryConfig{
+		{Name: "outputs", Path: "/data/outputs"},
+		{Name: "archive", Path: "s3://bucket/prefix/"},
+	}}
+	cases := []struct {
+		id     string
+		expect string
+	}{
+		{id: "outputs/a.png", expect: filepath.Join("/data/outputs", "a.png")},
+		{id: "outputs/old.zip!/b.png", expect: filepath.Join("/data/outputs", "old.zip!", "b.png")},
+		{id: "archive/sub/c.png", expect: "s3://bucket/prefix/sub/c.png"},
+		{id: "others/d.png", expect: "others/d.png"},
+	}
+	for _, c := range cases {
+		if res := cfg.locate(c.id); res != c.expect {
+			t.Errorf("expect %v, got %v", c.expect, res)
+		}
+	}
+
+	single := &Config{Libraries: []LibraryConfig{{Path: "/data"}}}
+	if res, expect := single.locate("sub/a.png"), filepath.Join("/data", "sub", "a.png"); res != expect {
+		t.Errorf("expect %v, got %v", expect, res)
+	}
+}
+
+func TestIgnored(t *testing.T) {
+	patterns := []string{"*.tmp", "outputs/grids/*"}
+	cases := []struct {
+		name   string
+		expect bool
+	}{
+		{name: "a.png", expect: false},
+		{name: "a.tmp", expect: true},
+		{name: "outputs/sub/a.tmp", expect: true},
+		{name: "outputs/grids/a.png", expect: true},
+		{name: "outputs/grids/sub/a.png", expect: false},
+		{name: "grids/a.png", expect: false},
+	}
+	for _, c := range cases {
+		if res := ignored(patterns, c.name); res != c.expect {
+			t.Errorf("%v: expect %v, got %v", c.name, c.expect, res)
+		}
+	}
+}
+
+func TestSettingsReload(t *testing.T) {
+	name := writeConfig(t, `
+host: localhost
+log-level: info
+libraries:
+  - path: /data
+`)
+	defaults := &Config{IndexDuration: time.Hour}
+	cfg, err := readConfig(name, defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	verbose := &levelWriter{Writer: &buf}
+	s := newSettings(cfg, verbose)
+	if _, err = io.WriteString(verbose, "info"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.WriteFile(name, []byte(`
+host: 0.0.0.0
+log-level: warn
+index-duration: 5m
+libraries:
+  - path: /data
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var logs bytes.Buffer
+	s.reload(name, defaults, func(*Config) {}, log.New(&logs, "", 0))
+
+	res := s.get()
+	if res.Host != "localhost" {
+		t.Errorf("expect host isn't changed, got %v", res.Host)
+	}
+	if res.IndexDuration != 5*time.Minute {
+		t.Errorf("expect %v, got %v", 5*time.Minute, res.IndexDuration)
+	}
+	if !strings.Contains(logs.String(), "host") {
+		t.Errorf("expect a message about host, got %q", logs.String())
+	}
+	if _, err = io.WriteString(verbose, "suppressed"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "info" {
+		t.Errorf("expect %q, got %q", "info", buf.String())
+	}
+}
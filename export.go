@@ -0,0 +1,125 @@
+// export.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/export"
+	"github.com/jkawamoto/sd-image-viewer/image"
+)
+
+// exportCommand dumps indexed documents matching the same filters as GET /images.
+type exportCommand struct {
+	global *options
+	filterOptions
+	Format   string `short:"f" long:"format" choice:"jsonl" choice:"csv" choice:"prompts" default:"jsonl" description:"output format; prompts writes lines read by the \"Prompts from file or textbox\" script of AUTOMATIC1111's web UI"`
+	Columns  string `long:"columns" value-name:"LIST" description:"comma-separated columns of CSV exports, e.g. id,prompt,steps,tags"`
+	Order    string `long:"order" choice:"asc" choice:"desc" default:"asc" description:"order of creation times"`
+	Failures bool   `long:"failures" description:"export failure records after images; only available in the jsonl format"`
+	Args     struct {
+		Query string `positional-arg-name:"query" description:"phrase contained in prompts"`
+	} `positional-args:"yes"`
+}
+
+// exportedFailure is a line of exported failure records.
+type exportedFailure struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	*image.Failure
+}
+
+func (c *exportCommand) Execute([]string) (err error) {
+	if c.Failures && c.Format != export.FormatJSONL {
+		return errors.New("failure records are available only in the jsonl format")
+	}
+	filter, err := c.filter(c.Args.Query)
+	if err != nil {
+		return err
+	}
+	req := &catalog.SearchRequest{Filter: filter}
+	if c.Order != "desc" {
+		req.Order = catalog.Ascending
+	}
+	var columns []string
+	if c.Columns != "" {
+		columns = strings.Split(c.Columns, ",")
+	}
+
+	cfg, err := loadConfig(c.global.Config, defaultConfig(), c.global.apply)
+	if err != nil {
+		return err
+	}
+
+	index, err := catalog.OpenBleveReadOnly(cfg.Index)
+	if err != nil {
+		return fmt.Errorf("failed to open the index: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, index.Close())
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return exportDocuments(ctx, os.Stdout, index, req, c.Format, columns, c.Failures)
+}
+
+// exportDocuments writes images matching the given request in the given format, and failure records after them if
+// failures is true, which are written as JSON lines.
+func exportDocuments(
+	ctx context.Context, w io.Writer, c catalog.Catalog, req *catalog.SearchRequest, format string, columns []string,
+	failures bool,
+) error {
+	ew, err := export.NewWriter(w, format, columns)
+	if err != nil {
+		return err
+	}
+	err = export.Each(ctx, c, req, func(hit *catalog.Hit) error {
+		return ew.Write(hit.ID, hit.Image)
+	})
+	if err != nil {
+		return err
+	}
+	if err = ew.Flush(); err != nil {
+		return err
+	}
+	if !failures {
+		return nil
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	fr := &catalog.FailureRequest{Limit: export.PageSize}
+	for {
+		res, err := c.SearchFailures(ctx, fr)
+		if err != nil {
+			return err
+		}
+		for _, v := range res.Hits {
+			if err = enc.Encode(&exportedFailure{ID: v.ID, Type: v.Failure.Type(), Failure: v.Failure}); err != nil {
+				return err
+			}
+		}
+		if len(res.Hits) < export.PageSize {
+			return bw.Flush()
+		}
+		fr.After = res.Next
+	}
+}
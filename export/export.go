@@ -0,0 +1,263 @@
+// export.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+// Package export writes indexed images in formats read by other tools, i.e. CSV, JSON Lines, and prompt lists read by
+// the "Prompts from file or textbox" script of AUTOMATIC1111's web UI. Images are written one by one while searching
+// them page by page so that large exports aren't built in memory.
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/image"
+)
+
+// PageSize is the number of images read from the index at once.
+const PageSize = 100
+
+// Formats of exports.
+const (
+	// FormatJSONL writes an Image of each image as a JSON line.
+	FormatJSONL = "jsonl"
+	// FormatCSV writes a row of the given columns of each image after a header.
+	FormatCSV = "csv"
+	// FormatPrompts writes a line of the prompt and the generation parameters of each image.
+	FormatPrompts = "prompts"
+)
+
+// DefaultColumns are columns of CSV exports used if no columns are given.
+var DefaultColumns = []string{
+	"id", "prompt", "negative-prompt", "checkpoint", "sampler", "steps", "cfg-scale", "seed", "size", "creation-time",
+}
+
+// Image is a line of JSON Lines exports, which has the full metadata of an image.
+type Image struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	*image.Image
+}
+
+// Writer writes images in a format.
+type Writer interface {
+	// Write writes the image of the given ID.
+	Write(id string, img *image.Image) error
+	// Flush writes any buffered data to the underlying writer.
+	Flush() error
+}
+
+// NewWriter returns a Writer of the given format. Columns are used only by CSV, and DefaultColumns are used if it is
+// empty.
+func NewWriter(w io.Writer, format string, columns []string) (Writer, error) {
+	switch format {
+	case FormatJSONL:
+		bw := bufio.NewWriter(w)
+		return &jsonlWriter{w: bw, enc: json.NewEncoder(bw)}, nil
+	case FormatCSV:
+		if len(columns) == 0 {
+			columns = DefaultColumns
+		}
+		for _, col := range columns {
+			if strings.TrimSpace(col) == "" {
+				return nil, fmt.Errorf("empty column name in %q", strings.Join(columns, ","))
+			}
+		}
+		return &csvWriter{w: csv.NewWriter(w), columns: columns}, nil
+	case FormatPrompts:
+		return &promptsWriter{w: bufio.NewWriter(w)}, nil
+	default:
+		return nil, fmt.Errorf("unknown export format: %q", format)
+	}
+}
+
+// Each calls fn with each image matching the given request in order, which is searched page by page with cursors so
+// that images indexed or removed meanwhile don't shift pages. The offset, limit and cursor of the request are ignored.
+func Each(ctx context.Context, c catalog.Catalog, req *catalog.SearchRequest, fn func(hit *catalog.Hit) error) error {
+	r := *req
+	r.Offset, r.Limit, r.After = 0, PageSize, nil
+	for {
+		res, err := c.Search(ctx, &r)
+		if err != nil {
+			return err
+		}
+		for _, v := range res.Hits {
+			if err = fn(v); err != nil {
+				return err
+			}
+		}
+		if len(res.Hits) < PageSize || res.Next == nil {
+			return nil
+		}
+		r.After = res.Next
+	}
+}
+
+// Value returns the value of the given column of an image. Columns are the ID, fields of images, i.e. prompt,
+// negative-prompt, checkpoint, creation-time, pixel and parameters, fields of annotations, i.e. favorite, rating,
+// tags and note, LoRAs, or names of parameters in metadata used in query strings, e.g. cfg-scale. Tags and LoRAs are
+// joined with commas. It returns an empty string if the image doesn't have the column.
+func Value(column, id string, img *image.Image) string {
+	switch column {
+	case "id":
+		return id
+	case "prompt":
+		return img.Prompt
+	case "negative-prompt":
+		return img.NegativePrompt
+	case "checkpoint":
+		return img.Checkpoint
+	case "creation-time":
+		return img.CreationTime.Format(time.RFC3339)
+	case "pixel":
+		return strconv.Itoa(img.Pixel)
+	case "parameters":
+		return img.Parameters
+	case "loras":
+		return strings.Join(img.LoRAs(), ",")
+	case "favorite":
+		return strconv.FormatBool(img.Annotation != nil && img.Annotation.Favorite)
+	case "rating":
+		if img.Annotation == nil || img.Annotation.Rating == 0 {
+			return ""
+		}
+		return strconv.Itoa(img.Annotation.Rating)
+	case "tags":
+		if img.Annotation == nil {
+			return ""
+		}
+		return strings.Join(img.Annotation.Tags, ",")
+	case "note":
+		if img.Annotation == nil {
+			return ""
+		}
+		return img.Annotation.Note
+	}
+	for k, v := range img.Metadata {
+		if catalog.ParamName(k) == column {
+			return v
+		}
+	}
+	return ""
+}
+
+// jsonlWriter writes images as JSON lines.
+type jsonlWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (w *jsonlWriter) Write(id string, img *image.Image) error {
+	return w.enc.Encode(&Image{ID: id, Type: img.Type(), Image: img})
+}
+
+func (w *jsonlWriter) Flush() error {
+	return w.w.Flush()
+}
+
+// csvWriter writes images as CSV rows. The header is written with the first row.
+type csvWriter struct {
+	w       *csv.Writer
+	columns []string
+	started bool
+}
+
+func (w *csvWriter) Write(id string, img *image.Image) error {
+	if err := w.header(); err != nil {
+		return err
+	}
+	row := make([]string, len(w.columns))
+	for i, col := range w.columns {
+		row[i] = Value(col, id, img)
+	}
+	return w.w.Write(row)
+}
+
+func (w *csvWriter) Flush() error {
+	// the header is written even if there are no images.
+	if err := w.header(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvWriter) header() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	return w.w.Write(w.columns)
+}
+
+// promptsWriter writes images as lines of options read by the "Prompts from file or textbox" script, e.g.
+// --prompt "a cat" --negative_prompt "blurry" --steps 20 --cfg_scale 7 --seed 1 --sampler_name "Euler a".
+type promptsWriter struct {
+	w *bufio.Writer
+}
+
+// promptOptions maps options of the script to names of parameters in metadata, which are written if images have them.
+var promptOptions = []struct {
+	option, param string
+	quoted        bool
+}{
+	{option: "steps", param: "steps"},
+	{option: "cfg_scale", param: "cfg-scale"},
+	{option: "seed", param: "seed"},
+	{option: "sampler_name", param: "sampler", quoted: true},
+}
+
+func (w *promptsWriter) Write(_ string, img *image.Image) error {
+	opts := []string{"--prompt", quote(img.Prompt)}
+	if img.NegativePrompt != "" {
+		opts = append(opts, "--negative_prompt", quote(img.NegativePrompt))
+	}
+	for _, o := range promptOptions {
+		v := Value(o.param, "", img)
+		if v == "" {
+			continue
+		}
+		if o.quoted {
+			v = quote(v)
+		} else if strings.ContainsAny(v, " \t\"'\\") {
+			// the script parses numbers, and other values break the line.
+			continue
+		}
+		opts = append(opts, "--"+o.option, v)
+	}
+	if width, height, ok := strings.Cut(Value("size", "", img), "x"); ok {
+		if _, err := strconv.Atoi(width); err == nil {
+			if _, err = strconv.Atoi(height); err == nil {
+				opts = append(opts, "--width", width, "--height", height)
+			}
+		}
+	}
+
+	_, err := fmt.Fprintln(w.w, strings.Join(opts, " "))
+	return err
+}
+
+func (w *promptsWriter) Flush() error {
+	return w.w.Flush()
+}
+
+// quoteReplacer escapes characters in double quotes of shlex and replaces line breaks.
+var quoteReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r\n", " ", "\n", " ", "\r", " ")
+
+// quote quotes the given value as the script splits lines with shlex, where line breaks are replaced with spaces
+// since each line is a job.
+func quote(s string) string {
+	return `"` + quoteReplacer.Replace(s) + `"`
+}
@@ -0,0 +1,210 @@
+// export_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/image"
+)
+
+var testImage = &image.Image{
+	Prompt:         "a \"cat\"\nsitting",
+	NegativePrompt: "blurry",
+	Checkpoint:     "sd15",
+	CreationTime:   time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC),
+	Pixel:          512 * 768,
+	Metadata: map[string]string{
+		"Steps":     "20",
+		"CFG scale": "7",
+		"Seed":      "1",
+		"Sampler":   "Euler a",
+		"Size":      "512x768",
+	},
+	Annotation: &image.Annotation{Favorite: true, Rating: 4, Tags: []string{"keeper", "print"}},
+}
+
+func TestValue(t *testing.T) {
+	cases := []struct {
+		column string
+		expect string
+	}{
+		{column: "id", expect: "a.png"},
+		{column: "checkpoint", expect: "sd15"},
+		{column: "creation-time", expect: "2023-04-01T12:00:00Z"},
+		{column: "cfg-scale", expect: "7"},
+		{column: "sampler", expect: "Euler a"},
+		{column: "favorite", expect: "true"},
+		{column: "rating", expect: "4"},
+		{column: "tags", expect: "keeper,print"},
+		{column: "note", expect: ""},
+		{column: "missing", expect: ""},
+	}
+	for _, c := range cases {
+		t.Run(c.column, func(t *testing.T) {
+			if res := Value(c.column, "a.png", testImage); res != c.expect {
+				t.Errorf("expect %q, got %q", c.expect, res)
+			}
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	cases := []struct {
+		format  string
+		columns []string
+		expect  string
+	}{
+		{
+			format:  FormatCSV,
+			columns: []string{"id", "steps", "tags"},
+			expect:  "id,steps,tags\na.png,20,\"keeper,print\"\n",
+		},
+		{
+			format: FormatPrompts,
+			expect: `--prompt "a \"cat\" sitting" --negative_prompt "blurry" --steps 20 --cfg_scale 7 --seed 1 ` +
+				`--sampler_name "Euler a" --width 512 --height 768` + "\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, c.format, c.columns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = w.Write("a.png", testImage); err != nil {
+				t.Fatal(err)
+			}
+			if err = w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if res := buf.String(); res != c.expect {
+				t.Errorf("expect %q, got %q", c.expect, res)
+			}
+		})
+	}
+
+	t.Run("jsonl", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, FormatJSONL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = w.Write("a.png", testImage); err != nil {
+			t.Fatal(err)
+		}
+		if err = w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		var res Image
+		if err = json.Unmarshal(buf.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+		expect := Image{ID: "a.png", Type: image.DocType, Image: testImage}
+		if !reflect.DeepEqual(res, expect) {
+			t.Errorf("expect %v, got %v", expect, res)
+		}
+	})
+
+	t.Run("empty csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, FormatCSV, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		rows, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expect := [][]string{DefaultColumns}; !reflect.DeepEqual(rows, expect) {
+			t.Errorf("expect %v, got %v", expect, rows)
+		}
+	})
+
+	for _, format := range []string{"xml", ""} {
+		if _, err := NewWriter(&bytes.Buffer{}, format, nil); err == nil {
+			t.Errorf("expect an error for %q", format)
+		}
+	}
+	if _, err := NewWriter(&bytes.Buffer{}, FormatCSV, []string{"id", " "}); err == nil {
+		t.Error("expect an error for an empty column")
+	}
+}
+
+func TestEach(t *testing.T) {
+	c, err := catalog.NewBleveMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	docs := make(map[string]catalog.Document)
+	for i := 0; i != PageSize+1; i++ {
+		docs[time.Duration(i).String()] = &image.Image{
+			Prompt:       "prompt",
+			CreationTime: now.Add(time.Duration(i) * time.Second),
+		}
+	}
+	if err = c.Index(context.Background(), docs); err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	req := &catalog.SearchRequest{Order: catalog.Ascending, Limit: 1}
+	err = Each(context.Background(), c, req, func(hit *catalog.Hit) error {
+		ids = append(ids, hit.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != PageSize+1 {
+		t.Errorf("expect %v images, got %v", PageSize+1, len(ids))
+	} else if ids[0] != "0s" || !strings.HasSuffix(ids[PageSize], "ns") {
+		t.Errorf("expect images in ascending order, got %v and %v", ids[0], ids[PageSize])
+	}
+
+	// images indexed while exporting don't shift pages.
+	seen := make(map[string]bool)
+	err = Each(context.Background(), c, &catalog.SearchRequest{}, func(hit *catalog.Hit) error {
+		if seen[hit.ID] {
+			t.Errorf("%v is exported twice", hit.ID)
+		}
+		seen[hit.ID] = true
+		if len(seen) == 1 {
+			return c.Index(context.Background(), map[string]catalog.Document{
+				"new": &image.Image{Prompt: "prompt", CreationTime: now.Add(time.Hour)},
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != PageSize+1 {
+		t.Errorf("expect %v images, got %v", PageSize+1, len(seen))
+	}
+}
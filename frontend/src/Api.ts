@@ -277,6 +277,8 @@ export class Api<SecurityDataType extends unknown> extends HttpClient<SecurityDa
       query?: {
         /** Search query. */
         query?: string;
+        /** Query string searching all indexed fields, e.g. prompt:"red dress" -negative:blurry steps:>30. */
+        q?: string;
         /** Retrieving the given sized images. */
         size?: "small" | "medium" | "large";
         /** Retrieving images that use the given checkpoint. */
@@ -8,7 +8,7 @@
  * http://opensource.org/licenses/mit-license.php
  */
 
-import {useEffect, useState} from 'react'
+import {useCallback, useEffect, useState} from 'react'
 import {Api, ApiConfig, Image as ImageInfo, Metadata} from "./Api.js";
 import {
   AppShell,
@@ -25,6 +25,7 @@ import {
 } from "@mantine/core";
 import Query from "./Query.tsx";
 import ImageDetail from "./ImageDetail.tsx";
+import Login from "./Login.tsx";
 import {Carousel, Embla, useAnimationOffsetEffect} from "@mantine/carousel";
 import {DatePickerInput} from '@mantine/dates';
 import dayjs from "dayjs";
@@ -32,7 +33,16 @@ import {useInputState} from "@mantine/hooks";
 
 const TRANSITION_DURATION = 200;
 
-const cfg: ApiConfig = {}
+// pages opened by share links send the token and the shared query string with every request.
+const searchParams = new URLSearchParams(window.location.search)
+const share = searchParams.get("share")
+const sharedQuery = searchParams.get("q")
+const withShare = (url: string) =>
+  share ? `${url}${url.includes("?") ? "&" : "?"}share=${encodeURIComponent(share)}` : url
+
+const cfg: ApiConfig = {
+  customFetch: (input, init) => fetch(typeof input === "string" ? withShare(input) : input, init),
+}
 if (import.meta.env.PROD) {
   cfg.baseUrl = `${window.location.protocol}//${window.location.hostname}:${window.location.port}/api/v1`
 }
@@ -50,14 +60,28 @@ function App() {
   const [thumbSize, setThumbSize] = useState(2)
   const [checkpoint, setCheckpoint] = useInputState<string | null>(null)
   const [checkpoints, setCheckpoints] = useState<string[]>([])
+  // loginRequired is set when the server requires authentication, and sessions counts successful logins.
+  const [loginRequired, setLoginRequired] = useState(false)
+  const [sessions, setSessions] = useState(0)
 
-  const getURL = (id: string) => `${api.baseUrl}/image/${encodeURIComponent(id)}`
+  const getURL = (id: string) => withShare(`${api.baseUrl}/image/${encodeURIComponent(id)}`)
+  // thumbnails in the grid, whose columns span thumbSize of 12 columns.
+  const getThumbnailURL = (id: string) =>
+    withShare(`${api.baseUrl}/image/${encodeURIComponent(id)}?w=${thumbSize <= 2 ? 256 : 512}`)
+  const onError = useCallback((err: Response) => {
+    if (err.status === 401 && !share) {
+      setLoginRequired(true)
+    } else {
+      console.error(err)
+    }
+  }, [])
 
   useEffect(() => {
     const fetchImages = async () => {
       const d = dayjs(date).startOf("day")
       const res = await api.images.getImages({
         query,
+        q: sharedQuery || undefined,
         page: page - 1,
         size: size === "small" || size === "medium" || size === "large" ? size : undefined,
         checkpoint: checkpoint || undefined,
@@ -74,16 +98,18 @@ function App() {
         setPage(res.data.metadata?.totalPages)
       }
     }
-    fetchImages().catch(console.error)
-  }, [page, query, size, checkpoint, order, date, thumbSize])
+    fetchImages().catch(onError)
+  }, [page, query, size, checkpoint, order, date, thumbSize, sessions, onError])
 
   useEffect(() => {
     const fetchCheckpoints = async () => {
       const res = await api.checkpoints.getCheckpoints()
       setCheckpoints(res.data)
     }
-    fetchCheckpoints().catch(console.error)
-  }, [])
+    if (!share) {
+      fetchCheckpoints().catch(onError)
+    }
+  }, [sessions, onError])
 
   const header = (
     <Header height={{base: 50, md: 70}} p="md" fixed>
@@ -157,7 +183,7 @@ function App() {
     images.map((image, index) => (
       <Grid.Col span={thumbSize} key={image.id}>
         <UnstyledButton onClick={() => setSelectedImage(index)}>
-          <Image src={getURL(image.id)} alt={image.prompt} radius="md" fit="scale-down" withPlaceholder/>
+          <Image src={getThumbnailURL(image.id)} alt={image.prompt} radius="md" fit="scale-down" withPlaceholder/>
         </UnstyledButton>
       </Grid.Col>
     ))
@@ -173,6 +199,10 @@ function App() {
 
   return (
     <AppShell padding="md" header={header} footer={footer}>
+      <Login baseUrl={api.baseUrl} opened={loginRequired} onLogin={() => {
+        setLoginRequired(false)
+        setSessions(sessions + 1)
+      }}/>
       <Modal opened={selectedImage !== null} onClose={() => setSelectedImage(null)} fullScreen
              transitionProps={{duration: TRANSITION_DURATION}}>
         <Carousel initialSlide={selectedImage || undefined} draggable={false} getEmblaApi={setEmbla}>
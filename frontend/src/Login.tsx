@@ -0,0 +1,61 @@
+/*
+ * Login.tsx
+ *
+ * Copyright (c) 2023 Junpei Kawamoto
+ *
+ * This software is released under the MIT License.
+ *
+ * http://opensource.org/licenses/mit-license.php
+ */
+
+import {FormEvent, useState} from "react";
+import {Button, Modal, PasswordInput, Stack, Text, TextInput} from "@mantine/core";
+import {useInputState} from "@mantine/hooks";
+
+type Props = {
+  baseUrl: string
+  opened: boolean
+  onLogin: () => void
+}
+
+function Login({baseUrl, opened, onLogin}: Props) {
+  const [user, setUser] = useInputState("")
+  const [password, setPassword] = useInputState("")
+  const [error, setError] = useState<string | null>(null)
+
+  const onSubmit = async (event: FormEvent) => {
+    event.preventDefault()
+    const res = await fetch(`${baseUrl}/login`, {
+      method: "POST",
+      headers: {"Content-Type": "application/json"},
+      credentials: "same-origin",
+      body: JSON.stringify({user, password}),
+    })
+    if (!res.ok) {
+      const body = await res.json().catch(() => null)
+      setError(body?.message || res.statusText)
+      return
+    }
+    setPassword("")
+    setError(null)
+    onLogin()
+  }
+
+  return (
+    <Modal opened={opened} onClose={() => undefined} withCloseButton={false} centered title="Log in">
+      <form onSubmit={(event) => {
+        onSubmit(event).catch((err) => setError(String(err)))
+      }}>
+        <Stack>
+          <TextInput label="User" value={user} onChange={setUser} autoComplete="username" required/>
+          <PasswordInput label="Password" value={password} onChange={setPassword} autoComplete="current-password"
+                         required/>
+          {error && <Text color="red" size="sm">{error}</Text>}
+          <Button type="submit">Log in</Button>
+        </Stack>
+      </form>
+    </Modal>
+  )
+}
+
+export default Login
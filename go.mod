@@ -4,6 +4,7 @@ go 1.20
 
 require (
 	github.com/blevesearch/bleve/v2 v2.3.9
+	github.com/blevesearch/bleve_index_api v1.0.5
 	github.com/brianvoe/gofakeit/v6 v6.23.2
 	github.com/go-openapi/errors v0.20.4
 	github.com/go-openapi/loads v0.21.2
@@ -15,8 +16,12 @@ require (
 	github.com/gohugoio/hugo v0.117.0
 	github.com/jessevdk/go-flags v1.5.0
 	github.com/jkawamoto/go-pngtext v0.1.0
+	go.etcd.io/bbolt v1.3.7
+	golang.org/x/crypto v0.21.0
 	golang.org/x/image v0.18.0
 	golang.org/x/net v0.23.0
+	golang.org/x/term v0.18.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -24,7 +29,6 @@ require (
 	github.com/asaskevich/govalidator v0.0.0-20230301143203-a9d515a09cc2 // indirect
 	github.com/bep/tmc v0.5.1 // indirect
 	github.com/bits-and-blooms/bitset v1.8.0 // indirect
-	github.com/blevesearch/bleve_index_api v1.0.5 // indirect
 	github.com/blevesearch/geo v0.1.17 // indirect
 	github.com/blevesearch/go-porterstemmer v1.0.3 // indirect
 	github.com/blevesearch/gtreap v0.1.1 // indirect
@@ -55,9 +59,7 @@ require (
 	github.com/mschoch/smat v0.2.0 // indirect
 	github.com/oklog/ulid v1.3.1 // indirect
 	github.com/rwcarlsen/goexif v0.0.0-20190401172101-9e8deecbddbd // indirect
-	go.etcd.io/bbolt v1.3.7 // indirect
 	go.mongodb.org/mongo-driver v1.12.1 // indirect
 	golang.org/x/sys v0.18.0 // indirect
 	google.golang.org/protobuf v1.33.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
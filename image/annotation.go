@@ -0,0 +1,73 @@
+// annotation.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package image
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxRating is the highest rating of images.
+	MaxRating = 5
+	// MaxTags is the maximum number of tags of an image, and MaxTagLength is the maximum number of characters of a tag.
+	MaxTags      = 50
+	MaxTagLength = 64
+	// MaxNoteLength is the maximum number of characters of a note.
+	MaxNoteLength = 4096
+)
+
+// Annotation is what users added to an image, e.g. whether it is a favourite. Annotations are not parsed from image
+// files but stored separately so that they are kept when images are indexed again.
+type Annotation struct {
+	Favorite bool `json:"favorite"`
+	// Rating is from 1 to MaxRating. Zero means the image isn't rated.
+	Rating int      `json:"rating"`
+	Tags   []string `json:"tags,omitempty"`
+	Note   string   `json:"note,omitempty"`
+}
+
+// IsZero returns true if the annotation has nothing.
+func (a *Annotation) IsZero() bool {
+	return a == nil || !a.Favorite && a.Rating == 0 && len(a.Tags) == 0 && a.Note == ""
+}
+
+// Normalize trims spaces of tags and the note, and removes empty and duplicated tags. It returns an error if the
+// annotation has invalid values.
+func (a *Annotation) Normalize() error {
+	if a.Rating < 0 || a.Rating > MaxRating {
+		return fmt.Errorf("rating must be from 0 to %v: %v", MaxRating, a.Rating)
+	}
+
+	var tags []string
+	seen := make(map[string]bool)
+	for _, t := range a.Tags {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		if utf8.RuneCountInString(t) > MaxTagLength {
+			return fmt.Errorf("tag %q is longer than %v characters", t, MaxTagLength)
+		}
+		seen[t] = true
+		tags = append(tags, t)
+	}
+	if len(tags) > MaxTags {
+		return fmt.Errorf("an image can have at most %v tags", MaxTags)
+	}
+	a.Tags = tags
+
+	a.Note = strings.TrimSpace(a.Note)
+	if utf8.RuneCountInString(a.Note) > MaxNoteLength {
+		return errors.New("note is too long")
+	}
+	return nil
+}
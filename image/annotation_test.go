@@ -0,0 +1,61 @@
+// annotation_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package image
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnnotationNormalize(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  Annotation
+		expect Annotation
+		err    bool
+	}{
+		{
+			name:   "tags and note",
+			input:  Annotation{Rating: 5, Tags: []string{" keeper", "", "keeper ", "print"}, Note: " lovely\n"},
+			expect: Annotation{Rating: 5, Tags: []string{"keeper", "print"}, Note: "lovely"},
+		},
+		{
+			name:   "empty",
+			input:  Annotation{Tags: []string{" "}},
+			expect: Annotation{},
+		},
+		{name: "negative rating", input: Annotation{Rating: -1}, err: true},
+		{name: "too high rating", input: Annotation{Rating: MaxRating + 1}, err: true},
+		{name: "long tag", input: Annotation{Tags: []string{strings.Repeat("あ", MaxTagLength+1)}}, err: true},
+		{name: "long note", input: Annotation{Note: strings.Repeat("a", MaxNoteLength+1)}, err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a := c.input
+			err := a.Normalize()
+			if c.err {
+				if err == nil {
+					t.Error("expect an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(a, c.expect) {
+				t.Errorf("expect %+v, got %+v", c.expect, a)
+			}
+			if a.IsZero() != reflect.DeepEqual(c.expect, Annotation{}) {
+				t.Errorf("expect IsZero is %v", !a.IsZero())
+			}
+		})
+	}
+}
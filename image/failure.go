@@ -0,0 +1,79 @@
+// failure.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package image
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"time"
+)
+
+const (
+	FailureDocType = "Failure"
+
+	// Classes of failures. They are single tokens so that they can be searched even if the index was created
+	// before the failure mapping existed.
+	ClassNoParameters           = "no_parameters"
+	ClassNotSupportedParameters = "not_supported_parameters"
+	ClassIO                     = "io"
+	ClassCorrupt                = "corrupt"
+)
+
+// FailureClasses is the list of all failure classes.
+var FailureClasses = []string{ClassNoParameters, ClassNotSupportedParameters, ClassIO, ClassCorrupt}
+
+// Failure records an image file that couldn't be parsed.
+type Failure struct {
+	Class       string    `json:"class"`
+	Message     string    `json:"message"`
+	Size        int64     `json:"size"`
+	ModTime     time.Time `json:"mod-time"`
+	Fingerprint string    `json:"fingerprint"`
+	FailedAt    time.Time `json:"failed-at"`
+}
+
+// NewFailure creates a failure record of the file described by the given info.
+func NewFailure(err error, info fs.FileInfo) *Failure {
+	return &Failure{
+		Class:       Classify(err),
+		Message:     err.Error(),
+		Size:        info.Size(),
+		ModTime:     info.ModTime(),
+		Fingerprint: Fingerprint(info),
+		FailedAt:    time.Now(),
+	}
+}
+
+func (*Failure) Type() string {
+	return FailureDocType
+}
+
+// Classify returns the failure class of the given error returned by ParseImageFile.
+func Classify(err error) string {
+	var pathErr *fs.PathError
+	switch {
+	case errors.Is(err, errNoParameters):
+		return ClassNoParameters
+	case errors.Is(err, errNotSupportedParameters):
+		return ClassNotSupportedParameters
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return ClassCorrupt
+	case errors.As(err, &pathErr):
+		return ClassIO
+	default:
+		return ClassCorrupt
+	}
+}
+
+// Fingerprint returns a string which changes when the file described by the given info is modified.
+func Fingerprint(info fs.FileInfo) string {
+	return fmt.Sprintf("%x-%x", info.Size(), info.ModTime().UnixNano())
+}
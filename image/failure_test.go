@@ -0,0 +1,37 @@
+// failure_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package image
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		err    error
+		expect string
+	}{
+		{err: errNoParameters, expect: ClassNoParameters},
+		{err: fmt.Errorf("%w: abc", errNotSupportedParameters), expect: ClassNotSupportedParameters},
+		{err: &fs.PathError{Op: "open", Path: "abc.png", Err: fs.ErrPermission}, expect: ClassIO},
+		{err: io.ErrUnexpectedEOF, expect: ClassCorrupt},
+		{err: errors.New("png: invalid format: not a PNG file"), expect: ClassCorrupt},
+	}
+	for _, c := range cases {
+		t.Run(c.err.Error(), func(t *testing.T) {
+			if res := Classify(c.err); res != c.expect {
+				t.Errorf("expect %v, got %v", c.expect, res)
+			}
+		})
+	}
+}
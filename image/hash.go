@@ -0,0 +1,91 @@
+// hash.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package image
+
+import (
+	"errors"
+	"fmt"
+	goimage "image"
+	"image/png"
+	"io"
+	"math/bits"
+	"path"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+const (
+	// hashWidth and hashHeight are the size of the grayscale image compared by the difference hash. Each row has one
+	// more pixel than the bits of the row since adjacent pixels are compared.
+	hashWidth  = 9
+	hashHeight = 8
+
+	// HashLength is the number of hexadecimal digits of perceptual hashes.
+	HashLength = (hashWidth - 1) * hashHeight / 4
+)
+
+// ComputeHash returns the perceptual hash of the image of the given name read from r, which is a difference hash
+// (dHash) of 64 bits in hexadecimal. Images which look the same, e.g. those resized or re-encoded, have the same or
+// close hashes in Hamming distance.
+func ComputeHash(r io.Reader, name string) (string, error) {
+	var (
+		src goimage.Image
+		err error
+	)
+	switch strings.ToLower(path.Ext(name)) {
+	case ".png":
+		src, err = png.Decode(r)
+	case ".webp":
+		src, err = webp.Decode(r)
+	default:
+		return "", fmt.Errorf("unsupported image format: %v", name)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// the scaler averages the pixels covered by each pixel of the small image.
+	gray := goimage.NewGray(goimage.Rect(0, 0, hashWidth, hashHeight))
+	draw.BiLinear.Scale(gray, gray.Bounds(), src, src.Bounds(), draw.Src, nil)
+
+	var hash uint64
+	for y := 0; y != hashHeight; y++ {
+		for x := 0; x != hashWidth-1; x++ {
+			hash <<= 1
+			if gray.GrayAt(x, y).Y < gray.GrayAt(x+1, y).Y {
+				hash |= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%0*x", HashLength, hash), nil
+}
+
+// HashDistance returns the Hamming distance between the given perceptual hashes, which is the number of different
+// bits.
+func HashDistance(a, b string) (int, error) {
+	x, err := parseHash(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := parseHash(b)
+	if err != nil {
+		return 0, err
+	}
+	return bits.OnesCount64(x ^ y), nil
+}
+
+func parseHash(s string) (uint64, error) {
+	if len(s) != HashLength {
+		return 0, errors.New("invalid hash: " + s)
+	}
+	return strconv.ParseUint(s, 16, 64)
+}
@@ -0,0 +1,105 @@
+// hash_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package image
+
+import (
+	"bytes"
+	goimage "image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+// encodePNG returns a PNG image of the given size whose pixels are given by fn.
+func encodePNG(t *testing.T, width, height int, fn func(x, y int) uint8) []byte {
+	t.Helper()
+
+	img := goimage.NewRGBA(goimage.Rect(0, 0, width, height))
+	for y := 0; y != height; y++ {
+		for x := 0; x != width; x++ {
+			v := fn(x, y)
+			img.Set(x, y, color.RGBA{R: v, G: v, B: v, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestComputeHash(t *testing.T) {
+	hash := func(data []byte, name string) string {
+		t.Helper()
+		res, err := ComputeHash(bytes.NewReader(data), name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	// brightness increases from left to right, so every pixel is darker than its right neighbour.
+	gradient := func(width int) func(x, y int) uint8 {
+		return func(x, _ int) uint8 {
+			return uint8(x * 255 / width)
+		}
+	}
+	if res := hash(encodePNG(t, 90, 80, gradient(90)), "a.png"); res != "ffffffffffffffff" {
+		t.Errorf("expect ffffffffffffffff, got %v", res)
+	}
+
+	// a pattern keeps its hash when it is resized.
+	pattern := func(scale int) func(x, y int) uint8 {
+		return func(x, y int) uint8 {
+			return uint8((x/scale*37 + y/scale*91) % 256)
+		}
+	}
+	small, large := hash(encodePNG(t, 90, 80, pattern(10)), "a.png"), hash(encodePNG(t, 180, 160, pattern(20)), "b.PNG")
+	if d, err := HashDistance(small, large); err != nil {
+		t.Fatal(err)
+	} else if d > 2 {
+		t.Errorf("expect close hashes, got %v and %v", small, large)
+	}
+
+	if _, err := ComputeHash(bytes.NewReader(encodePNG(t, 1, 1, gradient(1))), "a.jpg"); err == nil {
+		t.Error("expect an error for an unsupported format")
+	}
+	if _, err := ComputeHash(bytes.NewReader([]byte("image content")), "a.webp"); err == nil {
+		t.Error("expect an error for a broken image")
+	}
+}
+
+func TestHashDistance(t *testing.T) {
+	cases := []struct {
+		a, b   string
+		expect int
+		err    bool
+	}{
+		{a: "0000000000000000", b: "0000000000000000", expect: 0},
+		{a: "0000000000000000", b: "0000000000000003", expect: 2},
+		{a: "ffffffffffffffff", b: "0000000000000000", expect: 64},
+		{a: "0000000000000000", b: "000", err: true},
+		{a: "000000000000000g", b: "0000000000000000", err: true},
+	}
+	for _, c := range cases {
+		res, err := HashDistance(c.a, c.b)
+		if c.err {
+			if err == nil {
+				t.Errorf("expect an error for %v and %v", c.a, c.b)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res != c.expect {
+			t.Errorf("expect %v, got %v", c.expect, res)
+		}
+	}
+}
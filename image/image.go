@@ -9,15 +9,17 @@
 package image
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
-	"os"
-	"path/filepath"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
 	"time"
-
-	"github.com/blevesearch/bleve/v2"
-	"github.com/blevesearch/bleve/v2/analysis/analyzer/simple"
-	"github.com/blevesearch/bleve/v2/analysis/analyzer/standard"
-	"github.com/blevesearch/bleve/v2/mapping"
 )
 
 const (
@@ -28,8 +30,15 @@ const (
 	checkpointKey     = "Model"
 	stepsKey          = "Steps"
 	sizeKey           = "Size"
+	samplerKey        = "Sampler"
+
+	// maxFileSize is the maximum size of image files read into memory.
+	maxFileSize = 256 << 20
 )
 
+// loraRegexp matches LoRAs in prompts, e.g. <lora:name:0.8>.
+var loraRegexp = regexp.MustCompile(`(?i)<lora:([^:>]+)[^>]*>`)
+
 var (
 	errNoParameters           = errors.New("no parameters found")
 	errNotSupportedParameters = errors.New("parameter format is not supported")
@@ -42,25 +51,43 @@ type Image struct {
 	Pixel          int               `json:"pixel"`
 	CreationTime   time.Time         `json:"creation-time"`
 	Metadata       map[string]string `json:"metadata"`
+	// Parameters is the raw text of generation parameters, which Metadata is parsed from.
+	Parameters string `json:"parameters"`
+	// Hash is the perceptual hash computed by ComputeHash. It is empty if the pixels couldn't be decoded.
+	Hash string `json:"hash,omitempty"`
+	// Digest is the hex encoded SHA-256 hash of the file, which identifies its content while the file isn't modified
+	// after CreationTime.
+	Digest string `json:"digest,omitempty"`
+	// Annotation is what users added to the image. It is nil if the image doesn't have any annotations.
+	Annotation *Annotation `json:"annotation,omitempty"`
 }
 
 func (*Image) Type() string {
 	return DocType
 }
 
-func Analyzer(field string) string {
-	switch field {
-	case "prompt", "negative-prompt":
-		return standard.Name
-	case "checkpoint":
-		return simple.Name
-	default:
-		return ""
+// Sampler returns the name of the sampler used to generate the image.
+func (img *Image) Sampler() string {
+	return img.Metadata[samplerKey]
+}
+
+// LoRAs returns the names of LoRAs used in the prompt without duplicates.
+func (img *Image) LoRAs() []string {
+	var res []string
+	seen := make(map[string]bool)
+	for _, m := range loraRegexp.FindAllStringSubmatch(img.Prompt, -1) {
+		name := strings.TrimSpace(m[1])
+		if name != "" && !seen[name] {
+			seen[name] = true
+			res = append(res, name)
+		}
 	}
+	return res
 }
 
-func ParseImageFile(name string) (_ *Image, err error) {
-	f, err := os.Open(name)
+// ParseImageFile parses the image file of the given name in fsys.
+func ParseImageFile(fsys fs.FS, name string) (_ *Image, err error) {
+	f, err := fsys.Open(name)
 	if err != nil {
 		return nil, err
 	}
@@ -73,43 +100,51 @@ func ParseImageFile(name string) (_ *Image, err error) {
 		return nil, err
 	}
 
-	var img *Image
-	switch filepath.Ext(name) {
-	case ".png":
-		img, err = ParsePNG(f)
-		if err != nil {
-			return nil, err
+	r, ok := f.(io.ReadSeeker)
+	if !ok {
+		// image parsers require seekable readers but files in archives and remote storages are not.
+		if info.Size() > maxFileSize {
+			return nil, fmt.Errorf("%v is too large: %v bytes", name, info.Size())
 		}
-	case ".webp":
-		img, err = ParseWebP(f)
+		data, err := io.ReadAll(io.LimitReader(f, maxFileSize))
 		if err != nil {
 			return nil, err
 		}
-	default:
-		return nil, errors.New("filetype not supported")
+		r = bytes.NewReader(data)
 	}
 
-	img.CreationTime = info.ModTime()
-	return img, nil
-}
-
-func DocumentMapping() *mapping.DocumentMapping {
-	textFieldMapping := bleve.NewTextFieldMapping()
-	textFieldMapping.Analyzer = standard.Name
-
-	keywordFieldMapping := bleve.NewKeywordFieldMapping()
+	img, err := Parse(r, name)
+	if err != nil {
+		return nil, err
+	}
 
-	intFieldMapping := bleve.NewNumericFieldMapping()
+	// images whose pixels can't be decoded are still indexed by their parameters without hashes.
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	img.Hash, _ = ComputeHash(r, name)
 
-	dateTimeFieldMapping := bleve.NewDateTimeFieldMapping()
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	if _, err = io.Copy(h, r); err != nil {
+		return nil, err
+	}
+	img.Digest = hex.EncodeToString(h.Sum(nil))
 
-	docMapping := bleve.NewDocumentMapping()
-	docMapping.AddFieldMappingsAt("prompt", textFieldMapping)
-	docMapping.AddFieldMappingsAt("negative-prompt", textFieldMapping)
-	docMapping.AddFieldMappingsAt("checkpoint", keywordFieldMapping)
-	docMapping.AddFieldMappingsAt("pixel", intFieldMapping)
-	docMapping.AddFieldMappingsAt("creation-time", dateTimeFieldMapping)
-	docMapping.AddSubDocumentMapping("metadata", bleve.NewDocumentMapping())
+	img.CreationTime = info.ModTime()
+	return img, nil
+}
 
-	return docMapping
+// Parse parses an image read from the given reader. The file type is determined by the extension of the given name.
+func Parse(r io.ReadSeeker, name string) (*Image, error) {
+	switch path.Ext(name) {
+	case ".png":
+		return ParsePNG(r)
+	case ".webp":
+		return ParseWebP(r)
+	default:
+		return nil, errors.New("filetype not supported")
+	}
 }
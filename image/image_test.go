@@ -0,0 +1,35 @@
+// image_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package image
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImageLoRAs(t *testing.T) {
+	cases := []struct {
+		prompt string
+		expect []string
+	}{
+		{prompt: "a cat"},
+		{
+			prompt: "a cat, <lora:add_detail:0.8>, <LORA:cute style:1>, <lora:add_detail:0.5>, <hypernet:foo:1>",
+			expect: []string{"add_detail", "cute style"},
+		},
+		{prompt: "<lora:no_weight>", expect: []string{"no_weight"}},
+	}
+
+	for _, c := range cases {
+		res := (&Image{Prompt: c.prompt}).LoRAs()
+		if !reflect.DeepEqual(res, c.expect) {
+			t.Errorf("expect %v, got %v", c.expect, res)
+		}
+	}
+}
@@ -48,6 +48,7 @@ func ParsePNG(r io.ReadSeeker) (*Image, error) {
 		Checkpoint:     params[checkpointKey],
 		Pixel:          cfg.Height * cfg.Width,
 		Metadata:       params,
+		Parameters:     data.Text,
 	}
 	res.Metadata[sizeKey] = fmt.Sprintf("%vx%v", cfg.Width, cfg.Height)
 	delete(res.Metadata, promptKey)
@@ -0,0 +1,183 @@
+// strip.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+const (
+	// pngSignature is the first bytes of PNG files.
+	pngSignature = "\x89PNG\r\n\x1a\n"
+
+	// vp8xFlagEXIF and vp8xFlagXMP are flags of the VP8X chunk telling the file has EXIF and XMP chunks.
+	vp8xFlagEXIF = 0x08
+	vp8xFlagXMP  = 0x04
+)
+
+// pngMetadataChunks are types of PNG chunks storing metadata, i.e. textual data such as generation parameters, EXIF and
+// the modification time.
+var pngMetadataChunks = map[string]bool{"tEXt": true, "zTXt": true, "iTXt": true, "eXIf": true, "tIME": true}
+
+// ErrNotSeekable is returned by StripMetadata when the image requires random access but the reader isn't seekable.
+var ErrNotSeekable = errors.New("image can't be stripped without random access")
+
+// StripMetadata copies the image of the given name from r to w without metadata such as generation parameters. Pixel
+// data are copied as is without being decoded, and only headers of chunks are held in memory. PNG images are copied
+// in a single pass, while WebP images require r to be an io.ReadSeeker.
+func StripMetadata(w io.Writer, r io.Reader, name string) error {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".png":
+		return stripPNG(w, r)
+	case ".webp":
+		rs, ok := r.(io.ReadSeeker)
+		if !ok {
+			return ErrNotSeekable
+		}
+		return stripWebP(w, rs)
+	default:
+		return fmt.Errorf("unsupported image format: %v", name)
+	}
+}
+
+// stripPNG copies a PNG image without textual, EXIF and time chunks.
+func stripPNG(w io.Writer, r io.Reader) error {
+	sig := make([]byte, len(pngSignature))
+	if _, err := io.ReadFull(r, sig); err != nil {
+		return err
+	}
+	if string(sig) != pngSignature {
+		return errors.New("not png file")
+	}
+	if _, err := w.Write(sig); err != nil {
+		return err
+	}
+
+	// each chunk consists of the length, the type, the data, and the CRC.
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, header); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		size := int64(binary.BigEndian.Uint32(header[:4])) + 4
+		if pngMetadataChunks[string(header[4:])] {
+			if _, err := io.CopyN(io.Discard, r, size); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := w.Write(header); err != nil {
+			return err
+		}
+		if _, err := io.CopyN(w, r, size); err != nil {
+			return err
+		}
+		if string(header[4:]) == "IEND" {
+			return nil
+		}
+	}
+}
+
+// webpChunk is a chunk of a WebP image.
+type webpChunk struct {
+	id     string
+	offset int64
+	// size is the size of the data excluding the padding byte.
+	size int64
+}
+
+// padded returns the size of the data including the padding byte, which chunks of odd sizes have.
+func (c *webpChunk) padded() int64 {
+	return c.size + c.size&1
+}
+
+// stripWebP copies a WebP image without EXIF and XMP chunks. The file is read twice since the RIFF header has the size
+// of the stripped file.
+func stripWebP(w io.Writer, r io.ReadSeeker) error {
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return err
+	}
+	if string(header[:4]) != "RIFF" || string(header[8:]) != "WEBP" {
+		return errors.New("not webp file")
+	}
+
+	var (
+		chunks []webpChunk
+		total  int64 = 4
+	)
+	for offset := int64(len(header)); ; {
+		h := make([]byte, 8)
+		if _, err := io.ReadFull(r, h); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+		c := webpChunk{id: string(h[:4]), offset: offset + 8, size: int64(binary.LittleEndian.Uint32(h[4:]))}
+		if c.id != "EXIF" && c.id != "XMP " {
+			chunks = append(chunks, c)
+			total += 8 + c.padded()
+		}
+		offset = c.offset + c.padded()
+		if _, err := r.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	binary.LittleEndian.PutUint32(header[4:8], uint32(total))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	h := make([]byte, 8)
+	for _, c := range chunks {
+		if _, err := r.Seek(c.offset, io.SeekStart); err != nil {
+			return err
+		}
+		copy(h, c.id)
+		binary.LittleEndian.PutUint32(h[4:], uint32(c.size))
+		if _, err := w.Write(h); err != nil {
+			return err
+		}
+
+		var body io.Reader = io.LimitReader(r, c.padded())
+		if c.id == "VP8X" {
+			// the flags tell readers the file has the removed chunks.
+			data, err := io.ReadAll(body)
+			if err != nil {
+				return err
+			}
+			if len(data) != 0 {
+				data[0] &^= vp8xFlagEXIF | vp8xFlagXMP
+			}
+			body = bytes.NewReader(data)
+		}
+		n, err := io.Copy(w, body)
+		if err != nil {
+			return err
+		}
+		if n == c.size && n != c.padded() {
+			// some writers omit the padding byte of the last chunk.
+			_, err = w.Write([]byte{0})
+		} else if n != c.padded() {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// strip_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	goimage "image"
+	"image/png"
+	"io"
+	"testing"
+)
+
+// pngChunk returns a PNG chunk of the given type and data.
+func pngChunk(typ string, data []byte) []byte {
+	res := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	res = append(res, typ...)
+	res = append(res, data...)
+	return binary.BigEndian.AppendUint32(res, crc32.ChecksumIEEE(res[4:]))
+}
+
+// riffChunk returns a RIFF chunk of the given ID and data, which is padded to an even size.
+func riffChunk(id string, data []byte) []byte {
+	res := append([]byte(id), binary.LittleEndian.AppendUint32(nil, uint32(len(data)))...)
+	res = append(res, data...)
+	if len(data)%2 == 1 {
+		res = append(res, 0)
+	}
+	return res
+}
+
+func TestStripPNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, goimage.NewGray(goimage.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+	// the text chunk is inserted after the IHDR chunk.
+	ihdr := len(pngSignature) + 25
+	text := pngChunk("tEXt", []byte("parameters\x00a cat\nSteps: 20"))
+	src := append(append(append([]byte{}, buf.Bytes()[:ihdr]...), text...), buf.Bytes()[ihdr:]...)
+
+	var res bytes.Buffer
+	if err := StripMetadata(&res, bytes.NewReader(src), "a.png"); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res.Bytes(), buf.Bytes()) {
+		t.Errorf("expect %x, got %x", buf.Bytes(), res.Bytes())
+	}
+	if _, err := ParsePNG(bytes.NewReader(res.Bytes())); !errors.Is(err, errNoParameters) {
+		t.Errorf("expect %v, got %v", errNoParameters, err)
+	}
+}
+
+func TestStripWebP(t *testing.T) {
+	webp := func(chunks ...[]byte) []byte {
+		body := []byte("WEBP")
+		for _, c := range chunks {
+			body = append(body, c...)
+		}
+		return append(append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(body)))...), body...)
+	}
+	vp8x := func(flags byte) []byte {
+		return riffChunk("VP8X", []byte{flags, 0, 0, 0, 1, 0, 0, 1, 0, 0})
+	}
+	pixels := riffChunk("VP8L", []byte{0x2f, 1, 2})
+
+	src := webp(vp8x(vp8xFlagEXIF|vp8xFlagXMP|0x10), pixels, riffChunk("EXIF", []byte("params")),
+		riffChunk("XMP ", []byte("xmp")))
+	expect := webp(vp8x(0x10), pixels)
+
+	var res bytes.Buffer
+	if err := StripMetadata(&res, bytes.NewReader(src), "a.webp"); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res.Bytes(), expect) {
+		t.Errorf("expect %x, got %x", expect, res.Bytes())
+	}
+
+	if err := StripMetadata(&res, bytes.NewReader(src), "a.jpg"); err == nil {
+		t.Error("expect an error")
+	}
+	if err := StripMetadata(&res, io.MultiReader(bytes.NewReader(src)), "a.webp"); !errors.Is(err, ErrNotSeekable) {
+		t.Errorf("expect %v, got %v", ErrNotSeekable, err)
+	}
+}
@@ -77,6 +77,7 @@ func ParseWebP(r io.Reader) (*Image, error) {
 		Checkpoint:     params[checkpointKey],
 		Pixel:          int(width * height),
 		Metadata:       params,
+		Parameters:     text,
 	}
 	res.Metadata[sizeKey] = fmt.Sprintf("%vx%v", width, height)
 	delete(res.Metadata, promptKey)
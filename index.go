@@ -9,76 +9,210 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"log"
 	"os"
-	"path/filepath"
+	"os/signal"
+	"path"
+	"syscall"
 	"time"
 
-	"github.com/blevesearch/bleve/v2"
-	"github.com/blevesearch/bleve/v2/search/query"
-
+	"github.com/jkawamoto/sd-image-viewer/archive"
+	"github.com/jkawamoto/sd-image-viewer/catalog"
 	"github.com/jkawamoto/sd-image-viewer/image"
+	"github.com/jkawamoto/sd-image-viewer/source"
+	"github.com/jkawamoto/sd-image-viewer/thumbnail"
+	"github.com/jkawamoto/sd-image-viewer/trash"
+	"github.com/jkawamoto/sd-image-viewer/userdata"
 )
 
 const (
 	pngExt  = ".png"
 	webpExt = ".webp"
-	posFile = ".pos"
 
 	maxBatchSize = 100
+	// maxEntrySize is the maximum size of entries of archives read into memory while indexing them, which is the same
+	// as the limit of the image parsers.
+	maxEntrySize = 256 << 20
+
+	// indexVersion identifies the scheme of document IDs, which are names of files relative to the library root.
+	indexVersion = "2"
 )
 
-func newIndex(name string) (_ bleve.Index, created bool, err error) {
-	index, err := bleve.Open(name)
-	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
-		indexMapping := bleve.NewIndexMapping()
-		indexMapping.AddDocumentMapping(image.DocType, image.DocumentMapping())
+// userdataFileSuffix is appended to the path of the index to get the file of the user data, e.g. annotations.
+const userdataFileSuffix = ".userdata"
 
-		index, err = bleve.New(name, indexMapping)
-		created = true
-	}
+const (
+	indexVersionKey = "version"
+	lastIndexedKey  = "last-indexed"
+)
+
+// newIndex opens the index of the given name, which is created if it doesn't exist. An index whose documents have
+// fields of an older schema is rebuilt, i.e. reset and reported as created, so that all images are indexed again.
+func newIndex(name string, logger *log.Logger) (_ catalog.Catalog, created bool, err error) {
+	c, created, err := catalog.OpenBleve(name)
 	if err != nil {
 		return nil, false, err
 	}
+	if !created {
+		outdated, err := c.Outdated()
+		if err != nil {
+			return nil, false, errors.Join(err, c.Close())
+		}
+		if outdated {
+			logger.Println("The index was created by an older version and will be rebuilt")
+			if err = c.Reset(); err != nil {
+				return nil, false, errors.Join(fmt.Errorf("failed to rebuild the index: %w", err), c.Close())
+			}
+			created = true
+		}
+	}
+	if created {
+		if err = markUpdated(c); err != nil {
+			return nil, false, errors.Join(err, c.Close())
+		}
+	}
+	return c, created, nil
+}
 
-	return index, created, nil
+// isOutdated returns true if documents in the given catalog have IDs of an older scheme.
+func isOutdated(c catalog.Catalog) (bool, error) {
+	v, err := c.GetMeta(indexVersionKey)
+	if err != nil {
+		return false, err
+	}
+	return string(v) != indexVersion, nil
 }
 
-func indexDir(ctx context.Context, dir string, index bleve.Index, force bool, logger *log.Logger) (err error) {
-	var lastIndexed time.Time
+// markUpdated records documents in the given catalog have IDs of the current scheme.
+func markUpdated(c catalog.Catalog) error {
+	return c.SetMeta(indexVersionKey, []byte(indexVersion))
+}
 
-	posFileName := filepath.Join(dir, posFile)
-	info, err := os.Stat(posFileName)
-	if os.IsNotExist(err) {
-		// not index yet
-	} else if err != nil {
-		return err
-	} else if !force {
-		lastIndexed = info.ModTime()
+// library is a source of images whose IDs are prefixed with its name.
+type library struct {
+	Name   string
+	Source source.Source
+}
+
+// id returns the ID of the file of the given name in this library.
+func (l *library) id(name string) string {
+	if l.Name == "" {
+		return name
+	}
+	return l.Name + "/" + name
+}
+
+// lastIndexedKey returns the key of the metadata storing when this library was indexed.
+func (l *library) lastIndexedKey() string {
+	if l.Name == "" {
+		return lastIndexedKey
 	}
+	return lastIndexedKey + "/" + l.Name
+}
+
+// indexOptions are options of an indexing pass.
+type indexOptions struct {
+	// force reindexes files even if they haven't been modified.
+	force bool
+	// ignore is a list of patterns of files which are not indexed.
+	ignore []string
+	// verbose logs each indexed file.
+	verbose *log.Logger
+	// thumbnails creates thumbnails of indexed images in the background if it isn't nil.
+	thumbnails *thumbnail.Cache
+	// userdata adds annotations to indexed images if it isn't nil. Annotations of files which don't exist in source
+	// anymore follow the files when they are found with other names.
+	userdata *userdata.Store
+	source   source.Source
+}
+
+func indexDir(ctx context.Context, lib *library, c catalog.Catalog, opts *indexOptions, logger *log.Logger) (err error) {
+	key := lib.lastIndexedKey()
+
+	var lastIndexed time.Time
+	if !opts.force {
+		v, err := c.GetMeta(key)
+		if err != nil {
+			return err
+		}
+		if v != nil {
+			if err = lastIndexed.UnmarshalText(v); err != nil {
+				return fmt.Errorf("failed to parse the last indexed time: %w", err)
+			}
+		}
+	}
+
+	started := time.Now()
 	defer func() {
 		if err == nil {
-			f, e := os.Create(posFileName)
-			err = errors.Join(err, e, f.Close())
+			v, _ := started.MarshalText()
+			err = c.SetMeta(key, v)
 		}
 	}()
 
-	b := index.NewBatch()
-	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+	failures, err := loadFailures(ctx, c)
+	if err != nil {
+		return fmt.Errorf("failed to load failure records: %w", err)
+	}
+
+	var orphaned map[int64][]orphan
+	if opts.userdata != nil {
+		if orphaned, err = findOrphans(opts.userdata, opts.source); err != nil {
+			return fmt.Errorf("failed to find annotations of missing files: %w", err)
+		}
+	}
+
+	b := make(map[string]catalog.Document)
+	// flush indexes the batch once it is full.
+	flush := func() error {
+		if len(b) != maxBatchSize {
+			return nil
+		}
+		if err := indexAnnotated(ctx, c, opts.userdata, b); err != nil {
+			return fmt.Errorf("failed to index items: %w", err)
+		}
+		b = make(map[string]catalog.Document)
+		return nil
+	}
+	err = lib.Source.Walk(ctx, func(name string, d fs.DirEntry, err error) error {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
 		if err != nil {
+			if d != nil && d.IsDir() && name != "." {
+				// an unreadable directory or a broken archive shouldn't stop indexing other files.
+				logger.Printf("Failed to read %v: %v", name, err)
+				return fs.SkipDir
+			}
 			return err
 		}
+		if name == trash.DirName && d.IsDir() {
+			// deleted images are not indexed until they are restored.
+			return fs.SkipDir
+		}
+		if name != "." && ignored(opts.ignore, name) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
 		if d.IsDir() {
+			if archive.IsArchive(name) {
+				// entries of archives are indexed again only if the archives are modified.
+				if info, err := d.Info(); err == nil && info.ModTime().Before(lastIndexed) {
+					return fs.SkipDir
+				}
+				opts.verbose.Printf("Indexing images in %v", lib.id(name))
+			}
 			return nil
 		}
-		if ext := filepath.Ext(path); ext != pngExt && ext != webpExt {
+		if ext := path.Ext(name); ext != pngExt && ext != webpExt {
 			return nil
 		}
 		info, err := d.Info()
@@ -87,41 +221,232 @@ func indexDir(ctx context.Context, dir string, index bleve.Index, force bool, lo
 			return nil
 		}
 
-		if info.ModTime().Before(lastIndexed) {
-			return nil
+		id := lib.id(name)
+		// entries of archives are read once from the walk instead of opening the archives again for each of them.
+		var fsys fs.FS = lib.Source
+		if e, ok := d.(*source.Entry); ok {
+			if fsys, err = readEntry(name, e, info); err != nil {
+				logger.Printf("Failed to read an image file %v: %v", id, err)
+				b[id] = image.NewFailure(err, info)
+				return flush()
+			}
 		}
 
-		img, err := image.ParseImageFile(path)
-		if err != nil {
-			logger.Printf("Failed to parse an image file: %v", err)
-			return nil
+		// moved files usually keep their modification times, so they are checked before skipping unmodified files.
+		moved := false
+		if candidates := orphaned[info.Size()]; len(candidates) != 0 {
+			from, err := adoptAnnotation(ctx, c, opts.userdata, fsys, name, id, candidates)
+			if err != nil {
+				logger.Printf("Failed to look for the annotation of %v: %v", id, err)
+			} else if from != "" {
+				logger.Printf("Moved the annotation of %v to %v", from, id)
+				orphaned[info.Size()] = removeOrphan(candidates, from)
+				if err = c.Delete(ctx, from); err != nil {
+					return fmt.Errorf("failed to remove items: %w", err)
+				}
+				moved = true
+			}
 		}
 
-		logger.Printf("Indexing %v", path)
-		err = b.Index(path, img)
-		if err != nil {
-			return fmt.Errorf("failed to index an image: %w", err)
+		if _, _, inArchive := archive.Split(name); !inArchive && !moved && info.ModTime().Before(lastIndexed) {
+			return nil
+		}
+		if !opts.force && failures[id] == image.Fingerprint(info) {
+			// this file has failed to be parsed and hasn't been changed since then.
+			return nil
 		}
 
-		if b.Size() == maxBatchSize {
-			if err = index.Batch(b); err != nil {
-				return fmt.Errorf("failed to index items: %w", err)
+		var doc catalog.Document
+		img, err := image.ParseImageFile(fsys, name)
+		if err != nil {
+			logger.Printf("Failed to parse an image file %v: %v", id, err)
+			doc = image.NewFailure(err, info)
+		} else {
+			opts.verbose.Printf("Indexing %v", id)
+			doc = img
+			if opts.thumbnails != nil {
+				createThumbnail(ctx, opts.thumbnails, fsys, name, id, img.Digest, logger)
 			}
-			b.Reset()
 		}
 
-		return nil
+		b[id] = doc
+		return flush()
 	})
 	if err != nil {
 		return err
 	}
-	if b.Size() != 0 {
-		return index.Batch(b)
+	if len(b) != 0 {
+		return indexAnnotated(ctx, c, opts.userdata, b)
 	}
 	return nil
 }
 
-func pruneIndex(ctx context.Context, index bleve.Index, logger *log.Logger) error {
+// indexAnnotated indexes the given documents with their annotations in data, which can be nil. The annotations are
+// read exclusively with requests modifying them so that annotations modified while parsing the files aren't lost.
+func indexAnnotated(
+	ctx context.Context, c catalog.Catalog, data *userdata.Store, docs map[string]catalog.Document,
+) error {
+	if data == nil {
+		return c.Index(ctx, docs)
+	}
+	return data.Exclusive(func() error {
+		for id, doc := range docs {
+			img, ok := doc.(*image.Image)
+			if !ok {
+				continue
+			}
+			a, err := data.Annotation(id)
+			if err != nil {
+				return fmt.Errorf("failed to read the annotation of %v: %w", id, err)
+			}
+			img.Annotation = a
+		}
+		return c.Index(ctx, docs)
+	})
+}
+
+// createThumbnail creates the thumbnail of the given file of the given digest. Thumbnails of entries of archives read
+// into memory are created while walking the archives since opening the entries again requires reading the archives
+// again.
+func createThumbnail(
+	ctx context.Context, thumbs *thumbnail.Cache, fsys fs.FS, name, id, digest string, logger *log.Logger,
+) {
+	if _, ok := fsys.(*memFS); !ok {
+		thumbs.Prefetch(ctx, fsys, name, digest)
+		return
+	}
+	if err := thumbs.Create(fsys, name, digest); err != nil {
+		logger.Printf("Failed to create a thumbnail of %v: %v", id, err)
+	}
+}
+
+// readEntry reads the given entry of an archive being visited, and returns a file system which has only the entry of
+// the given name.
+func readEntry(name string, e *source.Entry, info fs.FileInfo) (fs.FS, error) {
+	if info.Size() > maxEntrySize {
+		return nil, fmt.Errorf("%v is too large: %v bytes", name, info.Size())
+	}
+	data, err := io.ReadAll(io.LimitReader(e.Open(), maxEntrySize))
+	if err != nil {
+		return nil, err
+	}
+	return &memFS{name: name, data: data, info: info}, nil
+}
+
+// memFS is a file system of a file read into memory, which can be opened many times.
+type memFS struct {
+	name string
+	data []byte
+	info fs.FileInfo
+}
+
+func (m *memFS) Open(name string) (fs.File, error) {
+	if name != m.name {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &memFile{Reader: bytes.NewReader(m.data), info: m.info}, nil
+}
+
+// memFile is a file opened from memFS.
+type memFile struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (*memFile) Close() error {
+	return nil
+}
+
+// orphan is an annotation of a file which doesn't exist anymore.
+type orphan struct {
+	id   string
+	file *userdata.File
+}
+
+// findOrphans returns annotations of images whose files don't exist in src, keyed by the sizes of the files.
+// Annotations without file information can't be found again and are omitted.
+func findOrphans(data *userdata.Store, src source.Source) (map[int64][]orphan, error) {
+	annotated, err := data.Annotated()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[int64][]orphan)
+	for id, file := range annotated {
+		if file == nil {
+			continue
+		}
+		if _, err = src.Stat(id); errors.Is(err, fs.ErrNotExist) {
+			res[file.Size] = append(res[file.Size], orphan{id: id, file: file})
+		}
+	}
+	return res, nil
+}
+
+// adoptAnnotation moves the annotation of the candidate having the same content as the file of the given name to id,
+// and returns the ID of the candidate. It returns an empty string if no candidates match, or id is already indexed or
+// annotated, i.e. the file isn't a moved one but a copy.
+func adoptAnnotation(
+	ctx context.Context, c catalog.Catalog, data *userdata.Store, fsys fs.FS, name, id string, candidates []orphan,
+) (string, error) {
+	if img, err := c.Get(ctx, id); err != nil || img != nil {
+		return "", err
+	}
+	if a, err := data.Annotation(id); err != nil || a != nil {
+		return "", err
+	}
+	file, err := userdata.FileOf(fsys, name)
+	if err != nil {
+		return "", err
+	}
+	for _, o := range candidates {
+		if *o.file != *file {
+			continue
+		}
+		moved, err := data.MoveAnnotation(o.id, id)
+		if err != nil || !moved {
+			return "", err
+		}
+		return o.id, nil
+	}
+	return "", nil
+}
+
+// removeOrphan returns the orphans except the one of the given ID.
+func removeOrphan(orphans []orphan, id string) []orphan {
+	res := make([]orphan, 0, len(orphans))
+	for _, o := range orphans {
+		if o.id != id {
+			res = append(res, o)
+		}
+	}
+	return res
+}
+
+// loadFailures returns the fingerprints of files that failed to be parsed, keyed by their names.
+func loadFailures(ctx context.Context, c catalog.Catalog) (map[string]string, error) {
+	res := make(map[string]string)
+	req := &catalog.FailureRequest{Limit: maxBatchSize}
+	for {
+		failures, err := c.SearchFailures(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range failures.Hits {
+			res[v.ID] = v.Failure.Fingerprint
+		}
+		if len(failures.Hits) < req.Limit {
+			return res, nil
+		}
+		req.After = failures.Next
+	}
+}
+
+func pruneIndex(ctx context.Context, c catalog.Catalog, src source.Source, logger *log.Logger) error {
 	size := 100
 	from := 0
 
@@ -131,29 +456,154 @@ func pruneIndex(ctx context.Context, index bleve.Index, logger *log.Logger) erro
 			return ctx.Err()
 		}
 
-		res, err := index.Search(bleve.NewSearchRequestOptions(query.NewMatchAllQuery(), size, from, false))
+		ids, err := c.IDs(ctx, from, size)
 		if err != nil {
 			return err
 		}
-		if len(res.Hits) == 0 {
+		if len(ids) == 0 {
 			logger.Println("Finished pruning index")
 			return nil
 		}
 		from += size
 
-		b := index.NewBatch()
-		for _, v := range res.Hits {
-			_, err = os.Stat(v.ID)
-			if os.IsNotExist(err) {
-				logger.Printf("Removing %v from index", v.ID)
-				b.Delete(v.ID)
+		var removed []string
+		for _, id := range ids {
+			_, err := src.Stat(id)
+			if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrInvalid) {
+				// IDs of older versions are absolute paths, which are invalid names.
+				logger.Printf("Removing %v from index", id)
+				removed = append(removed, id)
 				from--
 			} else if err != nil {
 				logger.Printf("Failed to stat a file: %v", err)
 			}
 		}
-		if err = index.Batch(b); err != nil {
+		if err = c.Delete(ctx, removed...); err != nil {
 			return fmt.Errorf("failed to remove items: %w", err)
 		}
 	}
 }
+
+// pruneLibraries removes documents of files which don't exist in src. If outdated is true, it also marks the index is
+// updated after removing documents with IDs of an older scheme.
+func pruneLibraries(ctx context.Context, c catalog.Catalog, src source.Source, outdated bool, logger *log.Logger) error {
+	if err := pruneIndex(ctx, c, src, logger); err != nil {
+		return err
+	}
+	if outdated {
+		if err := markUpdated(c); err != nil {
+			return fmt.Errorf("failed to update the index version: %w", err)
+		}
+	}
+	return nil
+}
+
+// indexCommand runs one indexing pass and exits.
+type indexCommand struct {
+	global *options
+	Force  bool        `long:"force" description:"force reindexing all images"`
+	Prune  bool        `long:"prune" description:"remove non exiting images from the index"`
+	Args   libraryArgs `positional-args:"yes"`
+
+	logger *log.Logger
+}
+
+func (c *indexCommand) Execute([]string) (err error) {
+	cfg, err := loadConfig(c.global.Config, defaultConfig(), func(cfg *Config) {
+		c.global.apply(cfg)
+		c.Args.apply(cfg)
+	})
+	if err != nil {
+		return err
+	}
+	libs, src, err := openLibraries(cfg)
+	if err != nil {
+		return err
+	}
+
+	index, created, err := newIndex(cfg.Index, c.logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, index.Close())
+	}()
+
+	force, prune := c.Force || created, c.Prune
+	outdated, err := isOutdated(index)
+	if err != nil {
+		return err
+	}
+	if outdated {
+		c.logger.Println("The index was created by an older version and will be rebuilt")
+		force, prune = true, true
+	}
+
+	// an interrupted pass stops after the current batch is committed so that the index is closed properly.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if prune {
+		if err = pruneLibraries(ctx, index, src, outdated, c.logger); err != nil {
+			return fmt.Errorf("failed to prune index: %w", err)
+		}
+	}
+
+	data, err := userdata.Open(cfg.Index + userdataFileSuffix)
+	if err != nil {
+		return fmt.Errorf("failed to open the user data: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, data.Close())
+	}()
+
+	verbose, _ := newVerboseLogger(c.logger, cfg)
+	for _, lib := range libs {
+		opts := &indexOptions{
+			force:    force,
+			ignore:   cfg.ignorePatterns(lib.Name),
+			verbose:  verbose,
+			userdata: data,
+			source:   src,
+		}
+		if err = indexDir(ctx, lib, index, opts, c.logger); err != nil {
+			return fmt.Errorf("failed to index files in %v: %w", lib.Source, err)
+		}
+	}
+	return nil
+}
+
+// pruneCommand removes documents of files which don't exist anymore.
+type pruneCommand struct {
+	global *options
+	Args   libraryArgs `positional-args:"yes"`
+
+	logger *log.Logger
+}
+
+func (c *pruneCommand) Execute([]string) (err error) {
+	cfg, err := loadConfig(c.global.Config, defaultConfig(), func(cfg *Config) {
+		c.global.apply(cfg)
+		c.Args.apply(cfg)
+	})
+	if err != nil {
+		return err
+	}
+	_, src, err := openLibraries(cfg)
+	if err != nil {
+		return err
+	}
+
+	index, err := openExistingIndex(cfg.Index)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, index.Close())
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// an outdated index stays marked as outdated since its images need to be reindexed by the index command.
+	return pruneLibraries(ctx, index, src, false, c.logger)
+}
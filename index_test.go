@@ -0,0 +1,189 @@
+// index_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	goimage "image"
+	"image/png"
+	"io"
+	"io/fs"
+	"log"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/image"
+	"github.com/jkawamoto/sd-image-viewer/userdata"
+)
+
+// mapSource is a source of files in memory, which counts how many times each file is opened.
+type mapSource struct {
+	fstest.MapFS
+	opened map[string]int
+}
+
+func (m *mapSource) Open(name string) (fs.File, error) {
+	m.opened[name]++
+	return m.MapFS.Open(name)
+}
+
+func (m *mapSource) Walk(_ context.Context, fn fs.WalkDirFunc) error {
+	return fs.WalkDir(m.MapFS, ".", fn)
+}
+
+// newPNG returns a PNG image having the given generation parameters.
+func newPNG(t *testing.T, parameters string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, goimage.NewGray(goimage.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+	data := append([]byte("parameters\x00"), parameters...)
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(append(chunk, "tEXt"...), data...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	// the text chunk follows the signature and the IHDR chunk.
+	ihdr := 8 + 25
+	return append(append(append([]byte{}, buf.Bytes()[:ihdr]...), chunk...), buf.Bytes()[ihdr:]...)
+}
+
+func TestIndexDir(t *testing.T) {
+	c, err := catalog.NewBleveMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// files are modified after each pass so that only failure records decide whether they are parsed again.
+	modTime := time.Now().Add(time.Hour)
+	files := fstest.MapFS{
+		"a.png":      {Data: newPNG(t, "a cat\nSteps: 20, Sampler: Euler a"), ModTime: modTime},
+		"broken.png": {Data: []byte("not a png"), ModTime: modTime},
+		"note.txt":   {Data: []byte("not an image"), ModTime: modTime},
+	}
+	src := &mapSource{MapFS: files, opened: make(map[string]int)}
+	lib := &library{Source: src}
+	logger := log.New(io.Discard, "", 0)
+	opts := &indexOptions{verbose: logger}
+	ctx := context.Background()
+
+	failure := func(t *testing.T) *image.Failure {
+		t.Helper()
+		res, err := c.SearchFailures(ctx, &catalog.FailureRequest{Limit: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Hits) == 0 {
+			return nil
+		}
+		if len(res.Hits) != 1 || res.Hits[0].ID != "broken.png" {
+			t.Fatalf("expect a failure of broken.png, got %v", res.Hits)
+		}
+		return res.Hits[0].Failure
+	}
+
+	if err = indexDir(ctx, lib, c, opts, logger); err != nil {
+		t.Fatal(err)
+	}
+	if img, err := c.Get(ctx, "a.png"); err != nil || img == nil || img.Prompt != "a cat" {
+		t.Errorf("expect a.png is indexed, got %v (%v)", img, err)
+	}
+	first := failure(t)
+	if first == nil {
+		t.Fatal("expect a failure record")
+	}
+	info, err := files.Stat("broken.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Class != image.ClassCorrupt {
+		t.Errorf("expect %v, got %v", image.ClassCorrupt, first.Class)
+	}
+	if expect := image.Fingerprint(info); first.Fingerprint != expect {
+		t.Errorf("expect %v, got %v", expect, first.Fingerprint)
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		if err = indexDir(ctx, lib, c, opts, logger); err != nil {
+			t.Fatal(err)
+		}
+		if n := src.opened["broken.png"]; n != 1 {
+			t.Errorf("expect the unchanged file isn't parsed again, got %v times", n)
+		}
+		if res := failure(t); res == nil || !res.FailedAt.Equal(first.FailedAt) {
+			t.Errorf("expect the failure record is kept, got %v", res)
+		}
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		files["broken.png"].Data = newPNG(t, "a dog\nSteps: 30, Sampler: Euler a")
+		if err = indexDir(ctx, lib, c, opts, logger); err != nil {
+			t.Fatal(err)
+		}
+		if res := failure(t); res != nil {
+			t.Errorf("expect the failure record is replaced, got %v", res)
+		}
+		if img, err := c.Get(ctx, "broken.png"); err != nil || img == nil || img.Prompt != "a dog" {
+			t.Errorf("expect the fixed file is indexed, got %v (%v)", img, err)
+		}
+	})
+}
+
+func TestIndexAnnotated(t *testing.T) {
+	ctx := context.Background()
+	c, err := catalog.NewBleveMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	data, err := userdata.Open(filepath.Join(t.TempDir(), "userdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := data.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	// the annotation is added after the file was parsed, e.g. by a request while walking the library.
+	docs := map[string]catalog.Document{
+		"a.png": &image.Image{Prompt: "a cat", CreationTime: time.Now()},
+		"b.png": &image.Failure{Message: "broken", FailedAt: time.Now()},
+	}
+	if err = data.SetAnnotation("a.png", &image.Annotation{Favorite: true}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err = indexAnnotated(ctx, c, data, docs); err != nil {
+		t.Fatal(err)
+	}
+	img, err := c.Get(ctx, "a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img == nil || img.Annotation == nil || !img.Annotation.Favorite {
+		t.Errorf("expect a.png is indexed with its annotation, got %v", img)
+	}
+}
@@ -0,0 +1,105 @@
+// lifecycle.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// service is the interface of the API server run by a lifecycle.
+type service interface {
+	Serve() error
+	Shutdown() error
+}
+
+// indexer indexes images until ctx is done. A value sent to rescan requests starting a new indexing pass.
+// It should return after the current batch is committed once ctx is done.
+type indexer func(ctx context.Context, rescan <-chan struct{})
+
+// lifecycle runs a server and an indexer, and shuts them down in order:
+// on SIGINT or SIGTERM, it stops accepting requests, drains in-flight requests, cancels the indexer, waits for it
+// to finish and closes the index. SIGHUP triggers a rescan of the library.
+type lifecycle struct {
+	signals chan os.Signal
+	logger  *log.Logger
+	// reload is called on SIGHUP before requesting a rescan if it is not nil.
+	reload func()
+}
+
+func newLifecycle(logger *log.Logger) *lifecycle {
+	return &lifecycle{
+		signals: make(chan os.Signal, 1),
+		logger:  logger,
+	}
+}
+
+// Run runs the given server and indexer until the server stops, and then closes the index.
+func (l *lifecycle) Run(s service, idx indexer, index io.Closer) (err error) {
+	signal.Notify(l.signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(l.signals)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rescan := make(chan struct{}, 1)
+	indexerDone := make(chan struct{})
+	go func() {
+		defer close(indexerDone)
+		idx(ctx, rescan)
+	}()
+
+	serverDone := make(chan error, 1)
+	go func() {
+		serverDone <- s.Serve()
+	}()
+
+	stopping := false
+loop:
+	for {
+		select {
+		case sig := <-l.signals:
+			switch {
+			case sig == syscall.SIGHUP:
+				l.logger.Println("Received SIGHUP, rescanning the library")
+				if l.reload != nil {
+					l.reload()
+				}
+				select {
+				case rescan <- struct{}{}:
+				default:
+					// a rescan is already requested.
+				}
+			case stopping:
+				l.logger.Println("Received a signal again, exiting immediately")
+				os.Exit(1)
+			default:
+				l.logger.Printf("Received %v, shutting down", sig)
+				stopping = true
+				if err = s.Shutdown(); err != nil {
+					l.logger.Printf("Failed to shut down the server: %v", err)
+				}
+			}
+		case err = <-serverDone:
+			break loop
+		}
+	}
+
+	l.logger.Println("Waiting for the indexer to stop")
+	cancel()
+	<-indexerDone
+
+	l.logger.Println("Closing the index")
+	return errors.Join(err, index.Close())
+}
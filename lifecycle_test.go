@@ -0,0 +1,93 @@
+// lifecycle_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+)
+
+type fakeService struct {
+	stop chan struct{}
+	once sync.Once
+}
+
+func (s *fakeService) Serve() error {
+	<-s.stop
+	return nil
+}
+
+func (s *fakeService) Shutdown() error {
+	s.once.Do(func() { close(s.stop) })
+	return nil
+}
+
+// recorder records events in order.
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) Close() error {
+	r.add("close")
+	return nil
+}
+
+func TestLifecycle(t *testing.T) {
+	l := newLifecycle(log.New(io.Discard, "", 0))
+	s := &fakeService{stop: make(chan struct{})}
+	r := &recorder{}
+
+	rescanned := make(chan struct{})
+	idx := func(ctx context.Context, rescan <-chan struct{}) {
+		for {
+			select {
+			case <-ctx.Done():
+				select {
+				case <-s.stop:
+					r.add("indexer stopped")
+				default:
+					t.Error("expect the indexer is canceled after the server stops")
+				}
+				return
+			case <-rescan:
+				r.add("rescan")
+				rescanned <- struct{}{}
+			}
+		}
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- l.Run(s, idx, r)
+	}()
+
+	l.signals <- syscall.SIGHUP
+	<-rescanned
+	l.signals <- syscall.SIGTERM
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	expect := "rescan,indexer stopped,close"
+	if res := strings.Join(r.events, ","); res != expect {
+		t.Errorf("expect %v, got %v", expect, res)
+	}
+}
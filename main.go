@@ -9,19 +9,14 @@
 package main
 
 import (
-	"context"
 	"errors"
-	"flag"
 	"fmt"
 	"log"
 	"os"
-	"path/filepath"
-	"sync"
-	"time"
+	"strings"
 
 	"github.com/blevesearch/bleve/v2"
-
-	"github.com/jkawamoto/sd-image-viewer/server"
+	"github.com/jessevdk/go-flags"
 )
 
 const AppName = "sd-image-viewer"
@@ -31,83 +26,97 @@ func main() {
 	logger := log.Default()
 	bleve.SetLog(logger)
 
-	cacheDir, err := os.UserCacheDir()
+	opts := &options{}
+	parser, err := newParser(opts, logger)
 	if err != nil {
-		logger.Printf("Failed to get the cache directory: %v", err)
-	}
-
-	host := flag.String("host", "localhost", "the IP to listen on")
-	port := flag.Int("port", 0, "the port to listen on for insecure connections, defaults to a random value")
-	indexPath := flag.String("index", filepath.Join(cacheDir, AppName), "path to the index")
-	duration := flag.Duration("index-duration", time.Hour, "duration of indexing")
-	force := flag.Bool("force", false, "force reindexing all images")
-	prune := flag.Bool("prune", false, "remove non exiting images from the index")
-	version := flag.Bool("v", false, "prints current version")
-
-	flag.Parse()
-	if *version {
-		fmt.Printf("%v %v\n", AppName, Version)
-		os.Exit(0)
-	}
-	if flag.NArg() == 0 {
-		logger.Fatalln("One directory path is required")
+		logger.Fatal(err)
 	}
-	dir := flag.Arg(0)
 
-	index, created, err := newIndex(*indexPath)
-	if err != nil {
-		logger.Fatalf("Failed to create an index: %v", err)
+	args := os.Args[1:]
+	if v, ok := legacyArgs(parser, args); ok {
+		logger.Printf("Running without a command is deprecated; run %v %v instead", AppName, strings.Join(v, " "))
+		args = v
 	}
-	if created {
-		// if a new index is created, force reindexing all images.
-		*force = true
+	if _, err = parser.ParseArgs(args); err != nil {
+		var flagsErr *flags.Error
+		if errors.As(err, &flagsErr) {
+			if flagsErr.Type == flags.ErrHelp {
+				fmt.Println(err)
+				os.Exit(0)
+			}
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		logger.Fatal(err)
 	}
-	defer func() {
-		logger.Printf("Closing the index")
-		if err = index.Close(); err != nil {
-			logger.Printf("Failed to close the index: %v", err)
+	if parser.Active == nil {
+		if opts.Version {
+			fmt.Printf("%v %v\n", AppName, Version)
+			return
 		}
-	}()
-
-	var wg sync.WaitGroup
-	defer wg.Wait()
+		parser.WriteHelp(os.Stderr)
+		os.Exit(2)
+	}
+}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+// newParser returns a parser of the given global options and the commands.
+func newParser(opts *options, logger *log.Logger) (*flags.Parser, error) {
+	parser := flags.NewParser(opts, flags.HelpFlag|flags.PassDoubleDash)
+	parser.SubcommandsOptional = true
+	serve := &serveCommand{global: opts, isSet: optionSet(parser, "serve"), logger: logger}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		if *prune {
-			err := pruneIndex(ctx, index, logger)
-			if errors.Is(err, context.Canceled) {
-				return
-			} else if err != nil {
-				logger.Printf("Failed to prune index: %v", err)
-			}
-		}
-		for {
-			err := indexDir(ctx, dir, index, *force, logger)
-			if errors.Is(err, context.Canceled) {
-				break
-			} else if err != nil {
-				logger.Printf("Failed to index files in %v: %v", dir, err)
-			}
-			*force = false
-			select {
-			case <-ctx.Done():
-				break
-			case <-time.After(*duration):
-			}
+	commands := []struct {
+		name, short, long string
+		data              any
+	}{
+		{
+			name:  "serve",
+			short: "Serve images",
+			long:  "Serve images with the web UI while indexing new images periodically.",
+			data:  serve,
+		},
+		{
+			name:  "index",
+			short: "Index images",
+			long:  "Index new and modified images once and exit.",
+			data:  &indexCommand{global: opts, logger: logger},
+		},
+		{
+			name:  "prune",
+			short: "Remove deleted images from the index",
+			long:  "Remove images which don't exist in the libraries anymore from the index.",
+			data:  &pruneCommand{global: opts, logger: logger},
+		},
+		{
+			name:  "search",
+			short: "Search images",
+			long:  "Search images in the index with the same filters as the web UI.",
+			data:  &searchCommand{global: opts},
+		},
+		{
+			name:  "stats",
+			short: "Print statistics of the index",
+			long:  "Print the numbers of indexed images and failures, when libraries were indexed, and statistics of images matching the same filters as the web UI.",
+			data:  &statsCommand{global: opts},
+		},
+		{
+			name:  "user",
+			short: "Manage users",
+			long:  "Manage users of the server and their API tokens. Authentication is required once a user is added.",
+			data:  newUserCommand(opts),
+		},
+		{
+			name:  "export",
+			short: "Export indexed documents",
+			long:  "Export indexed images matching the filters as JSON lines, CSV, or prompts read by AUTOMATIC1111's web UI.",
+			data:  &exportCommand{global: opts},
+		},
+	}
+	for _, c := range commands {
+		if _, err := parser.AddCommand(c.name, c.short, c.long, c.data); err != nil {
+			return nil, fmt.Errorf("failed to add command %v: %w", c.name, err)
 		}
-	}()
-
-	s, err := server.NewServer(*host, *port, index, dir, logger)
-	if err != nil {
-		logger.Fatalf("Failed to create a server: %v", err)
 	}
+	return parser, nil
 
-	if err = s.Serve(); err != nil {
-		logger.Fatalf("Failed to serve: %v", err)
-	}
 }
@@ -0,0 +1,191 @@
+// preview.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+// Package preview renders thumbnails of images in terminals with the sixel or kitty graphics protocol.
+package preview
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/png"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Protocol is a graphics protocol of terminals.
+type Protocol string
+
+const (
+	Sixel Protocol = "sixel"
+	Kitty Protocol = "kitty"
+)
+
+// kittyChunkSize is the maximum size of base64 encoded data sent in an escape sequence of the kitty protocol.
+const kittyChunkSize = 4096
+
+// Detect guesses the protocol supported by the current terminal from environment variables. It returns Sixel unless
+// the terminal is known to support the kitty protocol.
+func Detect() Protocol {
+	if os.Getenv("KITTY_WINDOW_ID") != "" || strings.Contains(os.Getenv("TERM"), "kitty") {
+		return Kitty
+	}
+	switch os.Getenv("TERM_PROGRAM") {
+	case "WezTerm", "ghostty":
+		return Kitty
+	}
+	return Sixel
+}
+
+// Render decodes a PNG or WebP image read from r, and writes its thumbnail fitting in a square of the given size with
+// the given protocol.
+func Render(w io.Writer, r io.Reader, p Protocol, size int) error {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return err
+	}
+	img = Thumbnail(img, size)
+
+	switch p {
+	case Sixel:
+		return writeSixel(w, img)
+	case Kitty:
+		return writeKitty(w, img)
+	default:
+		return fmt.Errorf("unknown protocol: %v", p)
+	}
+}
+
+// Thumbnail returns a downscaled copy of the given image fitting in a square of the given size. It returns the image
+// as is if it already fits.
+func Thumbnail(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+	if width > height {
+		width, height = size, height*size/width
+	} else {
+		width, height = width*size/height, size
+	}
+	if width == 0 {
+		width = 1
+	}
+	if height == 0 {
+		height = 1
+	}
+
+	res := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.ApproxBiLinear.Scale(res, res.Bounds(), img, b, draw.Src, nil)
+	return res
+}
+
+// writeKitty writes the given image as a PNG image transmitted and displayed by the kitty graphics protocol.
+func writeKitty(w io.Writer, img image.Image) error {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	data := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	bw := bufio.NewWriter(w)
+	for first := true; first || len(data) != 0; first = false {
+		chunk := data
+		if len(chunk) > kittyChunkSize {
+			chunk = chunk[:kittyChunkSize]
+		}
+		data = data[len(chunk):]
+
+		more := 0
+		if len(data) != 0 {
+			more = 1
+		}
+		if first {
+			fmt.Fprintf(bw, "\x1b_Ga=T,f=100,m=%d;%s\x1b\\", more, chunk)
+		} else {
+			fmt.Fprintf(bw, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	bw.WriteString("\n")
+	return bw.Flush()
+}
+
+// writeSixel writes the given image in sixel, quantizing its colors to the 256 colors of the Plan 9 palette.
+func writeSixel(w io.Writer, img image.Image) error {
+	b := img.Bounds()
+	p := image.NewPaletted(image.Rect(0, 0, b.Dx(), b.Dy()), palette.Plan9)
+	draw.FloydSteinberg.Draw(p, p.Bounds(), img, b.Min)
+	width, height := p.Bounds().Dx(), p.Bounds().Dy()
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "\x1bPq\"1;1;%d;%d", width, height)
+	for i, c := range p.Palette {
+		r, g, b, _ := c.RGBA()
+		fmt.Fprintf(bw, "#%d;2;%d;%d;%d", i, r*100/0xffff, g*100/0xffff, b*100/0xffff)
+	}
+
+	for y := 0; y < height; y += 6 {
+		var used [256]bool
+		for dy := 0; dy != 6 && y+dy < height; dy++ {
+			for x := 0; x != width; x++ {
+				used[p.ColorIndexAt(x, y+dy)] = true
+			}
+		}
+
+		for i := range used {
+			if !used[i] {
+				continue
+			}
+			fmt.Fprintf(bw, "#%d", i)
+
+			// each character represents a column of 6 pixels, which are encoded with run lengths.
+			var last byte
+			run := 0
+			for x := 0; x != width; x++ {
+				var bits byte
+				for dy := 0; dy != 6 && y+dy < height; dy++ {
+					if p.ColorIndexAt(x, y+dy) == uint8(i) {
+						bits |= 1 << dy
+					}
+				}
+				ch := 63 + bits
+				if run != 0 && ch != last {
+					writeSixelRun(bw, last, run)
+					run = 0
+				}
+				last = ch
+				run++
+			}
+			writeSixelRun(bw, last, run)
+			// returns to the start of this band to draw pixels of the next color.
+			bw.WriteByte('$')
+		}
+		// moves to the next band.
+		bw.WriteByte('-')
+	}
+	bw.WriteString("\x1b\\\n")
+	return bw.Flush()
+}
+
+func writeSixelRun(w *bufio.Writer, ch byte, run int) {
+	if run > 3 {
+		fmt.Fprintf(w, "!%d%c", run, ch)
+		return
+	}
+	for i := 0; i != run; i++ {
+		w.WriteByte(ch)
+	}
+}
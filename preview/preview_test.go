@@ -0,0 +1,123 @@
+// preview_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package preview
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func newTestImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y != height; y++ {
+		for x := 0; x != width; x++ {
+			if x < width/2 {
+				img.Set(x, y, color.RGBA{R: 0xff, A: 0xff})
+			} else {
+				img.Set(x, y, color.RGBA{B: 0xff, A: 0xff})
+			}
+		}
+	}
+	return img
+}
+
+func TestThumbnail(t *testing.T) {
+	cases := []struct {
+		width, height int
+		expect        image.Rectangle
+	}{
+		{width: 512, height: 768, expect: image.Rect(0, 0, 128, 192)},
+		{width: 768, height: 512, expect: image.Rect(0, 0, 192, 128)},
+		{width: 100, height: 50, expect: image.Rect(0, 0, 100, 50)},
+	}
+	for _, c := range cases {
+		res := Thumbnail(newTestImage(c.width, c.height), 192)
+		if res.Bounds() != c.expect {
+			t.Errorf("expect %v, got %v", c.expect, res.Bounds())
+		}
+	}
+}
+
+func TestWriteSixel(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeSixel(&buf, newTestImage(8, 7)); err != nil {
+		t.Fatal(err)
+	}
+
+	res := buf.String()
+	if !strings.HasPrefix(res, "\x1bPq\"1;1;8;7") {
+		t.Errorf("expect a sixel header, got %q", res)
+	}
+	if !strings.HasSuffix(res, "\x1b\\\n") {
+		t.Errorf("expect a string terminator, got %q", res)
+	}
+
+	// the first band has 6 rows, and each color fills the half of them; the second band has 1 row.
+	bands := regexp.MustCompile(`#\d+(![0-9]+[?-~]|[?-~])+\$`).FindAllString(res, -1)
+	// colors are drawn in the order of the palette, where blue comes before red.
+	expect := []string{"!4?!4~$", "!4~!4?$", "!4?!4@$", "!4@!4?$"}
+	if len(bands) != len(expect) {
+		t.Fatalf("expect %v color runs, got %q", len(expect), bands)
+	}
+	for i, v := range bands {
+		if !strings.HasSuffix(v, expect[i]) {
+			t.Errorf("expect a run ending with %q, got %q", expect[i], v)
+		}
+	}
+}
+
+func TestWriteKitty(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	// random pixels are hardly compressed so that the image is sent in several chunks.
+	rand.New(rand.NewSource(1)).Read(img.Pix)
+
+	var buf bytes.Buffer
+	if err := writeKitty(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	matches := regexp.MustCompile("\x1b_G([^;]*);([^\x1b]*)\x1b\\\\").FindAllStringSubmatch(buf.String(), -1)
+	if len(matches) < 2 {
+		t.Fatalf("expect multiple chunks, got %v", len(matches))
+	}
+	var data strings.Builder
+	for i, m := range matches {
+		switch {
+		case i == 0 && m[1] != "a=T,f=100,m=1":
+			t.Errorf("unexpected control data of the first chunk: %v", m[1])
+		case i != 0 && i != len(matches)-1 && m[1] != "m=1":
+			t.Errorf("unexpected control data of a middle chunk: %v", m[1])
+		case i == len(matches)-1 && m[1] != "m=0":
+			t.Errorf("unexpected control data of the last chunk: %v", m[1])
+		}
+		if len(m[2]) > kittyChunkSize {
+			t.Errorf("expect chunks are at most %v bytes, got %v", kittyChunkSize, len(m[2]))
+		}
+		data.WriteString(m[2])
+	}
+
+	b, err := base64.StdEncoding.DecodeString(data.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Bounds() != img.Bounds() {
+		t.Errorf("expect %v, got %v", img.Bounds(), res.Bounds())
+	}
+}
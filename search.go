@@ -0,0 +1,241 @@
+// search.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/export"
+	"github.com/jkawamoto/sd-image-viewer/preview"
+	"github.com/jkawamoto/sd-image-viewer/source"
+)
+
+const (
+	// maxPromptWidth is the maximum number of characters of prompts shown in tables.
+	maxPromptWidth = 60
+	// previewSize is the maximum width and height of thumbnails in pixels.
+	previewSize = 192
+)
+
+// filterOptions are options filtering images with the same filters as GET /images.
+type filterOptions struct {
+	Q          string `short:"q" long:"query" description:"query string searching all indexed fields, e.g. 'steps:>30 -negative:blurry'"`
+	Checkpoint string `long:"checkpoint" description:"checkpoint used to generate images"`
+	Size       string `long:"size" choice:"small" choice:"medium" choice:"large" description:"size of images"`
+	After      string `long:"after" value-name:"DATE" description:"return images created at or after this date (RFC 3339 or YYYY-MM-DD)"`
+	Before     string `long:"before" value-name:"DATE" description:"return images created before this date (RFC 3339 or YYYY-MM-DD)"`
+}
+
+// filter returns the filter given by the options and the phrase contained in prompts.
+func (o *filterOptions) filter(prompt string) (filter catalog.Filter, err error) {
+	filter.Prompt = prompt
+	filter.Checkpoint = o.Checkpoint
+	filter.SetSize(o.Size)
+	if strings.TrimSpace(o.Q) != "" {
+		if filter.Query, err = catalog.ParseQuery(o.Q); err != nil {
+			return filter, err
+		}
+	}
+	if o.After != "" {
+		if filter.After, err = parseDate(o.After); err != nil {
+			return filter, err
+		}
+	}
+	if o.Before != "" {
+		if filter.Before, err = parseDate(o.Before); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+// searchCommand searches images with the same filters as GET /images.
+type searchCommand struct {
+	global *options
+	filterOptions
+	Order   string `long:"order" choice:"asc" choice:"desc" default:"desc" description:"order of creation times"`
+	Limit   int    `long:"limit" default:"20" description:"maximum number of images"`
+	Page    int    `long:"page" description:"page number starting from 0"`
+	Format  string `short:"f" long:"format" choice:"table" choice:"json" choice:"paths" default:"table" description:"output format; json writes JSON lines and paths writes locations of images"`
+	Preview string `long:"preview" optional:"yes" optional-value:"auto" choice:"auto" choice:"sixel" choice:"kitty" description:"render thumbnails with the sixel or kitty graphics protocol"`
+	Library string `long:"library" value-name:"PATH" description:"path to a directory or an URL of an S3 bucket to locate and preview images, instead of the libraries in the configuration file"`
+	Args    struct {
+		Query string `positional-arg-name:"query" description:"phrase contained in prompts"`
+	} `positional-args:"yes"`
+}
+
+// request returns the search request given by the options.
+func (c *searchCommand) request() (*catalog.SearchRequest, error) {
+	if c.Limit <= 0 || c.Page < 0 {
+		return nil, errors.New("limit must be positive and page must not be negative")
+	}
+
+	filter, err := c.filter(c.Args.Query)
+	if err != nil {
+		return nil, err
+	}
+	req := &catalog.SearchRequest{
+		Filter: filter,
+		Offset: c.Limit * c.Page,
+		Limit:  c.Limit,
+	}
+	if c.Order == "asc" {
+		req.Order = catalog.Ascending
+	}
+	return req, nil
+}
+
+func (c *searchCommand) Execute([]string) (err error) {
+	req, err := c.request()
+	if err != nil {
+		return err
+	}
+	if c.Preview != "" && c.Format != "table" {
+		return errors.New("previews are available only in the table format")
+	}
+	cfg, err := loadConfig(c.global.Config, defaultConfig(), func(cfg *Config) {
+		c.global.apply(cfg)
+		if c.Library != "" {
+			cfg.Libraries = []LibraryConfig{{Path: c.Library}}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	// the index is opened in read-only mode so that it isn't modified, which fails while the server is running.
+	index, err := catalog.OpenBleveReadOnly(cfg.Index)
+	if err != nil {
+		return fmt.Errorf("failed to open the index: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, index.Close())
+	}()
+
+	res, err := index.Search(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("failed to search images: %w", err)
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer func() {
+		err = errors.Join(err, w.Flush())
+	}()
+	switch {
+	case c.Format == "json":
+		return writeJSONLines(w, res)
+	case c.Format == "paths":
+		for _, v := range res.Hits {
+			fmt.Fprintln(w, cfg.locate(v.ID))
+		}
+		return nil
+	case c.Preview != "":
+		_, src, err := openLibraries(cfg)
+		if err != nil {
+			return err
+		}
+		p := preview.Protocol(c.Preview)
+		if c.Preview == "auto" {
+			p = preview.Detect()
+		}
+		err = writePreviews(w, res, src, p)
+		if err != nil {
+			return err
+		}
+	default:
+		if err = writeTable(w, res); err != nil {
+			return err
+		}
+	}
+
+	if len(res.Hits) != 0 {
+		fmt.Fprintf(os.Stderr, "Showing %v-%v of %v images\n", req.Offset+1, req.Offset+len(res.Hits), res.Total)
+	} else {
+		fmt.Fprintf(os.Stderr, "Found %v images\n", res.Total)
+	}
+	return nil
+}
+
+// writeJSONLines writes the found images as JSON lines in the same format as the export command.
+func writeJSONLines(w io.Writer, res *catalog.SearchResult) error {
+	enc := json.NewEncoder(w)
+	for _, v := range res.Hits {
+		if err := enc.Encode(&export.Image{ID: v.ID, Type: v.Image.Type(), Image: v.Image}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writePreviews writes a thumbnail of each found image followed by its summary.
+func writePreviews(w *bufio.Writer, res *catalog.SearchResult, src source.Source, p preview.Protocol) error {
+	for _, v := range res.Hits {
+		if err := renderPreview(w, src, v.ID, p); err != nil {
+			fmt.Fprintf(w, "(no preview: %v)\n", err)
+		}
+		fmt.Fprintf(w, "%v  %v  %v\n%v\n\n",
+			v.ID, v.Image.CreationTime.Local().Format(time.DateTime), v.Image.Checkpoint,
+			truncate(v.Image.Prompt, maxPromptWidth))
+		// flushes each image so that previews appear progressively.
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderPreview(w io.Writer, src source.Source, name string, p preview.Protocol) (err error) {
+	f, err := src.Open(name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	// an image is rendered only after it is fully encoded so that a broken image doesn't leave a partial sequence.
+	var buf bytes.Buffer
+	if err = preview.Render(&buf, f, p, previewSize); err != nil {
+		return err
+	}
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+// writeTable writes the found images as a table.
+func writeTable(w io.Writer, res *catalog.SearchResult) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tCREATED\tCHECKPOINT\tPIXEL\tPROMPT")
+	for _, v := range res.Hits {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\n",
+			v.ID, v.Image.CreationTime.Local().Format(time.DateTime), v.Image.Checkpoint, v.Image.Pixel,
+			truncate(v.Image.Prompt, maxPromptWidth))
+	}
+	return tw.Flush()
+}
+
+// truncate shortens the given string to at most n characters and replaces line breaks with spaces.
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-3]) + "..."
+	}
+	return s
+}
@@ -0,0 +1,308 @@
+// serve.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/auth"
+	"github.com/jkawamoto/sd-image-viewer/server"
+	"github.com/jkawamoto/sd-image-viewer/source"
+	"github.com/jkawamoto/sd-image-viewer/thumbnail"
+	"github.com/jkawamoto/sd-image-viewer/tlscert"
+	"github.com/jkawamoto/sd-image-viewer/trash"
+	"github.com/jkawamoto/sd-image-viewer/userdata"
+)
+
+const (
+	// thumbnailDirSuffix is appended to the path of the index to get the directory of the thumbnail cache.
+	thumbnailDirSuffix = ".thumbnails"
+	// tlsDirSuffix is appended to the path of the index to get the directory of self-signed certificates.
+	tlsDirSuffix = ".tls"
+)
+
+// serveCommand serves images with the web UI while indexing images periodically.
+type serveCommand struct {
+	global             *options
+	Host               string        `long:"host" description:"the IP to listen on (default: localhost)"`
+	Port               int           `long:"port" description:"the port to listen on for insecure connections, defaults to a random value"`
+	IndexDuration      time.Duration `long:"index-duration" description:"duration of indexing (default: 1h)"`
+	Force              bool          `long:"force" description:"force reindexing all images"`
+	Prune              bool          `long:"prune" description:"remove non exiting images from the index"`
+	ThumbnailCacheSize int64         `long:"thumbnail-cache-size" description:"maximum size of cached thumbnails in megabytes (default: 1024)"`
+	TLS                flagBool      `long:"tls" optional:"yes" optional-value:"true" value-name:"[true|false]" description:"serve over HTTPS and redirect HTTP to it, with a self-signed certificate unless --tls-certificate is given"`
+	TLSPort            int           `long:"tls-port" description:"the port to listen on for HTTPS (default: 8443)"`
+	TLSCertificate     string        `long:"tls-certificate" description:"path to a certificate, which is reloaded when modified"`
+	TLSKey             string        `long:"tls-key" description:"path to the private key of the certificate"`
+	TLSNames           []string      `long:"tls-name" description:"host name or IP address added to the self-signed certificate, can be repeated"`
+	ReadOnly           flagBool      `long:"read-only" optional:"yes" optional-value:"true" value-name:"[true|false]" description:"disable deleting and restoring images"`
+	TrashRetention     time.Duration `long:"trash-retention" description:"duration deleted images are kept in the trash before being purged, zero keeps them (default: 720h)"`
+	Args               libraryArgs   `positional-args:"yes"`
+
+	// isSet reports whether the option of the given long name is given explicitly.
+	isSet  func(string) bool
+	logger *log.Logger
+}
+
+// apply overwrites the configuration with the options given explicitly, including zero values.
+func (c *serveCommand) apply(cfg *Config) {
+	c.global.apply(cfg)
+	c.Args.apply(cfg)
+	if c.isSet("host") {
+		cfg.Host = c.Host
+	}
+	if c.isSet("port") {
+		cfg.Port = c.Port
+	}
+	if c.isSet("index-duration") {
+		cfg.IndexDuration = c.IndexDuration
+	}
+	if c.isSet("thumbnail-cache-size") {
+		cfg.ThumbnailCacheSize = c.ThumbnailCacheSize
+	}
+	if c.isSet("tls") {
+		cfg.TLS.Enabled = bool(c.TLS)
+	}
+	if c.isSet("tls-port") {
+		cfg.TLS.Port = c.TLSPort
+	}
+	if c.isSet("tls-certificate") {
+		cfg.TLS.Certificate = c.TLSCertificate
+	}
+	if c.isSet("tls-key") {
+		cfg.TLS.Key = c.TLSKey
+	}
+	if c.isSet("tls-name") {
+		cfg.TLS.Names = c.TLSNames
+	}
+	if c.isSet("read-only") {
+		cfg.ReadOnly = bool(c.ReadOnly)
+	}
+	if c.isSet("trash-retention") {
+		cfg.TrashRetention = c.TrashRetention
+	}
+}
+
+func (c *serveCommand) Execute([]string) error {
+	logger := c.logger
+	defaults := defaultConfig()
+	cfg, err := loadConfig(c.global.Config, defaults, c.apply)
+	if err != nil {
+		return err
+	}
+	libs, src, err := openLibraries(cfg)
+	if err != nil {
+		return err
+	}
+	verboseLogger, verbose := newVerboseLogger(logger, cfg)
+	st := newSettings(cfg, verbose)
+
+	index, created, err := newIndex(cfg.Index, logger)
+	if err != nil {
+		return err
+	}
+	// fatalf is used instead of logger.Fatalf once the index is opened so that the index is closed properly.
+	fatalf := func(format string, v ...any) {
+		logger.Printf(format, v...)
+		if err := index.Close(); err != nil {
+			logger.Printf("Failed to close the index: %v", err)
+		}
+		os.Exit(1)
+	}
+
+	force, prune := c.Force || created, c.Prune
+	outdated, err := isOutdated(index)
+	if err != nil {
+		fatalf("Failed to read the index: %v", err)
+	}
+	if outdated {
+		// IDs or fields of documents have been changed; reindex all images and remove documents with old IDs.
+		logger.Println("The index was created by an older version and will be rebuilt")
+		force, prune = true, true
+	}
+
+	limit := cfg.ThumbnailCacheSize << 20
+	if limit == 0 {
+		limit = math.MaxInt64
+	}
+	thumbs, err := thumbnail.Open(cfg.Index+thumbnailDirSuffix, limit, logger)
+	if err != nil {
+		fatalf("Failed to open the thumbnail cache: %v", err)
+	}
+	defer func() {
+		if err := thumbs.Close(); err != nil {
+			logger.Printf("Failed to close the thumbnail cache: %v", err)
+		}
+	}()
+
+	users, err := auth.OpenStore(cfg.Index + usersFileSuffix)
+	if err != nil {
+		fatalf("Failed to open the user store: %v", err)
+	}
+	if !users.Enabled() {
+		logger.Println("Authentication is disabled since no users are registered")
+	}
+
+	data, err := userdata.Open(cfg.Index + userdataFileSuffix)
+	if err != nil {
+		fatalf("Failed to open the user data: %v", err)
+	}
+	defer func() {
+		if err := data.Close(); err != nil {
+			logger.Printf("Failed to close the user data: %v", err)
+		}
+	}()
+
+	// deleted images are moved to trash folders in libraries, which aren't modified in read-only mode.
+	var bin *trash.Trash
+	if !cfg.ReadOnly {
+		bin = trash.New(cfg.libraryRoots())
+	}
+
+	s, err := server.NewServer(cfg.Host, cfg.Port, index, src, thumbs, users, data, bin, logger, verboseLogger)
+	if err != nil {
+		fatalf("Failed to create a server: %v", err)
+	}
+	if cfg.TLS.enabled() {
+		certs, err := openCertificate(cfg, logger)
+		if err != nil {
+			fatalf("Failed to load the TLS certificate: %v", err)
+		}
+		server.EnableTLS(s, cfg.Host, cfg.TLS.Port, certs.GetCertificate)
+	}
+
+	idx := func(ctx context.Context, rescan <-chan struct{}) {
+		if prune {
+			err := pruneLibraries(ctx, index, src, outdated, logger)
+			if errors.Is(err, context.Canceled) {
+				return
+			} else if err != nil {
+				logger.Printf("Failed to prune index: %v", err)
+			}
+		}
+		changes := watch(ctx, src, logger)
+		for {
+			if retention := st.get().TrashRetention; bin != nil && retention != 0 {
+				purgeTrash(bin, data, retention, logger)
+			}
+			for _, lib := range libs {
+				opts := &indexOptions{
+					force:      force,
+					ignore:     st.get().ignorePatterns(lib.Name),
+					verbose:    verboseLogger,
+					thumbnails: thumbs,
+					userdata:   data,
+					source:     src,
+				}
+				err := indexDir(ctx, lib, index, opts, logger)
+				if errors.Is(err, context.Canceled) {
+					return
+				} else if err != nil {
+					logger.Printf("Failed to index files in %v: %v", lib.Source, err)
+				}
+			}
+			force = false
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(st.get().IndexDuration):
+			case <-rescan:
+			case name, ok := <-changes:
+				if !ok {
+					changes = nil
+					continue
+				}
+				logger.Printf("Found changes in %v", name)
+			}
+		}
+	}
+
+	lc := newLifecycle(logger)
+	if c.global.Config != "" {
+		lc.reload = func() {
+			st.reload(c.global.Config, defaults, c.apply, logger)
+		}
+		done := make(chan struct{})
+		defer close(done)
+		go watchConfig(c.global.Config, done, lc.reload)
+	}
+	return lc.Run(s, idx, index)
+}
+
+// openCertificate loads the certificate in the configuration, or creates a self-signed certificate next to the index
+// if it isn't given.
+func openCertificate(cfg *Config, logger *log.Logger) (*tlscert.Loader, error) {
+	if cfg.TLS.Certificate == "" {
+		return tlscert.LoadSelfSigned(cfg.Index+tlsDirSuffix, certificateNames(cfg), logger)
+	}
+	return tlscert.Load(cfg.TLS.Certificate, cfg.TLS.Key, logger)
+}
+
+// certificateNames returns host names and IP addresses of the server, i.e. the loopback addresses, the host name of
+// the machine, the host to listen on and the names in the configuration.
+func certificateNames(cfg *Config) []string {
+	candidates := []string{"localhost", "127.0.0.1", "::1"}
+	if host, err := os.Hostname(); err == nil {
+		candidates = append(candidates, host)
+	}
+	// the unspecified address, e.g. 0.0.0.0, can't be used to connect.
+	if ip := net.ParseIP(cfg.Host); ip == nil || !ip.IsUnspecified() {
+		candidates = append(candidates, cfg.Host)
+	}
+	candidates = append(candidates, cfg.TLS.Names...)
+
+	var res []string
+	seen := make(map[string]struct{})
+	for _, name := range candidates {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := seen[name]; ok || name == "" {
+			continue
+		}
+		seen[name] = struct{}{}
+		res = append(res, name)
+	}
+	return res
+}
+
+// purgeTrash permanently deletes images which have been in the trash longer than the given retention, and removes
+// their annotations and memberships of collections from data.
+func purgeTrash(bin *trash.Trash, data *userdata.Store, retention time.Duration, logger *log.Logger) {
+	items, err := bin.Purge(time.Now().Add(-retention))
+	ids := make([]string, len(items))
+	for i, item := range items {
+		logger.Printf("Purged %v from the trash", item.Image)
+		ids[i] = item.Image
+	}
+	if err != nil {
+		logger.Printf("Failed to purge the trash: %v", err)
+	}
+	if err = data.RemoveImages(ids...); err != nil {
+		logger.Printf("Failed to remove user data of purged images: %v", err)
+	}
+}
+
+// watch returns a channel receiving names of changed directories if the given source supports watching changes.
+// Otherwise, the returned channel never receives any values.
+func watch(ctx context.Context, src source.Source, logger *log.Logger) <-chan string {
+	if w, ok := src.(source.Watcher); ok {
+		ch, err := w.Watch(ctx)
+		if err == nil {
+			return ch
+		}
+		logger.Printf("Failed to watch changes: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+// serve_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package main
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+
+	"github.com/jkawamoto/sd-image-viewer/image"
+	"github.com/jkawamoto/sd-image-viewer/trash"
+	"github.com/jkawamoto/sd-image-viewer/userdata"
+)
+
+func TestPurgeTrash(t *testing.T) {
+	lib := t.TempDir()
+	for _, name := range []string{"a.png", "b.png"} {
+		if err := os.WriteFile(filepath.Join(lib, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := userdata.Open(filepath.Join(t.TempDir(), "userdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := data.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	for _, id := range []string{"a.png", "b.png"} {
+		if err = data.SetAnnotation(id, &image.Annotation{Favorite: true}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c, err := data.CreateCollection(&userdata.Collection{Name: "set", Images: []string{"a.png", "b.png"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bin := trash.New(map[string]string{"": lib})
+	if _, err = bin.Delete("a.png"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	purgeTrash(bin, data, time.Millisecond, log.New(io.Discard, "", 0))
+
+	items, err := bin.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 0 {
+		t.Errorf("expect the trash is empty, got %v", items)
+	}
+	if a, err := data.Annotation("a.png"); err != nil || a != nil {
+		t.Errorf("expect the annotation of the purged image is removed, got %v (%v)", a, err)
+	}
+	if a, err := data.Annotation("b.png"); err != nil || a == nil {
+		t.Errorf("expect the annotation of the other image is kept, got %v (%v)", a, err)
+	}
+	res, err := data.Collection(c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := []string{"b.png"}; !reflect.DeepEqual(res.Images, expect) {
+		t.Errorf("expect %v, got %v", expect, res.Images)
+	}
+}
+
+func TestServeCommandApply(t *testing.T) {
+	cases := []struct {
+		name   string
+		args   []string
+		expect Config
+	}{
+		{
+			name: "zero values",
+			args: []string{"serve", "--port", "0", "--index-duration", "0", "--read-only=false", "--tls", "/data"},
+			expect: Config{
+				Host:      "0.0.0.0",
+				TLS:       TLSConfig{Enabled: true, Port: 8443},
+				Libraries: []LibraryConfig{{Path: "/data"}},
+			},
+		},
+		{
+			name: "boolean flag before the library",
+			args: []string{"serve", "--read-only", "/data"},
+			expect: Config{
+				Host:          "0.0.0.0",
+				Port:          8080,
+				IndexDuration: time.Hour,
+				TLS:           TLSConfig{Port: 8443},
+				ReadOnly:      true,
+				Libraries:     []LibraryConfig{{Path: "/data"}},
+			},
+		},
+		{
+			name: "no flags",
+			args: []string{"serve"},
+			expect: Config{
+				Host:          "0.0.0.0",
+				Port:          8080,
+				IndexDuration: time.Hour,
+				TLS:           TLSConfig{Port: 8443},
+				ReadOnly:      true,
+				Libraries:     []LibraryConfig{{Path: "/config"}},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parser, err := newParser(&options{}, log.New(io.Discard, "", 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var cmd *serveCommand
+			parser.CommandHandler = func(command flags.Commander, _ []string) error {
+				cmd = command.(*serveCommand)
+				return nil
+			}
+			if _, err = parser.ParseArgs(c.args); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := Config{
+				Host:          "0.0.0.0",
+				Port:          8080,
+				IndexDuration: time.Hour,
+				TLS:           TLSConfig{Port: 8443},
+				ReadOnly:      true,
+				Libraries:     []LibraryConfig{{Path: "/config"}},
+			}
+			cmd.apply(&cfg)
+			if !reflect.DeepEqual(cfg, c.expect) {
+				t.Errorf("expect %+v, got %+v", c.expect, cfg)
+			}
+		})
+	}
+}
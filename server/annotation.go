@@ -0,0 +1,110 @@
+// annotation.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/image"
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+	"github.com/jkawamoto/sd-image-viewer/source"
+	"github.com/jkawamoto/sd-image-viewer/userdata"
+)
+
+// PutAnnotationHandler stores annotations in data and updates the indexed images so that they can be searched by
+// their annotations immediately. Images are read and indexed again exclusively with the indexer so that neither
+// overwrites the other with a stale image or a stale annotation.
+func PutAnnotationHandler(
+	c catalog.Catalog, src source.Source, data *userdata.Store, logger *log.Logger,
+) operations.PutAnnotationHandlerFunc {
+	return func(params operations.PutAnnotationParams, _ interface{}) middleware.Responder {
+		a := fromAnnotation(params.Annotation)
+		if err := a.Normalize(); err != nil {
+			return operations.NewPutAnnotationDefault(http.StatusBadRequest).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		}
+
+		ctx := params.HTTPRequest.Context()
+		var img *image.Image
+		err := data.Exclusive(func() (err error) {
+			img, err = c.Get(ctx, params.ID)
+			if err != nil {
+				logger.Printf("Failed to look up an image: %v", err)
+				return err
+			} else if img == nil {
+				return nil
+			}
+
+			// the content of the file lets the annotation follow the file when it is moved.
+			file, err := userdata.FileOf(src, params.ID)
+			if err != nil {
+				logger.Printf("Failed to read an annotated file: %v", err)
+				file = nil
+			}
+			if err = data.SetAnnotation(params.ID, a, file); err != nil {
+				logger.Printf("Failed to store an annotation: %v", err)
+				return err
+			}
+
+			img.Annotation = a
+			if a.IsZero() {
+				img.Annotation = nil
+			}
+			if err = c.Index(ctx, map[string]catalog.Document{params.ID: img}); err != nil {
+				logger.Printf("Failed to index an annotated image: %v", err)
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return operations.NewPutAnnotationDefault(http.StatusInternalServerError).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		} else if img == nil {
+			return operations.NewPutAnnotationDefault(http.StatusNotFound).WithPayload(&models.StandardError{
+				Message: swag.String(fmt.Sprintf("image %v is not found", params.ID)),
+			})
+		}
+		return operations.NewPutAnnotationOK().WithPayload(toAnnotation(a))
+	}
+}
+
+// fromAnnotation converts an annotation given by a request.
+func fromAnnotation(a *models.Annotation) *image.Annotation {
+	if a == nil {
+		return &image.Annotation{}
+	}
+	return &image.Annotation{
+		Favorite: a.Favorite,
+		Rating:   int(a.Rating),
+		Tags:     a.Tags,
+		Note:     a.Note,
+	}
+}
+
+// toAnnotation converts an annotation of an image. It returns nil if the image doesn't have any annotations.
+func toAnnotation(a *image.Annotation) *models.Annotation {
+	if a == nil {
+		return nil
+	}
+	return &models.Annotation{
+		Favorite: a.Favorite,
+		Rating:   int64(a.Rating),
+		Tags:     a.Tags,
+		Note:     a.Note,
+	}
+}
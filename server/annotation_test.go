@@ -0,0 +1,104 @@
+// annotation_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+)
+
+func TestPutAnnotation(t *testing.T) {
+	ts, _ := newTestServer(t)
+	client := &testClient{t: t, url: ts.URL}
+
+	cases := []struct {
+		name   string
+		id     string
+		body   string
+		status int
+		expect *models.Annotation
+	}{
+		{
+			name:   "annotation",
+			id:     "a.png",
+			body:   `{"favorite": true, "rating": 4, "tags": [" keeper ", "keeper", ""], "note": " nice "}`,
+			status: http.StatusOK,
+			expect: &models.Annotation{Favorite: true, Rating: 4, Tags: []string{"keeper"}, Note: "nice"},
+		},
+		{
+			name:   "invalid rating",
+			id:     "b.png",
+			body:   `{"rating": 6}`,
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "too long tag",
+			id:     "b.png",
+			body:   `{"tags": ["` + strings.Repeat("x", 65) + `"]}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "not indexed image",
+			id:     "c.png",
+			body:   `{"favorite": true}`,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			res, body := client.do(http.MethodPut, "/images/"+c.id+"/annotations", c.body)
+			if res.StatusCode != c.status {
+				t.Fatalf("expect %v, got %v: %v", c.status, res.StatusCode, body)
+			}
+			if c.expect == nil {
+				return
+			}
+			var a models.Annotation
+			if err := json.Unmarshal([]byte(body), &a); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(&a, c.expect) {
+				t.Errorf("expect %v, got %v", c.expect, &a)
+			}
+		})
+	}
+
+	// annotated images can be searched immediately.
+	for _, path := range []string{
+		"/images?favorite=true",
+		"/images?minRating=4",
+		"/images?tag=keeper",
+		"/images?q=note:nice",
+	} {
+		var list models.ImageList
+		if err := json.Unmarshal([]byte(client.expect(http.MethodGet, path, http.StatusOK)), &list); err != nil {
+			t.Fatal(err)
+		}
+		if len(list.Items) != 1 || *list.Items[0].ID != "a.png" || list.Items[0].Annotation == nil {
+			t.Errorf("%v: expect a.png with its annotation, got %v", path, list.Items)
+		}
+	}
+
+	// an empty annotation removes it.
+	client.do(http.MethodPut, "/images/a.png/annotations", `{}`)
+	var list models.ImageList
+	body := client.expect(http.MethodGet, "/images?favorite=true", http.StatusOK)
+	if err := json.Unmarshal([]byte(body), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 0 {
+		t.Errorf("expect no favourites, got %v", list.Items)
+	}
+}
@@ -0,0 +1,347 @@
+// auth.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/runtime/security"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/jkawamoto/sd-image-viewer/auth"
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+)
+
+const (
+	sessionCookie = "session"
+	sessionTTL    = 30 * 24 * time.Hour
+	// defaultShareHours is the lifetime of share links if not given.
+	defaultShareHours = 168
+	bearerPrefix      = "Bearer "
+
+	// freeLoginFailures is the number of failed logins allowed before refusing logins.
+	freeLoginFailures = 5
+	// maxLoginLockout is the maximum period logins are refused after failures.
+	maxLoginLockout = 15 * time.Minute
+	// loginFailureTTL is the period failed logins are remembered.
+	loginFailureTTL = time.Hour
+	// maxLoginFailureKeys is the number of keys of failed logins which triggers removing expired ones.
+	maxLoginFailureKeys = 10000
+)
+
+// configureAuth sets up authentication of the API with the given user store. Requests are authenticated by session
+// cookies, API tokens or share links if users are registered, and every request is allowed otherwise.
+func configureAuth(api *operations.SdImageViewerAPI, users *auth.Store, c catalog.Catalog) {
+	api.APIKeyAuthenticator = func(name, in string, fn security.TokenAuthentication) runtime.Authenticator {
+		a := security.APIKeyAuth(name, in, fn)
+		return runtime.AuthenticatorFunc(func(params interface{}) (bool, interface{}, error) {
+			if !users.Enabled() {
+				return true, &auth.Principal{}, nil
+			}
+			return a.Authenticate(params)
+		})
+	}
+
+	api.SessionAuth = func(v string) (interface{}, error) {
+		cookie, err := (&http.Request{Header: http.Header{"Cookie": {v}}}).Cookie(sessionCookie)
+		if err != nil {
+			return nil, errors.Unauthenticated("session")
+		}
+		user, err := users.VerifySession(cookie.Value)
+		if err != nil {
+			return nil, errors.Unauthenticated("session")
+		}
+		return &auth.Principal{User: user}, nil
+	}
+	api.TokenAuth = func(v string) (interface{}, error) {
+		token, ok := strings.CutPrefix(v, bearerPrefix)
+		if !ok {
+			return nil, errors.Unauthenticated("token")
+		}
+		user, err := users.VerifyToken(token)
+		if err != nil {
+			return nil, errors.Unauthenticated("token")
+		}
+		return &auth.Principal{User: user}, nil
+	}
+	api.ShareAuth = func(v string) (interface{}, error) {
+		share, err := users.VerifyShare(v)
+		if err != nil {
+			return nil, errors.Unauthenticated("share")
+		}
+		return &auth.Principal{User: share.User, Share: share}, nil
+	}
+
+	api.APIAuthorizer = runtime.AuthorizerFunc(func(r *http.Request, principal interface{}) error {
+		p, ok := principal.(*auth.Principal)
+		if !ok {
+			return errors.New(http.StatusForbidden, "unknown principal")
+		}
+		if p.Share == nil {
+			return nil
+		}
+		return authorizeShare(r, c, p.Share)
+	})
+}
+
+// authorizeShare checks the request is allowed by the given share link. A link of an image allows reading the image,
+// and a link of a search allows searching images with the query string and reading the matching images. Other
+// filters can be added to the search since they only narrow down the results.
+func authorizeShare(r *http.Request, c catalog.Catalog, share *auth.Share) error {
+	route := middleware.MatchedRouteFrom(r)
+	if route == nil || route.Operation == nil {
+		return errors.New(http.StatusForbidden, "the share link doesn't grant access")
+	}
+	id := route.Params.Get("id")
+	sameSearch := share.Search != "" && r.URL.Query().Get("q") == share.Search
+
+	allowed := false
+	switch route.Operation.ID {
+	case "getImage", "headImage":
+		allowed = id == share.Image || share.Search != "" && matchesSearch(r, c, share.Search, id)
+	case "getImageDetail":
+		allowed = share.Image != "" && id == share.Image || sameSearch && matchesSearch(r, c, share.Search, id)
+	case "getImages":
+		allowed = sameSearch
+	}
+	if !allowed {
+		return errors.New(http.StatusForbidden, "the share link doesn't grant access to %v", r.URL.Path)
+	}
+	return nil
+}
+
+// matchesSearch returns true if the image of the given ID matches the given query string.
+func matchesSearch(r *http.Request, c catalog.Catalog, search, id string) bool {
+	if id == "" {
+		return false
+	}
+	filter, err := newFilter(nil, &search, nil, nil, nil, nil)
+	if err != nil {
+		return false
+	}
+	filter.ID = id
+	res, err := c.Search(r.Context(), &catalog.SearchRequest{Filter: filter, Limit: 1})
+	return err == nil && res.Total != 0
+}
+
+func LoginHandler(users *auth.Store, throttle *loginThrottle, logger *log.Logger) operations.LoginHandlerFunc {
+	return func(params operations.LoginParams) middleware.Responder {
+		user := swag.StringValue(params.Credentials.User)
+		keys := loginKeys(params.HTTPRequest, user)
+		if wait := throttle.wait(keys...); wait > 0 {
+			logger.Printf("Refused to log in as %q from %v", user, params.HTTPRequest.RemoteAddr)
+			return operations.NewLoginTooManyRequests().
+				WithRetryAfter(int64((wait + time.Second - 1) / time.Second)).
+				WithPayload(&models.StandardError{Message: swag.String("too many failed logins")})
+		}
+		if err := users.Authenticate(user, swag.StringValue(params.Credentials.Password)); err != nil {
+			logger.Printf("Failed to log in as %q", user)
+			throttle.fail(keys...)
+			return operations.NewLoginUnauthorized().WithPayload(&models.StandardError{
+				Message: swag.String("wrong user name or password"),
+			})
+		}
+		throttle.reset(keys...)
+
+		v, err := users.NewSession(user, sessionTTL)
+		if err != nil {
+			logger.Printf("Failed to create a session: %v", err)
+			return operations.NewLoginDefault(http.StatusInternalServerError).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		}
+		return operations.NewLoginNoContent().WithSetCookie(newSessionCookie(params.HTTPRequest, v, sessionTTL))
+	}
+}
+
+// loginKeys returns keys of failed logins of the given request, i.e. the client IP address and the user name.
+func loginKeys(r *http.Request, user string) []string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return []string{"ip:" + host, "user:" + user}
+}
+
+// loginThrottle refuses logins after failures so that passwords can't be guessed by brute force. Failures are counted
+// by keys, i.e. client IP addresses and user names; after freeLoginFailures failures, logins of the key are refused
+// for a period which doubles with each failure up to maxLoginLockout. Counts are forgotten after loginFailureTTL
+// without failures.
+type loginThrottle struct {
+	mu       sync.Mutex
+	failures map[string]*loginFailures
+	now      func() time.Time
+}
+
+// loginFailures is the record of failed logins of a key.
+type loginFailures struct {
+	count int
+	last  time.Time
+	until time.Time
+}
+
+func newLoginThrottle() *loginThrottle {
+	return &loginThrottle{failures: make(map[string]*loginFailures), now: time.Now}
+}
+
+// wait returns how long logins of the given keys are refused, which is zero if they are allowed.
+func (t *loginThrottle) wait(keys ...string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	var res time.Duration
+	for _, k := range keys {
+		if f, ok := t.failures[k]; ok && f.until.Sub(now) > res {
+			res = f.until.Sub(now)
+		}
+	}
+	return res
+}
+
+// fail records a failed login of the given keys.
+func (t *loginThrottle) fail(keys ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if len(t.failures) >= maxLoginFailureKeys {
+		t.forget(now)
+	}
+	for _, k := range keys {
+		f, ok := t.failures[k]
+		if !ok || now.Sub(f.last) > loginFailureTTL {
+			f = new(loginFailures)
+			t.failures[k] = f
+		}
+		f.count++
+		f.last = now
+		if n := f.count - freeLoginFailures; n > 0 {
+			lockout := maxLoginLockout
+			if n < 32 && time.Second<<(n-1) < maxLoginLockout {
+				lockout = time.Second << (n - 1)
+			}
+			f.until = now.Add(lockout)
+		}
+	}
+}
+
+// reset forgets failed logins of the given keys after a successful login.
+func (t *loginThrottle) reset(keys ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, k := range keys {
+		delete(t.failures, k)
+	}
+}
+
+// forget removes records which are expired so that they don't grow unboundedly. t.mu must be locked.
+func (t *loginThrottle) forget(now time.Time) {
+	for k, f := range t.failures {
+		if now.Sub(f.last) > loginFailureTTL && !now.Before(f.until) {
+			delete(t.failures, k)
+		}
+	}
+}
+
+func LogoutHandler() operations.LogoutHandlerFunc {
+	return func(params operations.LogoutParams) middleware.Responder {
+		return operations.NewLogoutNoContent().WithSetCookie(newSessionCookie(params.HTTPRequest, "", -1))
+	}
+}
+
+// newSessionCookie returns a session cookie of the given value. It is sent only for the same site so that other sites
+// can't make requests with the session.
+func newSessionCookie(r *http.Request, v string, ttl time.Duration) string {
+	c := &http.Cookie{
+		Name:     sessionCookie,
+		Value:    v,
+		Path:     "/",
+		MaxAge:   int(ttl.Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if ttl < 0 {
+		c.MaxAge = -1
+	}
+	return c.String()
+}
+
+func CreateShareHandler(users *auth.Store, c catalog.Catalog, logger *log.Logger) operations.CreateShareHandlerFunc {
+	return func(params operations.CreateShareParams, principal interface{}) middleware.Responder {
+		p, _ := principal.(*auth.Principal)
+		if p == nil || p.User == "" {
+			return operations.NewCreateShareDefault(http.StatusBadRequest).WithPayload(&models.StandardError{
+				Message: swag.String("share links require users to be registered"),
+			})
+		}
+
+		hours := params.Share.ExpiresIn
+		if hours == 0 {
+			hours = defaultShareHours
+		}
+		share := &auth.Share{
+			Image:   params.Share.Image,
+			Search:  params.Share.Q,
+			User:    p.User,
+			Expires: time.Now().Add(time.Duration(hours) * time.Hour).Truncate(time.Second),
+		}
+		if share.Image != "" {
+			img, err := c.Get(params.HTTPRequest.Context(), share.Image)
+			if err != nil {
+				logger.Printf("Failed to look up the image: %v", err)
+				return operations.NewCreateShareDefault(http.StatusInternalServerError).WithPayload(&models.StandardError{
+					Message: swag.String(err.Error()),
+				})
+			} else if img == nil {
+				return operations.NewCreateShareDefault(http.StatusNotFound).WithPayload(&models.StandardError{
+					Message: swag.String(fmt.Sprintf("image %v is not found", share.Image)),
+				})
+			}
+		}
+		if share.Search != "" {
+			if _, err := newFilter(nil, &share.Search, nil, nil, nil, nil); err != nil {
+				return operations.NewCreateShareDefault(http.StatusBadRequest).WithPayload(queryError(err))
+			}
+		}
+
+		token, err := users.NewShare(share)
+		if err != nil {
+			return operations.NewCreateShareDefault(http.StatusBadRequest).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		}
+		logger.Printf("%v shared %v%v until %v", p.User, share.Image, share.Search, share.Expires)
+
+		// the web UI shows the search given by the q parameter.
+		u := "/?" + url.Values{"q": {share.Search}, "share": {token}}.Encode()
+		if share.Image != "" {
+			u = "/api/v1/image/" + url.PathEscape(share.Image) + "?" + url.Values{"share": {token}}.Encode()
+		}
+		return operations.NewCreateShareCreated().WithPayload(&models.Share{
+			Token:   swag.String(token),
+			URL:     swag.String(u),
+			Expires: (*strfmt.DateTime)(&share.Expires),
+		})
+	}
+}
@@ -0,0 +1,275 @@
+// auth_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testClient sends requests to a test server with the given credentials.
+type testClient struct {
+	t      *testing.T
+	url    string
+	cookie string
+	token  string
+	share  string
+}
+
+func (c *testClient) do(method, path, body string) (*http.Response, string) {
+	c.t.Helper()
+
+	u := c.url + "/api/v1" + path
+	if c.share != "" {
+		sep := "?"
+		if strings.Contains(path, "?") {
+			sep = "&"
+		}
+		u += sep + url.Values{"share": {c.share}}.Encode()
+	}
+	req, err := http.NewRequest(method, u, strings.NewReader(body))
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.cookie != "" {
+		req.Header.Set("Cookie", c.cookie)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	b, err := io.ReadAll(res.Body)
+	if err = errors.Join(err, res.Body.Close()); err != nil {
+		c.t.Fatal(err)
+	}
+	return res, string(b)
+}
+
+func (c *testClient) expect(method, path string, status int) string {
+	c.t.Helper()
+
+	res, body := c.do(method, path, "")
+	if res.StatusCode != status {
+		c.t.Errorf("%v %v: expect %v, got %v: %v", method, path, status, res.StatusCode, body)
+	}
+	return body
+}
+
+func TestAuthentication(t *testing.T) {
+	ts, users := newTestServer(t)
+	anonymous := &testClient{t: t, url: ts.URL}
+	anonymous.expect(http.MethodGet, "/images", http.StatusOK)
+
+	if err := users.AddUser("alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	anonymous.expect(http.MethodGet, "/images", http.StatusUnauthorized)
+	anonymous.expect(http.MethodGet, "/image/a.png", http.StatusUnauthorized)
+	anonymous.expect(http.MethodGet, "/checkpoints", http.StatusUnauthorized)
+
+	res, _ := anonymous.do(http.MethodPost, "/login", `{"user": "alice", "password": "wrong"}`)
+	if res.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expect %v, got %v", http.StatusUnauthorized, res.StatusCode)
+	}
+	res, _ = anonymous.do(http.MethodPost, "/login", `{"user": "alice", "password": "secret"}`)
+	if res.StatusCode != http.StatusNoContent {
+		t.Fatalf("expect %v, got %v", http.StatusNoContent, res.StatusCode)
+	}
+	cookies := res.Cookies()
+	if len(cookies) != 1 || !cookies[0].HttpOnly || cookies[0].SameSite != http.SameSiteLaxMode {
+		t.Fatalf("expect an HTTP only session cookie, got %v", cookies)
+	}
+	session := &testClient{t: t, url: ts.URL, cookie: "other=1; " + cookies[0].Name + "=" + cookies[0].Value}
+	session.expect(http.MethodGet, "/images", http.StatusOK)
+	res, _ = session.do(http.MethodGet, "/image/a.png", "")
+	if cc := res.Header.Get("Cache-Control"); !strings.HasPrefix(cc, "private,") {
+		t.Errorf("expect images are cached only by browsers, got %v", cc)
+	}
+
+	res, _ = session.do(http.MethodPost, "/logout", "")
+	if cookies = res.Cookies(); len(cookies) != 1 || cookies[0].MaxAge >= 0 {
+		t.Errorf("expect the session cookie is removed, got %v", cookies)
+	}
+
+	token, _, err := users.CreateToken("alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := &testClient{t: t, url: ts.URL, token: token}
+	script.expect(http.MethodGet, "/images", http.StatusOK)
+	(&testClient{t: t, url: ts.URL, token: token + "x"}).expect(http.MethodGet, "/images", http.StatusUnauthorized)
+	(&testClient{t: t, url: ts.URL, cookie: "session=" + token}).expect(http.MethodGet, "/images", http.StatusUnauthorized)
+
+	if err = users.SetPassword("alice", "changed"); err != nil {
+		t.Fatal(err)
+	}
+	session.expect(http.MethodGet, "/images", http.StatusUnauthorized)
+}
+
+func TestShareLinks(t *testing.T) {
+	ts, users := newTestServer(t)
+	anonymous := &testClient{t: t, url: ts.URL}
+	res, _ := anonymous.do(http.MethodPost, "/shares", `{"image": "a.png"}`)
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expect share links are not available without users, got %v", res.StatusCode)
+	}
+
+	if err := users.AddUser("alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	token, _, err := users.CreateToken("alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &testClient{t: t, url: ts.URL, token: token}
+
+	share := func(body string) *testClient {
+		t.Helper()
+		res, b := user.do(http.MethodPost, "/shares", body)
+		if res.StatusCode != http.StatusCreated {
+			t.Fatalf("expect %v, got %v: %v", http.StatusCreated, res.StatusCode, b)
+		}
+		var v struct {
+			Token string `json:"token"`
+			URL   string `json:"url"`
+		}
+		if err := json.Unmarshal([]byte(b), &v); err != nil {
+			t.Fatal(err)
+		}
+		u, err := url.Parse(v.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Query().Get("share") != v.Token {
+			t.Errorf("expect the URL has the token, got %v", v.URL)
+		}
+		return &testClient{t: t, url: ts.URL, share: v.Token}
+	}
+
+	invalid := []string{`{}`, `{"image": "a.png", "q": "cat"}`, `{"q": "prompt:("}`, `{"image": "a.png", "expiresIn": -1}`}
+	for _, body := range invalid {
+		if res, b := user.do(http.MethodPost, "/shares", body); res.StatusCode/100 != 4 {
+			t.Errorf("expect %v is rejected, got %v: %v", body, res.StatusCode, b)
+		}
+	}
+	if res, _ := user.do(http.MethodPost, "/shares", `{"image": "missing.png"}`); res.StatusCode != http.StatusNotFound {
+		t.Errorf("expect %v, got %v", http.StatusNotFound, res.StatusCode)
+	}
+
+	image := share(`{"image": "a.png", "expiresIn": 1}`)
+	image.expect(http.MethodGet, "/image/a.png", http.StatusOK)
+	image.expect(http.MethodHead, "/image/a.png", http.StatusOK)
+	image.expect(http.MethodGet, "/images/a.png", http.StatusOK)
+	image.expect(http.MethodGet, "/image/b.png", http.StatusForbidden)
+	image.expect(http.MethodGet, "/images", http.StatusForbidden)
+	image.expect(http.MethodGet, "/checkpoints", http.StatusForbidden)
+	image.expect(http.MethodGet, "/index/failures", http.StatusForbidden)
+	if res, _ := image.do(http.MethodPost, "/shares", `{"image": "b.png"}`); res.StatusCode != http.StatusForbidden {
+		t.Errorf("expect share links can't create share links, got %v", res.StatusCode)
+	}
+
+	search := share(`{"q": "cat"}`)
+	body := search.expect(http.MethodGet, "/images?q=cat", http.StatusOK)
+	if !strings.Contains(body, `"a.png"`) || strings.Contains(body, `"b.png"`) {
+		t.Errorf("expect only a.png is found, got %v", body)
+	}
+	search.expect(http.MethodGet, "/images?q=cat&order=asc&checkpoint=model-a", http.StatusOK)
+	search.expect(http.MethodGet, "/images", http.StatusForbidden)
+	search.expect(http.MethodGet, "/images?q=dog", http.StatusForbidden)
+	search.expect(http.MethodGet, "/image/a.png", http.StatusOK)
+	search.expect(http.MethodGet, "/images/a.png?q=cat", http.StatusOK)
+	search.expect(http.MethodGet, "/images/a.png", http.StatusForbidden)
+	search.expect(http.MethodGet, "/image/b.png", http.StatusForbidden)
+
+	forged := &testClient{t: t, url: ts.URL, share: search.share + "x"}
+	forged.expect(http.MethodGet, "/images?q=cat", http.StatusUnauthorized)
+	if err = users.RemoveUser("alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err = users.AddUser("bob", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	image.expect(http.MethodGet, "/image/a.png", http.StatusUnauthorized)
+}
+
+func TestLoginThrottle(t *testing.T) {
+	now := time.Now()
+	throttle := newLoginThrottle()
+	throttle.now = func() time.Time { return now }
+
+	for i := 0; i < freeLoginFailures; i++ {
+		if wait := throttle.wait("ip:a", "user:alice"); wait != 0 {
+			t.Fatalf("expect logins are allowed after %v failures, got %v", i, wait)
+		}
+		throttle.fail("ip:a", "user:alice")
+	}
+	if wait := throttle.wait("ip:a"); wait != 0 {
+		t.Errorf("expect logins are allowed until failures exceed the limit, got %v", wait)
+	}
+
+	throttle.fail("ip:a", "user:alice")
+	if wait := throttle.wait("ip:b", "user:alice"); wait != time.Second {
+		t.Errorf("expect logins as the user are refused for a second, got %v", wait)
+	}
+	throttle.fail("ip:a", "user:alice")
+	if wait := throttle.wait("ip:a", "user:bob"); wait != 2*time.Second {
+		t.Errorf("expect logins from the client are refused for 2 seconds, got %v", wait)
+	}
+	if wait := throttle.wait("ip:b", "user:bob"); wait != 0 {
+		t.Errorf("expect logins of others are allowed, got %v", wait)
+	}
+
+	for i := 0; i < 30; i++ {
+		throttle.fail("ip:a")
+	}
+	if wait := throttle.wait("ip:a"); wait != maxLoginLockout {
+		t.Errorf("expect %v, got %v", maxLoginLockout, wait)
+	}
+
+	now = now.Add(loginFailureTTL + maxLoginLockout + time.Second)
+	throttle.fail("ip:a")
+	if wait := throttle.wait("ip:a"); wait != 0 {
+		t.Errorf("expect failures are forgotten, got %v", wait)
+	}
+	throttle.reset("user:alice")
+	if _, ok := throttle.failures["user:alice"]; ok {
+		t.Error("expect failures are reset")
+	}
+}
+
+func TestLoginTooManyRequests(t *testing.T) {
+	ts, users := newTestServer(t)
+	if err := users.AddUser("alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	client := &testClient{t: t, url: ts.URL}
+	for i := 0; i <= freeLoginFailures; i++ {
+		res, _ := client.do(http.MethodPost, "/login", `{"user": "alice", "password": "wrong"}`)
+		if res.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expect %v, got %v", http.StatusUnauthorized, res.StatusCode)
+		}
+	}
+	res, _ := client.do(http.MethodPost, "/login", `{"user": "alice", "password": "secret"}`)
+	if res.StatusCode != http.StatusTooManyRequests || res.Header.Get("Retry-After") != "1" {
+		t.Errorf("expect %v with Retry-After, got %v %v", http.StatusTooManyRequests, res.StatusCode, res.Header)
+	}
+}
@@ -0,0 +1,264 @@
+// collection.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+	"github.com/jkawamoto/sd-image-viewer/userdata"
+)
+
+// errImageNotIndexed is returned when images added to a collection aren't indexed.
+var errImageNotIndexed = errors.New("image is not found")
+
+func GetCollectionsHandler(data *userdata.Store, logger *log.Logger) operations.GetCollectionsHandlerFunc {
+	return func(params operations.GetCollectionsParams, _ interface{}) middleware.Responder {
+		list, err := data.Collections()
+		if err != nil {
+			logger.Printf("Failed to list collections: %v", err)
+			return operations.NewGetCollectionsDefault(http.StatusInternalServerError).
+				WithPayload(&models.StandardError{
+					Message: swag.String(err.Error()),
+				})
+		}
+
+		res := make([]*models.Collection, len(list))
+		for i, v := range list {
+			res[i] = toCollection(v)
+			// images are only given by the detail of a collection to keep lists small.
+			res[i].Images = nil
+		}
+		return operations.NewGetCollectionsOK().WithPayload(res)
+	}
+}
+
+func CreateCollectionHandler(data *userdata.Store, logger *log.Logger) operations.CreateCollectionHandlerFunc {
+	return func(params operations.CreateCollectionParams, _ interface{}) middleware.Responder {
+		res, err := data.CreateCollection(&userdata.Collection{
+			Name:        swag.StringValue(params.Collection.Name),
+			Description: params.Collection.Description,
+			Cover:       params.Collection.Cover,
+		})
+		if err != nil {
+			return operations.NewCreateCollectionDefault(collectionStatus(err, logger)).
+				WithPayload(&models.StandardError{
+					Message: swag.String(err.Error()),
+				})
+		}
+		return operations.NewCreateCollectionCreated().WithPayload(toCollection(res))
+	}
+}
+
+func GetCollectionHandler(data *userdata.Store, logger *log.Logger) operations.GetCollectionHandlerFunc {
+	return func(params operations.GetCollectionParams, _ interface{}) middleware.Responder {
+		res, err := data.Collection(params.ID)
+		if err != nil {
+			return operations.NewGetCollectionDefault(collectionStatus(err, logger)).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		}
+		return operations.NewGetCollectionOK().WithPayload(toCollection(res))
+	}
+}
+
+func UpdateCollectionHandler(data *userdata.Store, logger *log.Logger) operations.UpdateCollectionHandlerFunc {
+	return func(params operations.UpdateCollectionParams, _ interface{}) middleware.Responder {
+		res, err := data.UpdateCollection(params.ID, func(c *userdata.Collection) error {
+			c.Name = swag.StringValue(params.Collection.Name)
+			c.Description = params.Collection.Description
+			c.Cover = params.Collection.Cover
+			return nil
+		})
+		if err != nil {
+			return operations.NewUpdateCollectionDefault(collectionStatus(err, logger)).
+				WithPayload(&models.StandardError{
+					Message: swag.String(err.Error()),
+				})
+		}
+		return operations.NewUpdateCollectionOK().WithPayload(toCollection(res))
+	}
+}
+
+func DeleteCollectionHandler(data *userdata.Store, logger *log.Logger) operations.DeleteCollectionHandlerFunc {
+	return func(params operations.DeleteCollectionParams, _ interface{}) middleware.Responder {
+		if err := data.DeleteCollection(params.ID); err != nil {
+			return operations.NewDeleteCollectionDefault(collectionStatus(err, logger)).
+				WithPayload(&models.StandardError{
+					Message: swag.String(err.Error()),
+				})
+		}
+		return operations.NewDeleteCollectionNoContent()
+	}
+}
+
+func AddCollectionImagesHandler(
+	c catalog.Catalog, data *userdata.Store, logger *log.Logger,
+) operations.AddCollectionImagesHandlerFunc {
+	return func(params operations.AddCollectionImagesParams, _ interface{}) middleware.Responder {
+		err := checkIndexed(params.HTTPRequest.Context(), c, params.Images.Images)
+		if err != nil {
+			return operations.NewAddCollectionImagesDefault(collectionStatus(err, logger)).
+				WithPayload(&models.StandardError{
+					Message: swag.String(err.Error()),
+				})
+		}
+
+		res, err := data.UpdateCollection(params.ID, func(coll *userdata.Collection) error {
+			// positions are 1-based so that the zero value means appending images.
+			coll.Add(int(params.Images.Position)-1, params.Images.Images...)
+			return nil
+		})
+		if err != nil {
+			return operations.NewAddCollectionImagesDefault(collectionStatus(err, logger)).
+				WithPayload(&models.StandardError{
+					Message: swag.String(err.Error()),
+				})
+		}
+		return operations.NewAddCollectionImagesOK().WithPayload(toCollection(res))
+	}
+}
+
+func SetCollectionImagesHandler(
+	c catalog.Catalog, data *userdata.Store, logger *log.Logger,
+) operations.SetCollectionImagesHandlerFunc {
+	return func(params operations.SetCollectionImagesParams, _ interface{}) middleware.Responder {
+		res, err := data.UpdateCollection(params.ID, func(coll *userdata.Collection) error {
+			// images already in the collection are kept even if they aren't indexed now, e.g. while being reindexed.
+			var added []string
+			for _, id := range params.Images.Images {
+				if !contains(coll.Images, id) {
+					added = append(added, id)
+				}
+			}
+			if err := checkIndexed(params.HTTPRequest.Context(), c, added); err != nil {
+				return err
+			}
+			coll.Set(params.Images.Images...)
+			return nil
+		})
+		if err != nil {
+			return operations.NewSetCollectionImagesDefault(collectionStatus(err, logger)).
+				WithPayload(&models.StandardError{
+					Message: swag.String(err.Error()),
+				})
+		}
+		return operations.NewSetCollectionImagesOK().WithPayload(toCollection(res))
+	}
+}
+
+func RemoveCollectionImagesHandler(
+	data *userdata.Store, logger *log.Logger,
+) operations.RemoveCollectionImagesHandlerFunc {
+	return func(params operations.RemoveCollectionImagesParams, _ interface{}) middleware.Responder {
+		res, err := data.UpdateCollection(params.ID, func(coll *userdata.Collection) error {
+			coll.Remove(params.Image...)
+			return nil
+		})
+		if err != nil {
+			return operations.NewRemoveCollectionImagesDefault(collectionStatus(err, logger)).
+				WithPayload(&models.StandardError{
+					Message: swag.String(err.Error()),
+				})
+		}
+		return operations.NewRemoveCollectionImagesOK().WithPayload(toCollection(res))
+	}
+}
+
+// setCollectionFilter restricts the filter to images in the collection of the given ID if it isn't nil. Sorting images
+// by their positions requires a collection.
+func setCollectionFilter(data *userdata.Store, filter *catalog.Filter, id, sort *string) (int, error) {
+	if id == nil {
+		if swag.StringValue(sort) == catalog.SortPosition {
+			return http.StatusBadRequest, errors.New("sorting by position requires a collection")
+		}
+		return 0, nil
+	}
+
+	coll, err := data.Collection(*id)
+	if errors.Is(err, userdata.ErrCollectionNotFound) {
+		return http.StatusNotFound, err
+	} else if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	filter.IDs = coll.Images
+	if filter.IDs == nil {
+		filter.IDs = []string{}
+	}
+	return 0, nil
+}
+
+// checkIndexed returns errImageNotIndexed if any images of the given IDs aren't indexed.
+func checkIndexed(ctx context.Context, c catalog.Catalog, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	res, err := c.Search(ctx, &catalog.SearchRequest{Filter: catalog.Filter{IDs: ids}, Limit: len(ids)})
+	if err != nil {
+		return err
+	}
+	found := make(map[string]bool, len(res.Hits))
+	for _, v := range res.Hits {
+		found[v.ID] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			return fmt.Errorf("%w: %v", errImageNotIndexed, id)
+		}
+	}
+	return nil
+}
+
+// collectionStatus returns the status code of the given error of a collection. Unexpected errors are logged.
+func collectionStatus(err error, logger *log.Logger) int {
+	switch {
+	case errors.Is(err, userdata.ErrCollectionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, userdata.ErrInvalidCollection), errors.Is(err, errImageNotIndexed):
+		return http.StatusBadRequest
+	default:
+		logger.Printf("Failed to access a collection: %v", err)
+		return http.StatusInternalServerError
+	}
+}
+
+// toCollection converts a collection.
+func toCollection(c *userdata.Collection) *models.Collection {
+	created, updated := strfmt.DateTime(c.Created), strfmt.DateTime(c.Updated)
+	return &models.Collection{
+		ID:          swag.String(c.ID),
+		Name:        swag.String(c.Name),
+		Description: c.Description,
+		Cover:       c.CoverImage(),
+		Count:       swag.Int64(int64(len(c.Images))),
+		Images:      c.Images,
+		Created:     &created,
+		Updated:     &updated,
+	}
+}
+
+// contains returns true if ids has the given ID.
+func contains(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+// collection_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+)
+
+func TestCollections(t *testing.T) {
+	ts, _ := newTestServer(t)
+	client := &testClient{t: t, url: ts.URL}
+
+	decode := func(body string, v any) {
+		t.Helper()
+		if err := json.Unmarshal([]byte(body), v); err != nil {
+			t.Fatalf("failed to parse %v: %v", body, err)
+		}
+	}
+	send := func(method, path, body string, status int) *models.Collection {
+		t.Helper()
+		res, b := client.do(method, path, body)
+		if res.StatusCode != status {
+			t.Fatalf("%v %v: expect %v, got %v: %v", method, path, status, res.StatusCode, b)
+		}
+		var c models.Collection
+		if status < http.StatusBadRequest && status != http.StatusNoContent {
+			decode(b, &c)
+		}
+		return &c
+	}
+	expectImages := func(c *models.Collection, expect ...string) {
+		t.Helper()
+		if expect == nil {
+			expect = []string{}
+		}
+		if !reflect.DeepEqual(c.Images, expect) {
+			t.Errorf("expect %v, got %v", expect, c.Images)
+		}
+	}
+
+	body := `{"name": " client deliverables ", "description": "final"}`
+	c := send(http.MethodPost, "/collections", body, http.StatusCreated)
+	if *c.Name != "client deliverables" || c.Description != "final" || *c.Count != 0 {
+		t.Errorf("expect a new collection, got %+v", c)
+	}
+	id := *c.ID
+	path := "/collections/" + id
+	send(http.MethodPost, "/collections", `{"name": ""}`, http.StatusBadRequest)
+
+	c = send(http.MethodPost, path+"/images", `{"images": ["b.png", "a.png"]}`, http.StatusOK)
+	expectImages(c, "b.png", "a.png")
+	if c.Cover != "b.png" {
+		t.Errorf("expect the first image is the cover, got %v", c.Cover)
+	}
+	send(http.MethodPost, path+"/images", `{"images": ["missing.png"]}`, http.StatusBadRequest)
+	c = send(http.MethodPost, path+"/images", `{"images": ["a.png"], "position": 1}`, http.StatusOK)
+	expectImages(c, "a.png", "b.png")
+
+	c = send(http.MethodPut, path, `{"name": "renamed", "cover": "b.png"}`, http.StatusOK)
+	if *c.Name != "renamed" || c.Description != "" || c.Cover != "b.png" {
+		t.Errorf("expect the collection is updated, got %+v", c)
+	}
+	send(http.MethodPut, path, `{"name": "renamed", "cover": "missing.png"}`, http.StatusBadRequest)
+
+	// images are listed in the order of the collection.
+	var list models.ImageList
+	decode(client.expect(http.MethodGet, "/images?sort=position&collection="+id, http.StatusOK), &list)
+	if len(list.Items) != 2 || *list.Items[0].ID != "a.png" || *list.Items[1].ID != "b.png" {
+		t.Errorf("expect images in the collection, got %v", list.Items)
+	}
+	var detail models.ImageDetail
+	decode(client.expect(http.MethodGet, "/images/a.png?sort=position&collection="+id, http.StatusOK), &detail)
+	if detail.Previous != "" || detail.Next != "b.png" {
+		t.Errorf("expect the next image is b.png, got %q and %q", detail.Previous, detail.Next)
+	}
+	client.expect(http.MethodGet, "/images?sort=position", http.StatusBadRequest)
+	client.expect(http.MethodGet, "/images?collection=missing", http.StatusNotFound)
+
+	c = send(http.MethodPut, path+"/images", `{"images": ["b.png", "a.png"]}`, http.StatusOK)
+	expectImages(c, "b.png", "a.png")
+	c = send(http.MethodDelete, path+"/images?image=b.png", "", http.StatusOK)
+	expectImages(c, "a.png")
+	if c.Cover != "a.png" {
+		t.Errorf("expect the cover is reset, got %v", c.Cover)
+	}
+
+	var collections []*models.Collection
+	decode(client.expect(http.MethodGet, "/collections", http.StatusOK), &collections)
+	if len(collections) != 1 || *collections[0].ID != id || *collections[0].Count != 1 || collections[0].Images != nil {
+		t.Errorf("expect the collection without images, got %v", collections)
+	}
+
+	send(http.MethodDelete, path, "", http.StatusNoContent)
+	send(http.MethodGet, path, "", http.StatusNotFound)
+	send(http.MethodDelete, path, "", http.StatusNotFound)
+	decode(client.expect(http.MethodGet, "/images", http.StatusOK), &list)
+	if len(list.Items) != 2 {
+		t.Errorf("expect images aren't deleted, got %v", list.Items)
+	}
+}
@@ -0,0 +1,414 @@
+// download.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+
+	"github.com/jkawamoto/sd-image-viewer/archive"
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/export"
+	"github.com/jkawamoto/sd-image-viewer/image"
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+	"github.com/jkawamoto/sd-image-viewer/source"
+	"github.com/jkawamoto/sd-image-viewer/userdata"
+)
+
+const (
+	manifestCSV  = "csv"
+	manifestNone = "none"
+
+	// maxStrippedSize is the maximum size of non-seekable WebP images read into memory to strip their metadata.
+	maxStrippedSize = 32 << 20
+)
+
+// manifestColumns are the columns of CSV manifests following the file name and the ID. The others are keys of
+// metadata of images.
+var manifestColumns = []string{
+	"prompt", "negative-prompt", "checkpoint", "Sampler", "Steps", "CFG scale", "Seed", "Size", "creation-time",
+	"parameters",
+}
+
+// DownloadImagesHandler streams a ZIP archive of the requested images, which is written while searching images page by
+// page so that neither the whole result nor temporary files are kept.
+func DownloadImagesHandler(
+	c catalog.Catalog, src source.Source, data *userdata.Store, logger *log.Logger,
+) operations.DownloadImagesHandlerFunc {
+	return func(params operations.DownloadImagesParams, _ interface{}) middleware.Responder {
+		opts := params.Options
+		if opts == nil {
+			opts = new(models.DownloadRequest)
+		}
+
+		req := &catalog.SearchRequest{Sort: swag.StringValue(params.Sort)}
+		if len(opts.Images) != 0 {
+			req.Filter.IDs = opts.Images
+			req.Sort = catalog.SortPosition
+		} else {
+			filter, err := newFilter(params.Query, params.Q, params.Size, params.Checkpoint, params.Before, params.After)
+			if err != nil {
+				return jsonResponder{
+					operations.NewDownloadImagesDefault(http.StatusBadRequest).WithPayload(queryError(err)),
+				}
+			}
+			setAnnotationFilter(&filter, params.Favorite, params.MinRating, params.Tag)
+			if code, err := setCollectionFilter(data, &filter, params.Collection, params.Sort); err != nil {
+				return jsonResponder{operations.NewDownloadImagesDefault(code).WithPayload(&models.StandardError{
+					Message: swag.String(err.Error()),
+				})}
+			}
+			req.Filter = filter
+		}
+		if swag.StringValue(params.Order) == "asc" {
+			req.Order = catalog.Ascending
+		}
+
+		return &zipContent{
+			ctx:     params.HTTPRequest.Context(),
+			catalog: c,
+			src:     src,
+			req:     req,
+			opts:    opts,
+			logger:  logger,
+		}
+	}
+}
+
+// zipContent is a responder writing images matching a search request and their manifest as a ZIP archive.
+type zipContent struct {
+	ctx     context.Context
+	catalog catalog.Catalog
+	src     source.Source
+	req     *catalog.SearchRequest
+	opts    *models.DownloadRequest
+	logger  *log.Logger
+}
+
+// WriteResponse implements middleware.Responder.
+func (z *zipContent) WriteResponse(rw http.ResponseWriter, _ runtime.Producer) {
+	clearWriteDeadline(rw, z.logger)
+	h := rw.Header()
+	h.Set("Content-Type", "application/zip")
+	h.Set("Content-Disposition", `attachment; filename="images.zip"`)
+	rw.WriteHeader(http.StatusOK)
+
+	// errors can't be reported once the status is sent, so the archive is left truncated to be detected as broken.
+	if err := z.write(rw); err != nil && z.ctx.Err() == nil {
+		z.logger.Printf("Failed to write a ZIP archive: %v", err)
+	}
+}
+
+// clearWriteDeadline clears the write deadline of the response so that streaming a large response isn't cut off by the
+// write timeout of the server.
+func clearWriteDeadline(rw http.ResponseWriter, logger *log.Logger) {
+	if err := http.NewResponseController(rw).SetWriteDeadline(time.Time{}); err != nil {
+		logger.Printf("Failed to clear the write deadline: %v", err)
+	}
+}
+
+// write writes the archive. Images which can't be read are skipped. The manifest is stored after the images, and its
+// rows are read from the index again in pages of the written images so that only their names are kept in memory.
+func (z *zipContent) write(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	// used has names of the written images only when images are flattened since IDs are unique otherwise.
+	var used map[string]bool
+	if z.opts.Flatten {
+		used = make(map[string]bool)
+	}
+	var written []writtenImage
+	err := export.Each(z.ctx, z.catalog, z.req, func(hit *catalog.Hit) error {
+		name := z.entryName(hit.ID, used)
+		ok, err := z.writeImage(zw, hit.ID, name)
+		if ok && z.opts.Manifest != manifestNone {
+			written = append(written, writtenImage{id: hit.ID, name: name})
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if z.opts.Manifest != manifestNone {
+		if err = z.writeManifest(zw, written); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// strip returns a reader of the given image without metadata, which is stripped while reading it. Non-seekable WebP
+// images, e.g. in archives, are read into memory unless they are larger than maxStrippedSize. The image is read until
+// the first bytes are stripped so that broken images are skipped without breaking the archive; errors found after
+// that are returned by the reader. Closing the reader waits until stripping stops.
+func (z *zipContent) strip(f io.Reader, info fs.FileInfo, id string) (io.ReadCloser, error) {
+	if _, ok := f.(io.ReadSeeker); !ok && strings.EqualFold(path.Ext(id), ".webp") {
+		if info.Size() > maxStrippedSize {
+			return nil, errors.New("file is too large")
+		}
+		b, err := io.ReadAll(io.LimitReader(f, maxStrippedSize+1))
+		if err != nil {
+			return nil, err
+		} else if len(b) > maxStrippedSize {
+			return nil, errors.New("file is too large")
+		}
+		f = bytes.NewReader(b)
+	}
+
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(image.StripMetadata(pw, f, id))
+	}()
+	r := &strippedImage{Reader: bufio.NewReader(pr), pipe: pr, done: done}
+	if _, err := r.Peek(1); err != nil {
+		return nil, errors.Join(err, r.Close())
+	}
+	return r, nil
+}
+
+// strippedImage reads an image being stripped by another goroutine.
+type strippedImage struct {
+	*bufio.Reader
+	pipe *io.PipeReader
+	done chan struct{}
+}
+
+func (s *strippedImage) Close() error {
+	err := s.pipe.Close()
+	<-s.done
+	return err
+}
+
+// writtenImage is an image written in an archive.
+type writtenImage struct {
+	id   string
+	name string
+}
+
+// writeManifest writes the manifest of the given images, which are read from the index export.PageSize at a time.
+// Images removed from the index after being written are omitted.
+func (z *zipContent) writeManifest(zw *zip.Writer, written []writtenImage) error {
+	var mw manifestWriter
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: z.manifestName(), Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	if z.opts.Manifest == manifestCSV {
+		mw = newCSVManifest(w)
+	} else {
+		mw = newJSONManifest(w)
+	}
+
+	for len(written) != 0 {
+		page := written
+		if len(page) > export.PageSize {
+			page = page[:export.PageSize]
+		}
+		written = written[len(page):]
+
+		names := make(map[string]string, len(page))
+		req := &catalog.SearchRequest{Sort: catalog.SortPosition}
+		for _, v := range page {
+			names[v.id] = v.name
+			req.Filter.IDs = append(req.Filter.IDs, v.id)
+		}
+		err = export.Each(z.ctx, z.catalog, req, func(hit *catalog.Hit) error {
+			return mw.write(names[hit.ID], hit)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return mw.close()
+}
+
+// manifestName returns the name of the manifest in the archive.
+func (z *zipContent) manifestName() string {
+	if z.opts.Manifest == manifestCSV {
+		return "manifest.csv"
+	}
+	return "manifest.json"
+}
+
+// entryName returns the name of the image of the given ID in the archive. Images in archives are stored in folders
+// named after the archives. If used isn't nil, the name isn't in used and is added to it.
+func (z *zipContent) entryName(id string, used map[string]bool) string {
+	name := strings.ReplaceAll(id, archive.Separator, "/")
+	if z.opts.Flatten {
+		name = path.Base(name)
+	}
+	if used == nil {
+		return name
+	}
+
+	res := name
+	ext := path.Ext(name)
+	for i := 2; used[res]; i++ {
+		res = strings.TrimSuffix(name, ext) + "." + strconv.Itoa(i) + ext
+	}
+	used[res] = true
+	return res
+}
+
+// writeImage writes the image of the given ID as the given name. It returns false if the image can't be read, which
+// is logged and skipped.
+func (z *zipContent) writeImage(zw *zip.Writer, id, name string) (bool, error) {
+	f, info, err := openImage(z.src, id)
+	if err != nil {
+		z.logger.Printf("Failed to open an image file: %v", err)
+		return false, nil
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			z.logger.Printf("Failed to close a file: %v", err)
+		}
+	}()
+
+	var content io.Reader = f
+	if z.opts.StripMetadata {
+		r, err := z.strip(f, info, id)
+		if err != nil {
+			z.logger.Printf("Failed to strip metadata of %v: %v", id, err)
+			return false, nil
+		}
+		defer r.Close()
+		content = r
+	}
+
+	// images are already compressed.
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store, Modified: info.ModTime()})
+	if err != nil {
+		return false, err
+	}
+	if _, err = io.Copy(w, content); err != nil {
+		return false, fmt.Errorf("failed to copy %v: %w", id, err)
+	}
+	return true, nil
+}
+
+// manifestWriter writes a manifest of the images in an archive.
+type manifestWriter interface {
+	// write writes an entry of the image stored as the given name.
+	write(name string, hit *catalog.Hit) error
+	// close writes the end of the manifest.
+	close() error
+}
+
+// manifestEntry is an image in JSON manifests.
+type manifestEntry struct {
+	// File is the name of the image in the archive.
+	File  string        `json:"file"`
+	Image *models.Image `json:"image"`
+}
+
+// jsonManifest writes manifest.json, which is an array of written images and their metadata.
+type jsonManifest struct {
+	w   io.Writer
+	enc *json.Encoder
+	sep string
+}
+
+func newJSONManifest(w io.Writer) *jsonManifest {
+	return &jsonManifest{w: w, enc: json.NewEncoder(w), sep: "[\n"}
+}
+
+func (m *jsonManifest) write(name string, hit *catalog.Hit) error {
+	if _, err := io.WriteString(m.w, m.sep); err != nil {
+		return err
+	}
+	m.sep = ","
+
+	img := toImage(hit.ID, hit.Image)
+	img.Parameters = hit.Image.Parameters
+	return m.enc.Encode(&manifestEntry{File: name, Image: img})
+}
+
+func (m *jsonManifest) close() error {
+	end := "]\n"
+	if m.sep != "," {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(m.w, end)
+	return err
+}
+
+// csvManifest writes manifest.csv, which has a row of each written image.
+type csvManifest struct {
+	w      *csv.Writer
+	header bool
+}
+
+func newCSVManifest(w io.Writer) *csvManifest {
+	return &csvManifest{w: csv.NewWriter(w)}
+}
+
+func (m *csvManifest) write(name string, hit *catalog.Hit) error {
+	if err := m.writeHeader(); err != nil {
+		return err
+	}
+
+	img := hit.Image
+	row := []string{name, hit.ID}
+	for _, col := range manifestColumns {
+		switch col {
+		case "prompt":
+			row = append(row, img.Prompt)
+		case "negative-prompt":
+			row = append(row, img.NegativePrompt)
+		case "checkpoint":
+			row = append(row, img.Checkpoint)
+		case "creation-time":
+			row = append(row, img.CreationTime.Format(time.RFC3339))
+		case "parameters":
+			row = append(row, img.Parameters)
+		default:
+			row = append(row, img.Metadata[col])
+		}
+	}
+	return m.w.Write(row)
+}
+
+// writeHeader writes the header unless it is written.
+func (m *csvManifest) writeHeader() error {
+	if m.header {
+		return nil
+	}
+	m.header = true
+	return m.w.Write(append([]string{"file", "id"}, manifestColumns...))
+}
+
+func (m *csvManifest) close() error {
+	if err := m.writeHeader(); err != nil {
+		return err
+	}
+	m.w.Flush()
+	return m.w.Error()
+}
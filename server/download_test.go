@@ -0,0 +1,290 @@
+// download_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	goimage "image"
+	"image/png"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/swag"
+
+	"github.com/jkawamoto/sd-image-viewer/archive"
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/export"
+	"github.com/jkawamoto/sd-image-viewer/image"
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+	"github.com/jkawamoto/sd-image-viewer/source"
+)
+
+func TestDownloadImages(t *testing.T) {
+	ts, _ := newTestServer(t)
+	client := &testClient{t: t, url: ts.URL}
+
+	// download returns contents of files in the archive in order.
+	download := func(path, body string) ([]string, map[string]string) {
+		t.Helper()
+		res, b := client.do(http.MethodPost, path, body)
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("expect %v, got %v: %v", http.StatusOK, res.StatusCode, b)
+		}
+		if ct := res.Header.Get("Content-Type"); ct != "application/zip" {
+			t.Errorf("expect application/zip, got %v", ct)
+		}
+		r, err := zip.NewReader(strings.NewReader(b), int64(len(b)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		files := make(map[string]string)
+		for _, f := range r.File {
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			content, err := io.ReadAll(rc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			names = append(names, f.Name)
+			files[f.Name] = string(content)
+		}
+		return names, files
+	}
+
+	names, files := download("/downloads", `{"images": ["b.png", "missing.png", "a.png"], "manifest": "csv"}`)
+	if expect := []string{"b.png", "a.png", "manifest.csv"}; !reflect.DeepEqual(names, expect) {
+		t.Errorf("expect %v, got %v", expect, names)
+	}
+	if files["a.png"] != testImage {
+		t.Errorf("expect %q, got %q", testImage, files["a.png"])
+	}
+	rows, err := csv.NewReader(strings.NewReader(files["manifest.csv"])).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[0][0] != "file" || rows[1][0] != "b.png" || rows[1][2] != "a dog" {
+		t.Errorf("expect a row of each image, got %v", rows)
+	}
+
+	names, files = download("/downloads?q=cat", "")
+	if expect := []string{"a.png", "manifest.json"}; !reflect.DeepEqual(names, expect) {
+		t.Errorf("expect %v, got %v", expect, names)
+	}
+	var manifest []*manifestEntry
+	if err = json.Unmarshal([]byte(files["manifest.json"]), &manifest); err != nil {
+		t.Fatalf("failed to parse the manifest: %v", err)
+	}
+	if len(manifest) != 1 || manifest[0].File != "a.png" || manifest[0].Image.Prompt != "a cat" {
+		t.Errorf("expect a.png in the manifest, got %v", manifest)
+	}
+
+	// images which can't be stripped are skipped since they aren't PNG files.
+	names, _ = download("/downloads", `{"images": ["a.png"], "manifest": "none", "stripMetadata": true}`)
+	if len(names) != 0 {
+		t.Errorf("expect no files, got %v", names)
+	}
+
+	client.expect(http.MethodPost, "/downloads?q=prompt:(", http.StatusBadRequest)
+	client.expect(http.MethodPost, "/downloads?collection=missing", http.StatusNotFound)
+}
+
+// slowSource is a source taking the given time to open each file.
+type slowSource struct {
+	source.Source
+	delay time.Duration
+}
+
+func (s slowSource) Open(name string) (fs.File, error) {
+	time.Sleep(s.delay)
+	return s.Source.Open(name)
+}
+
+func TestDownloadImagesWriteTimeout(t *testing.T) {
+	lib := t.TempDir()
+	docs := make(map[string]catalog.Document)
+	for _, name := range []string{"a.png", "b.png", "c.png"} {
+		if err := os.WriteFile(filepath.Join(lib, name), []byte(testImage), 0644); err != nil {
+			t.Fatal(err)
+		}
+		docs[name] = &image.Image{Prompt: name, CreationTime: time.Now()}
+	}
+	c, err := catalog.NewBleveMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	if err = c.Index(context.Background(), docs); err != nil {
+		t.Fatal(err)
+	}
+
+	logger := log.New(io.Discard, "", 0)
+	handler := DownloadImagesHandler(c, slowSource{Source: source.Dir(lib), delay: 100 * time.Millisecond}, nil, logger)
+	ts := httptest.NewUnstartedServer(withLogger(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		params := operations.NewDownloadImagesParams()
+		params.HTTPRequest = req
+		handler(params, nil).WriteResponse(rw, nil)
+	}), logger))
+	// the archive takes longer than the write timeout.
+	ts.Config.WriteTimeout = 150 * time.Millisecond
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	res, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(res.Body)
+	if err = errors.Join(err, res.Body.Close()); err != nil {
+		t.Fatal(err)
+	}
+	r, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatalf("expect a complete archive, got %v", err)
+	}
+	if len(r.File) != len(docs)+1 {
+		t.Errorf("expect %v files, got %v", len(docs)+1, len(r.File))
+	}
+}
+
+// newTextPNG returns a PNG image having the given generation parameters in a text chunk, and the image without it.
+func newTextPNG(t *testing.T, parameters string) ([]byte, []byte) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, goimage.NewGray(goimage.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+	data := append([]byte("parameters\x00"), parameters...)
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(append(chunk, "tEXt"...), data...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	// the text chunk follows the signature and the IHDR chunk.
+	ihdr := 8 + 25
+	return append(append(append([]byte{}, buf.Bytes()[:ihdr]...), chunk...), buf.Bytes()[ihdr:]...), buf.Bytes()
+}
+
+func TestDownloadImagesStripMetadata(t *testing.T) {
+	lib := t.TempDir()
+	img, stripped := newTextPNG(t, "a cat\nSteps: 20")
+
+	// images in archives aren't seekable, and manifests have more rows than a page.
+	var ids []string
+	docs := make(map[string]catalog.Document)
+	for i := 0; i < export.PageSize+1; i++ {
+		name := fmt.Sprintf("%03d.png", i)
+		if err := os.WriteFile(filepath.Join(lib, name), img, 0644); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, name)
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Write(img); err != nil {
+		t.Fatal(err)
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(filepath.Join(lib, "images.zip"), buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ids = append(ids, archive.Join("images.zip", "a.png"))
+	for i, id := range ids {
+		docs[id] = &image.Image{Prompt: id, CreationTime: time.Unix(int64(i), 0)}
+	}
+
+	c, err := catalog.NewBleveMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	if err = c.Index(context.Background(), docs); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := DownloadImagesHandler(c, source.Archives(source.Dir(lib)), nil, log.New(io.Discard, "", 0))
+	params := operations.NewDownloadImagesParams()
+	params.HTTPRequest = httptest.NewRequest(http.MethodPost, "/downloads", nil)
+	params.Order = swag.String("asc")
+	params.Options = &models.DownloadRequest{StripMetadata: true, Manifest: manifestCSV}
+	rec := httptest.NewRecorder()
+	handler(params, nil).WriteResponse(rec, nil)
+
+	r, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatalf("expect a complete archive, got %v", err)
+	}
+	if len(r.File) != len(ids)+1 {
+		t.Fatalf("expect %v files, got %v", len(ids)+1, len(r.File))
+	}
+	for _, f := range r.File[:len(ids)] {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		if err = errors.Join(err, rc.Close()); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, stripped) {
+			t.Errorf("expect metadata of %v is stripped", f.Name)
+		}
+	}
+
+	rc, err := r.File[len(ids)].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(rc).ReadAll()
+	if err = errors.Join(err, rc.Close()); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != len(ids)+1 {
+		t.Fatalf("expect %v rows, got %v", len(ids)+1, len(rows))
+	}
+	for i, row := range rows[1:] {
+		if row[1] != ids[i] || row[0] != r.File[i].Name {
+			t.Errorf("expect a row of %v, got %v", ids[i], row)
+		}
+	}
+}
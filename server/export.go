@@ -0,0 +1,116 @@
+// export.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/export"
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+	"github.com/jkawamoto/sd-image-viewer/userdata"
+)
+
+// exportFiles maps export formats to their content types and file names.
+var exportFiles = map[string]struct {
+	contentType, name string
+}{
+	export.FormatJSONL:   {contentType: "application/x-ndjson", name: "images.jsonl"},
+	export.FormatCSV:     {contentType: "text/csv; charset=utf-8", name: "images.csv"},
+	export.FormatPrompts: {contentType: "text/plain; charset=utf-8", name: "prompts.txt"},
+}
+
+// ExportImagesHandler streams metadata of images matching the search parameters in the requested format, which is
+// written while searching images page by page.
+func ExportImagesHandler(
+	c catalog.Catalog, data *userdata.Store, logger *log.Logger,
+) operations.ExportImagesHandlerFunc {
+	return func(params operations.ExportImagesParams, _ interface{}) middleware.Responder {
+		format := swag.StringValue(params.Format)
+		// checks the format and the columns before sending the status.
+		if _, err := export.NewWriter(io.Discard, format, params.Columns); err != nil {
+			return jsonResponder{operations.NewExportImagesDefault(http.StatusBadRequest).WithPayload(
+				&models.StandardError{Message: swag.String(err.Error())},
+			)}
+		}
+
+		filter, err := newFilter(params.Query, params.Q, params.Size, params.Checkpoint, params.Before, params.After)
+		if err != nil {
+			return jsonResponder{operations.NewExportImagesDefault(http.StatusBadRequest).WithPayload(queryError(err))}
+		}
+		setAnnotationFilter(&filter, params.Favorite, params.MinRating, params.Tag)
+		if code, err := setCollectionFilter(data, &filter, params.Collection, params.Sort); err != nil {
+			return jsonResponder{operations.NewExportImagesDefault(code).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})}
+		}
+
+		req := &catalog.SearchRequest{Filter: filter, Sort: swag.StringValue(params.Sort)}
+		if swag.StringValue(params.Order) == "asc" {
+			req.Order = catalog.Ascending
+		}
+		return &exportContent{
+			ctx:     params.HTTPRequest.Context(),
+			catalog: c,
+			req:     req,
+			format:  format,
+			columns: params.Columns,
+			logger:  logger,
+		}
+	}
+}
+
+// exportContent is a responder writing images matching a search request in an export format.
+type exportContent struct {
+	ctx     context.Context
+	catalog catalog.Catalog
+	req     *catalog.SearchRequest
+	format  string
+	columns []string
+	logger  *log.Logger
+}
+
+// WriteResponse implements middleware.Responder.
+func (e *exportContent) WriteResponse(rw http.ResponseWriter, _ runtime.Producer) {
+	clearWriteDeadline(rw, e.logger)
+	file := exportFiles[e.format]
+	h := rw.Header()
+	h.Set("Content-Type", file.contentType)
+	h.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%v"`, file.name))
+	rw.WriteHeader(http.StatusOK)
+
+	// errors can't be reported once the status is sent, so the export is left truncated.
+	if err := e.write(rw); err != nil && e.ctx.Err() == nil {
+		e.logger.Printf("Failed to export images: %v", err)
+	}
+}
+
+// write writes the images.
+func (e *exportContent) write(w io.Writer) error {
+	ew, err := export.NewWriter(w, e.format, e.columns)
+	if err != nil {
+		return err
+	}
+	err = export.Each(e.ctx, e.catalog, e.req, func(hit *catalog.Hit) error {
+		return ew.Write(hit.ID, hit.Image)
+	})
+	if err != nil {
+		return err
+	}
+	return ew.Flush()
+}
@@ -0,0 +1,136 @@
+// export_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/image"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+)
+
+func TestExportImages(t *testing.T) {
+	ts, _ := newTestServer(t)
+	client := &testClient{t: t, url: ts.URL}
+
+	cases := []struct {
+		path        string
+		contentType string
+		expect      string
+	}{
+		{
+			path:        "/exports?q=cat&format=csv&columns=id,prompt",
+			contentType: "text/csv; charset=utf-8",
+			expect:      "id,prompt\na.png,a cat\n",
+		},
+		{
+			path:        "/exports?q=dog&format=prompts",
+			contentType: "text/plain; charset=utf-8",
+			expect:      "--prompt \"a dog\"\n",
+		},
+		{
+			path:        "/exports?q=missing&format=csv&columns=id",
+			contentType: "text/csv; charset=utf-8",
+			expect:      "id\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			res, body := client.do(http.MethodGet, c.path, "")
+			if res.StatusCode != http.StatusOK {
+				t.Fatalf("expect %v, got %v: %v", http.StatusOK, res.StatusCode, body)
+			}
+			if ct := res.Header.Get("Content-Type"); ct != c.contentType {
+				t.Errorf("expect %v, got %v", c.contentType, ct)
+			}
+			if body != c.expect {
+				t.Errorf("expect %q, got %q", c.expect, body)
+			}
+		})
+	}
+
+	_, body := client.do(http.MethodGet, "/exports?order=asc", "")
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"id":"a.png"`) || !strings.Contains(lines[1], `"prompt":"a dog"`) {
+		t.Errorf("expect a JSON line of each image, got %q", body)
+	}
+
+	client.expect(http.MethodGet, "/exports?format=xml", http.StatusUnprocessableEntity)
+	// errors are written in JSON even if the CSV format is requested.
+	res, body := client.do(http.MethodGet, "/exports?q=prompt:(&format=csv", "")
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expect %v, got %v: %v", http.StatusBadRequest, res.StatusCode, body)
+	}
+	if ct := res.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expect application/json, got %v", ct)
+	}
+	client.expect(http.MethodGet, "/exports?collection=missing", http.StatusNotFound)
+}
+
+// slowCatalog is a catalog taking the given time to search images.
+type slowCatalog struct {
+	catalog.Catalog
+	delay time.Duration
+}
+
+func (c slowCatalog) Search(ctx context.Context, req *catalog.SearchRequest) (*catalog.SearchResult, error) {
+	time.Sleep(c.delay)
+	return c.Catalog.Search(ctx, req)
+}
+
+func TestExportImagesWriteTimeout(t *testing.T) {
+	c, err := catalog.NewBleveMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	err = c.Index(context.Background(), map[string]catalog.Document{
+		"a.png": &image.Image{Prompt: "a cat", CreationTime: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := log.New(io.Discard, "", 0)
+	handler := ExportImagesHandler(slowCatalog{Catalog: c, delay: 300 * time.Millisecond}, nil, logger)
+	ts := httptest.NewUnstartedServer(withLogger(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		params := operations.NewExportImagesParams()
+		params.HTTPRequest = req
+		handler(params, nil).WriteResponse(rw, nil)
+	}), logger))
+	// the export takes longer than the write timeout.
+	ts.Config.WriteTimeout = 150 * time.Millisecond
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	res, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(res.Body)
+	if err = errors.Join(err, res.Body.Close()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"id":"a.png"`) {
+		t.Errorf("expect a complete export, got %q", b)
+	}
+}
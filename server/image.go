@@ -0,0 +1,309 @@
+// image.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+
+	"github.com/jkawamoto/sd-image-viewer/auth"
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+	"github.com/jkawamoto/sd-image-viewer/source"
+	"github.com/jkawamoto/sd-image-viewer/thumbnail"
+	"github.com/jkawamoto/sd-image-viewer/userdata"
+)
+
+const (
+	// maxBufferedSize is the maximum size of files read into memory to serve byte ranges when they aren't seekable,
+	// e.g. files in archives.
+	maxBufferedSize = 32 << 20
+	// maxBufferedFiles is the maximum number of files read into memory at once. Files which aren't seekable are
+	// streamed without byte ranges while the others are being served.
+	maxBufferedFiles = 4
+)
+
+func GetImageHandler(
+	c catalog.Catalog, src source.Source, thumbs *thumbnail.Cache, buffers bufferSlots, logger *log.Logger,
+) operations.GetImageHandlerFunc {
+	return func(params operations.GetImageParams, principal interface{}) middleware.Responder {
+		res, code, err := openContent(params.HTTPRequest, c, src, thumbs, buffers, params.ID, params.W, logger)
+		if err != nil {
+			return operations.NewGetImageDefault(code).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		}
+		res.cacheControl = cacheControl(principal)
+		return res
+	}
+}
+
+func HeadImageHandler(
+	c catalog.Catalog, src source.Source, thumbs *thumbnail.Cache, buffers bufferSlots, logger *log.Logger,
+) operations.HeadImageHandlerFunc {
+	return func(params operations.HeadImageParams, principal interface{}) middleware.Responder {
+		res, code, err := openContent(params.HTTPRequest, c, src, thumbs, buffers, params.ID, params.W, logger)
+		if err != nil {
+			return operations.NewHeadImageDefault(code).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		}
+		res.cacheControl = cacheControl(principal)
+		return res
+	}
+}
+
+// openContent opens the indexed image file of the given ID, or its thumbnail if width is given, and returns a responder
+// serving it. It also returns a status code when it fails.
+//
+// ETags are the digests of the files stored in the index, and thumbnails have the digests and their widths, so that
+// files aren't read to compute them. Files modified after being indexed are hashed instead if they are seekable or
+// read into memory; they are always hashed for thumbnails, which are keyed by the digests.
+func openContent(
+	req *http.Request, c catalog.Catalog, src source.Source, thumbs *thumbnail.Cache, buffers bufferSlots, id string,
+	width *int64, logger *log.Logger,
+) (*imageContent, int, error) {
+	// only indexed images are served so that IDs never refer to other files, e.g. "../config.yaml".
+	img, err := c.Get(req.Context(), id)
+	if err != nil {
+		logger.Printf("Failed to look up the requested image: %v", err)
+		return nil, http.StatusInternalServerError, err
+	} else if img == nil {
+		logger.Printf("Requested image isn't indexed: %v", id)
+		return nil, http.StatusNotFound, fmt.Errorf("image %v is not found", id)
+	}
+
+	f, info, err := openImage(src, id)
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrInvalid) {
+		logger.Printf("Requested file doesn't exist: %v", err)
+		return nil, http.StatusNotFound, err
+	} else if err != nil {
+		logger.Printf("Failed to open the requested file: %v", err)
+		return nil, http.StatusInternalServerError, err
+	}
+
+	res := &imageContent{
+		req:         req,
+		file:        f,
+		contentType: mime.TypeByExtension(path.Ext(id)),
+		modTime:     info.ModTime(),
+		logger:      logger,
+	}
+	// creation times are stored in seconds.
+	digest := ""
+	if img.Digest != "" && img.CreationTime.Equal(info.ModTime().Truncate(time.Second)) {
+		digest = img.Digest
+	}
+	if width != nil {
+		w := thumbnail.Width(int(*width))
+		res.closeFile()
+		if digest == "" {
+			// thumbnails are keyed by digests, which are computed if the file has been modified since indexed.
+			file, err := userdata.FileOf(src, id)
+			if err != nil {
+				logger.Printf("Failed to compute the digest of %v: %v", id, err)
+				return nil, http.StatusInternalServerError, err
+			}
+			digest = file.Fingerprint
+		}
+		res.file, res.contentType, err = thumbs.Open(src, id, digest, w)
+		if err != nil {
+			logger.Printf("Failed to create a thumbnail of %v: %v", id, err)
+			return nil, http.StatusInternalServerError, err
+		}
+		digest = fmt.Sprintf("%v-%v", digest, w)
+	}
+	if digest != "" {
+		res.etag = fmt.Sprintf(`"%v"`, digest)
+	}
+
+	if rs, ok := res.file.(io.ReadSeeker); ok {
+		res.content = rs
+	} else if err = res.buffer(buffers); err != nil {
+		res.closeFile()
+		logger.Printf("Failed to read the requested file: %v", err)
+		return nil, http.StatusInternalServerError, err
+	}
+
+	if res.etag == "" && res.content != nil {
+		if res.etag, err = hashContent(res.content); err != nil {
+			res.closeFile()
+			logger.Printf("Failed to compute the ETag of %v: %v", id, err)
+			return nil, http.StatusInternalServerError, err
+		}
+	}
+	return res, 0, nil
+}
+
+// openImage opens the image file of the given name in the source.
+func openImage(src source.Source, name string) (fs.File, fs.FileInfo, error) {
+	f, err := src.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, errors.Join(err, f.Close())
+	}
+	return f, info, nil
+}
+
+// hashContent returns a strong ETag computed from the hash of the given content, which is rewound after reading.
+func hashContent(content io.ReadSeeker) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, content); err != nil {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`"%x"`, h.Sum(nil)), nil
+}
+
+// cacheControl returns the value of the Cache-Control header of images served to the given principal. Images served to
+// users or by share links are cached only by their browsers so that shared caches, e.g. proxies, don't serve them to
+// others.
+func cacheControl(principal interface{}) string {
+	if p, ok := principal.(*auth.Principal); ok && (p.User != "" || p.Share != nil) {
+		return "private, " + cacheMaxAge
+	}
+	return cacheMaxAge
+}
+
+// bufferSlots limits the number of files read into memory at once.
+type bufferSlots chan struct{}
+
+func newBufferSlots(n int) bufferSlots {
+	return make(bufferSlots, n)
+}
+
+// imageContent is a responder serving an image file with conditional and range requests.
+type imageContent struct {
+	req  *http.Request
+	file fs.File
+	// content is nil if the file isn't seekable and isn't read into memory.
+	content     io.ReadSeeker
+	contentType string
+	modTime     time.Time
+	// cacheControl is the value of the Cache-Control header.
+	cacheControl string
+	// etag is empty if the file is streamed and its digest isn't known.
+	etag   string
+	logger *log.Logger
+	// release returns the slot of buffers if the file is read into memory.
+	release func()
+}
+
+// buffer reads the file into memory if it isn't larger than maxBufferedSize and a slot of the given buffers is free,
+// which is returned when the file is closed. Otherwise, the file is streamed.
+func (c *imageContent) buffer(buffers bufferSlots) error {
+	info, err := c.file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() > maxBufferedSize {
+		return nil
+	}
+	select {
+	case buffers <- struct{}{}:
+	default:
+		return nil
+	}
+	c.release = func() { <-buffers }
+
+	data, err := io.ReadAll(io.LimitReader(c.file, maxBufferedSize+1))
+	if err != nil {
+		return err
+	} else if len(data) > maxBufferedSize {
+		return errors.New("file is larger than its size")
+	}
+	c.content = bytes.NewReader(data)
+	return nil
+}
+
+// WriteResponse implements middleware.Responder.
+func (c *imageContent) WriteResponse(rw http.ResponseWriter, _ runtime.Producer) {
+	defer c.closeFile()
+
+	// the content type is otherwise negotiated from the request regardless of the file.
+	h := rw.Header()
+	if c.contentType != "" {
+		h.Set("Content-Type", c.contentType)
+	} else {
+		h.Del("Content-Type")
+	}
+	h.Set("Cache-Control", c.cacheControl)
+	if c.etag != "" {
+		h.Set("ETag", c.etag)
+	}
+
+	if c.content != nil {
+		http.ServeContent(rw, c.req, "", c.modTime, c.content)
+		return
+	}
+
+	// files which can't be seeked are served without byte ranges.
+	h.Set("Last-Modified", c.modTime.In(gmt).Format(http.TimeFormat))
+	if c.notModified() {
+		rw.WriteHeader(http.StatusNotModified)
+		return
+	}
+	rw.WriteHeader(http.StatusOK)
+	if c.req.Method == http.MethodHead {
+		return
+	}
+	if _, err := io.Copy(rw, c.file); err != nil {
+		c.logger.Printf("Failed to transfer a file: %v", err)
+	}
+}
+
+// notModified returns true if the client has the file, which is checked by the ETag if the client sends one, and by
+// the modification time otherwise.
+func (c *imageContent) notModified() bool {
+	if match := c.req.Header.Get("If-None-Match"); match != "" {
+		if c.etag == "" {
+			return false
+		}
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == c.etag {
+				return true
+			}
+		}
+		return false
+	}
+	t, err := http.ParseTime(c.req.Header.Get("If-Modified-Since"))
+	return err == nil && !c.modTime.Truncate(time.Second).After(t)
+}
+
+func (c *imageContent) closeFile() {
+	if err := c.file.Close(); err != nil {
+		c.logger.Printf("Failed to close a file: %v", err)
+	}
+	if c.release != nil {
+		c.release()
+		c.release = nil
+	}
+}
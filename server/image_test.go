@@ -0,0 +1,313 @@
+// image_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/archive"
+	"github.com/jkawamoto/sd-image-viewer/auth"
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/image"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+	"github.com/jkawamoto/sd-image-viewer/source"
+	"github.com/jkawamoto/sd-image-viewer/thumbnail"
+	"github.com/jkawamoto/sd-image-viewer/trash"
+	"github.com/jkawamoto/sd-image-viewer/userdata"
+)
+
+const (
+	testImage  = "image content"
+	testSecret = "secret content"
+)
+
+// newTestServer starts a server of a library which has indexed images a.png and b.png. The directory containing the
+// library also has secret.txt, and the library has a symbolic link to it. Authentication is disabled until users are
+// added to the returned store.
+func newTestServer(t *testing.T) (*httptest.Server, *auth.Store) {
+	t.Helper()
+
+	root := t.TempDir()
+	lib := filepath.Join(root, "library")
+	if err := os.MkdirAll(filepath.Join(lib, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.png", "b.png"} {
+		if err := os.WriteFile(filepath.Join(lib, name), []byte(testImage), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "secret.txt"), []byte(testSecret), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "secret.txt"), filepath.Join(lib, "link.png")); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := catalog.NewBleveMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	err = c.Index(context.Background(), map[string]catalog.Document{
+		"a.png": &image.Image{Prompt: "a cat", CreationTime: time.Now(), Hash: "0000000000000000"},
+		"b.png": &image.Image{Prompt: "a dog", CreationTime: time.Now(), Hash: "0000000000000001"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := log.New(io.Discard, "", 0)
+	thumbs, err := thumbnail.Open(t.TempDir(), 1<<20, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := thumbs.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	users, err := auth.OpenStore(filepath.Join(root, "users"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := userdata.Open(filepath.Join(root, "userdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := data.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	s, err := NewServer("localhost", 0, c, source.Archives(source.Dir(lib)), thumbs, users, data, trash.New(map[string]string{"": lib}), logger,
+		logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s.GetHandler())
+	t.Cleanup(ts.Close)
+	return ts, users
+}
+
+func TestImagePathTraversal(t *testing.T) {
+	ts, _ := newTestServer(t)
+
+	res, err := http.Get(ts.URL + "/api/v1/image/a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(res.Body)
+	if err = errors.Join(err, res.Body.Close()); err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK || string(body) != testImage {
+		t.Fatalf("expect the indexed image is served, got %v: %q", res.StatusCode, body)
+	}
+
+	payloads := []string{
+		"../secret.txt",
+		"..%2Fsecret.txt",
+		"%2E%2E%2Fsecret.txt",
+		"%2e%2e%2fsecret.txt",
+		"%252E%252E%252Fsecret.txt",
+		"..%5Csecret.txt",
+		"sub%2F..%2F..%2Fsecret.txt",
+		".%2F..%2Fsecret.txt",
+		"..%2F..%2F..%2F..%2F..%2F..%2Fetc%2Fpasswd",
+		"%2Fetc%2Fpasswd",
+		"%2F%2Fetc%2Fpasswd",
+		"a.png%2F..%2F..%2Fsecret.txt",
+		"a.png%00..%2Fsecret.txt",
+		"link.png",
+		"link.png?w=128",
+		"a.zip!%2F..%2F..%2Fsecret.txt",
+	}
+	for _, p := range payloads {
+		for _, method := range []string{http.MethodGet, http.MethodHead} {
+			t.Run(method+" "+p, func(t *testing.T) {
+				req, err := http.NewRequest(method, ts.URL+"/api/v1/image/"+p, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				res, err := http.DefaultClient.Do(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body, err := io.ReadAll(res.Body)
+				if err = errors.Join(err, res.Body.Close()); err != nil {
+					t.Fatal(err)
+				}
+
+				if res.StatusCode != http.StatusNotFound {
+					t.Errorf("expect %v, got %v", http.StatusNotFound, res.StatusCode)
+				}
+				if strings.Contains(string(body), testSecret) {
+					t.Errorf("expect the secret isn't served, got %q", body)
+				}
+			})
+		}
+	}
+}
+
+func TestGetImage(t *testing.T) {
+	lib := t.TempDir()
+	img, _ := newTextPNG(t, "a cat\nSteps: 20, Sampler: Euler a")
+	if err := os.WriteFile(filepath.Join(lib, "a.png"), img, 0644); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("b.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Write(img); err != nil {
+		t.Fatal(err)
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(filepath.Join(lib, "images.zip"), buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	src := source.Archives(source.Dir(lib))
+	entry := archive.Join("images.zip", "b.png")
+	docs := make(map[string]catalog.Document)
+	for _, id := range []string{"a.png", entry} {
+		doc, err := image.ParseImageFile(src, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		docs[id] = doc
+	}
+	digest := docs["a.png"].(*image.Image).Digest
+
+	c, err := catalog.NewBleveMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	if err = c.Index(context.Background(), docs); err != nil {
+		t.Fatal(err)
+	}
+
+	logger := log.New(io.Discard, "", 0)
+	thumbs, err := thumbnail.Open(t.TempDir(), 1<<20, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := thumbs.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	buffers := newBufferSlots(1)
+	handler := GetImageHandler(c, src, thumbs, buffers, logger)
+
+	get := func(t *testing.T, id string, width int64, header http.Header) *httptest.ResponseRecorder {
+		t.Helper()
+		params := operations.NewGetImageParams()
+		params.HTTPRequest = httptest.NewRequest(http.MethodGet, "/", nil)
+		params.HTTPRequest.Header = header
+		params.ID = id
+		if width != 0 {
+			params.W = &width
+		}
+		rec := httptest.NewRecorder()
+		handler(params, nil).WriteResponse(rec, nil)
+		return rec
+	}
+
+	t.Run("digest", func(t *testing.T) {
+		res := get(t, "a.png", 0, http.Header{})
+		if etag := res.Header().Get("ETag"); etag != `"`+digest+`"` {
+			t.Errorf("expect the digest, got %v", etag)
+		}
+		res = get(t, "a.png", 0, http.Header{"If-None-Match": {`"` + digest + `"`}})
+		if res.Code != http.StatusNotModified {
+			t.Errorf("expect %v, got %v", http.StatusNotModified, res.Code)
+		}
+	})
+
+	t.Run("thumbnail", func(t *testing.T) {
+		res := get(t, "a.png", 128, http.Header{})
+		if etag := res.Header().Get("ETag"); etag != `"`+digest+`-128"` {
+			t.Errorf("expect the digest and the width, got %v", etag)
+		}
+	})
+
+	t.Run("buffered", func(t *testing.T) {
+		res := get(t, entry, 0, http.Header{"Range": {"bytes=0-3"}})
+		if res.Code != http.StatusPartialContent || res.Body.String() != string(img[:4]) {
+			t.Errorf("expect a range of the entry, got %v: %q", res.Code, res.Body.String())
+		}
+		if len(buffers) != 0 {
+			t.Error("expect the buffer is released")
+		}
+	})
+
+	t.Run("streamed", func(t *testing.T) {
+		// the entry is streamed while all the buffers are used.
+		buffers <- struct{}{}
+		defer func() { <-buffers }()
+
+		res := get(t, entry, 0, http.Header{"Range": {"bytes=0-3"}})
+		if res.Code != http.StatusOK || !bytes.Equal(res.Body.Bytes(), img) {
+			t.Errorf("expect the whole entry, got %v: %q", res.Code, res.Body.String())
+		}
+		etag := res.Header().Get("ETag")
+		if etag != `"`+docs[entry].(*image.Image).Digest+`"` {
+			t.Errorf("expect the digest, got %v", etag)
+		}
+		res = get(t, entry, 0, http.Header{"If-None-Match": {etag}})
+		if res.Code != http.StatusNotModified {
+			t.Errorf("expect %v, got %v", http.StatusNotModified, res.Code)
+		}
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		modified := append([]byte{}, img...)
+		modified[len(modified)-1] ^= 0xff
+		if err := os.WriteFile(filepath.Join(lib, "a.png"), modified, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(filepath.Join(lib, "a.png"), time.Now(), time.Now().Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		res := get(t, "a.png", 0, http.Header{})
+		if etag := res.Header().Get("ETag"); etag == "" || etag == `"`+digest+`"` {
+			t.Errorf("expect the ETag of the modified file, got %v", etag)
+		}
+	})
+}
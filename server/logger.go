@@ -23,6 +23,11 @@ func (w *responseWriter) WriteHeader(code int) {
 	w.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap returns the original response writer so that http.ResponseController can control it.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 func withLogger(h http.Handler, logger *log.Logger) http.HandlerFunc {
 	return func(res http.ResponseWriter, req *http.Request) {
 		w := &responseWriter{
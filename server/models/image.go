@@ -20,6 +20,9 @@ import (
 // swagger:model Image
 type Image struct {
 
+	// annotation
+	Annotation *Annotation `json:"annotation,omitempty"`
+
 	// checkpoint
 	Checkpoint string `json:"checkpoint,omitempty"`
 
@@ -27,6 +30,9 @@ type Image struct {
 	// Format: date-time
 	CreationTime strfmt.DateTime `json:"creation-time,omitempty"`
 
+	// The perceptual hash of 64 bits in hexadecimal, which is omitted if the pixels of the image couldn't be decoded.
+	Hash string `json:"hash,omitempty"`
+
 	// ID of the image file.
 	// Required: true
 	ID *string `json:"id"`
@@ -34,6 +40,9 @@ type Image struct {
 	// negative prompt
 	NegativePrompt string `json:"negative-prompt,omitempty"`
 
+	// The raw text of generation parameters.
+	Parameters string `json:"parameters,omitempty"`
+
 	// pixel
 	Pixel int64 `json:"pixel,omitempty"`
 
@@ -49,6 +58,9 @@ func (m *Image) UnmarshalJSON(data []byte) error {
 	// stage 1, bind the properties
 	var stage1 struct {
 
+		// annotation
+		Annotation *Annotation `json:"annotation,omitempty"`
+
 		// checkpoint
 		Checkpoint string `json:"checkpoint,omitempty"`
 
@@ -56,6 +68,9 @@ func (m *Image) UnmarshalJSON(data []byte) error {
 		// Format: date-time
 		CreationTime strfmt.DateTime `json:"creation-time,omitempty"`
 
+		// The perceptual hash of 64 bits in hexadecimal, which is omitted if the pixels of the image couldn't be decoded.
+		Hash string `json:"hash,omitempty"`
+
 		// ID of the image file.
 		// Required: true
 		ID *string `json:"id"`
@@ -63,6 +78,9 @@ func (m *Image) UnmarshalJSON(data []byte) error {
 		// negative prompt
 		NegativePrompt string `json:"negative-prompt,omitempty"`
 
+		// The raw text of generation parameters.
+		Parameters string `json:"parameters,omitempty"`
+
 		// pixel
 		Pixel int64 `json:"pixel,omitempty"`
 
@@ -74,10 +92,13 @@ func (m *Image) UnmarshalJSON(data []byte) error {
 	}
 	var rcv Image
 
+	rcv.Annotation = stage1.Annotation
 	rcv.Checkpoint = stage1.Checkpoint
 	rcv.CreationTime = stage1.CreationTime
+	rcv.Hash = stage1.Hash
 	rcv.ID = stage1.ID
 	rcv.NegativePrompt = stage1.NegativePrompt
+	rcv.Parameters = stage1.Parameters
 	rcv.Pixel = stage1.Pixel
 	rcv.Prompt = stage1.Prompt
 	*m = rcv
@@ -88,10 +109,13 @@ func (m *Image) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	delete(stage2, "annotation")
 	delete(stage2, "checkpoint")
 	delete(stage2, "creation-time")
+	delete(stage2, "hash")
 	delete(stage2, "id")
 	delete(stage2, "negative-prompt")
+	delete(stage2, "parameters")
 	delete(stage2, "pixel")
 	delete(stage2, "prompt")
 	// stage 3, add additional properties values
@@ -114,6 +138,9 @@ func (m *Image) UnmarshalJSON(data []byte) error {
 func (m Image) MarshalJSON() ([]byte, error) {
 	var stage1 struct {
 
+		// annotation
+		Annotation *Annotation `json:"annotation,omitempty"`
+
 		// checkpoint
 		Checkpoint string `json:"checkpoint,omitempty"`
 
@@ -121,6 +148,9 @@ func (m Image) MarshalJSON() ([]byte, error) {
 		// Format: date-time
 		CreationTime strfmt.DateTime `json:"creation-time,omitempty"`
 
+		// The perceptual hash of 64 bits in hexadecimal, which is omitted if the pixels of the image couldn't be decoded.
+		Hash string `json:"hash,omitempty"`
+
 		// ID of the image file.
 		// Required: true
 		ID *string `json:"id"`
@@ -128,6 +158,9 @@ func (m Image) MarshalJSON() ([]byte, error) {
 		// negative prompt
 		NegativePrompt string `json:"negative-prompt,omitempty"`
 
+		// The raw text of generation parameters.
+		Parameters string `json:"parameters,omitempty"`
+
 		// pixel
 		Pixel int64 `json:"pixel,omitempty"`
 
@@ -135,10 +168,13 @@ func (m Image) MarshalJSON() ([]byte, error) {
 		Prompt string `json:"prompt,omitempty"`
 	}
 
+	stage1.Annotation = m.Annotation
 	stage1.Checkpoint = m.Checkpoint
 	stage1.CreationTime = m.CreationTime
+	stage1.Hash = m.Hash
 	stage1.ID = m.ID
 	stage1.NegativePrompt = m.NegativePrompt
+	stage1.Parameters = m.Parameters
 	stage1.Pixel = m.Pixel
 	stage1.Prompt = m.Prompt
 
@@ -170,6 +206,10 @@ func (m Image) MarshalJSON() ([]byte, error) {
 func (m *Image) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAnnotation(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateCreationTime(formats); err != nil {
 		res = append(res, err)
 	}
@@ -184,6 +224,25 @@ func (m *Image) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Image) validateAnnotation(formats strfmt.Registry) error {
+	if swag.IsZero(m.Annotation) { // not required
+		return nil
+	}
+
+	if m.Annotation != nil {
+		if err := m.Annotation.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("annotation")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("annotation")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Image) validateCreationTime(formats strfmt.Registry) error {
 	if swag.IsZero(m.CreationTime) { // not required
 		return nil
@@ -205,8 +264,33 @@ func (m *Image) validateID(formats strfmt.Registry) error {
 	return nil
 }
 
-// ContextValidate validates this image based on context it is used
+// ContextValidate validate this image based on the context it is used
 func (m *Image) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateAnnotation(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Image) contextValidateAnnotation(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Annotation != nil {
+		if err := m.Annotation.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("annotation")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("annotation")
+			}
+			return err
+		}
+	}
+
 	return nil
 }
 
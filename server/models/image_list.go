@@ -19,6 +19,9 @@ import (
 // swagger:model ImageList
 type ImageList struct {
 
+	// facets
+	Facets *Facets `json:"facets,omitempty"`
+
 	// items
 	Items []*Image `json:"items"`
 
@@ -30,6 +33,10 @@ type ImageList struct {
 func (m *ImageList) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateFacets(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateItems(formats); err != nil {
 		res = append(res, err)
 	}
@@ -44,6 +51,25 @@ func (m *ImageList) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *ImageList) validateFacets(formats strfmt.Registry) error {
+	if swag.IsZero(m.Facets) { // not required
+		return nil
+	}
+
+	if m.Facets != nil {
+		if err := m.Facets.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("facets")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("facets")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *ImageList) validateItems(formats strfmt.Registry) error {
 	if swag.IsZero(m.Items) { // not required
 		return nil
@@ -93,6 +119,10 @@ func (m *ImageList) validateMetadata(formats strfmt.Registry) error {
 func (m *ImageList) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidateFacets(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateItems(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -107,6 +137,22 @@ func (m *ImageList) ContextValidate(ctx context.Context, formats strfmt.Registry
 	return nil
 }
 
+func (m *ImageList) contextValidateFacets(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Facets != nil {
+		if err := m.Facets.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("facets")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("facets")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *ImageList) contextValidateItems(ctx context.Context, formats strfmt.Registry) error {
 
 	for i := 0; i < len(m.Items); i++ {
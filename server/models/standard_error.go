@@ -22,6 +22,9 @@ type StandardError struct {
 	// The error message.
 	// Required: true
 	Message *string `json:"message"`
+
+	// The 1-based position of the problem in the query string, which is given for invalid query strings.
+	Position int64 `json:"position,omitempty"`
 }
 
 // Validate validates this standard error
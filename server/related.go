@@ -0,0 +1,57 @@
+// related.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+)
+
+func GetRelatedImagesHandler(c catalog.Catalog, logger *log.Logger) operations.GetRelatedImagesHandlerFunc {
+	return func(params operations.GetRelatedImagesParams, _ interface{}) middleware.Responder {
+		page := int(swag.Int64Value(params.Page))
+		limit := defaultLimit
+		if params.Limit != nil {
+			limit = int(swag.Int64Value(params.Limit))
+		}
+
+		res, err := c.Related(params.HTTPRequest.Context(), params.ID, limit*page, limit)
+		if err != nil {
+			logger.Printf("Failed to search related images: %v", err)
+			return operations.NewGetRelatedImagesDefault(http.StatusInternalServerError).WithPayload(
+				&models.StandardError{Message: swag.String(err.Error())},
+			)
+		} else if res == nil {
+			return operations.NewGetRelatedImagesDefault(http.StatusNotFound).WithPayload(&models.StandardError{
+				Message: swag.String(fmt.Sprintf("image %v is not found", params.ID)),
+			})
+		}
+
+		items := make([]*models.Image, len(res.Hits))
+		for i, v := range res.Hits {
+			items[i] = toImage(v.ID, v.Image)
+		}
+		return operations.NewGetRelatedImagesOK().WithPayload(&models.ImageList{
+			Items: items,
+			Metadata: &models.Metadata{
+				CurrentPage: swag.Int64(int64(page)),
+				TotalItems:  swag.Int64(int64(res.Total)),
+				TotalPages:  totalPages(res.Total, limit),
+			},
+		})
+	}
+}
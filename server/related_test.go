@@ -0,0 +1,36 @@
+// related_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+)
+
+func TestGetRelatedImages(t *testing.T) {
+	ts, _ := newTestServer(t)
+	client := &testClient{t: t, url: ts.URL}
+
+	// the test images share no terms but "a", which is a stop word.
+	var res models.ImageList
+	if err := json.Unmarshal([]byte(client.expect(http.MethodGet, "/images/a.png/related", http.StatusOK)), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 0 || *res.Metadata.TotalItems != 0 || *res.Metadata.CurrentPage != 0 {
+		t.Errorf("expect no related images, got %v", res.Items)
+	}
+	if *res.Metadata.TotalPages != 0 {
+		t.Errorf("expect no pages, got %v", *res.Metadata.TotalPages)
+	}
+
+	client.expect(http.MethodGet, "/images/missing.png/related", http.StatusNotFound)
+}
@@ -37,13 +37,38 @@ func configureAPI(api *operations.SdImageViewerAPI) http.Handler {
 
 	api.JSONProducer = runtime.JSONProducer()
 
+	// Applies when the "Cookie" header is set
+	if api.SessionAuth == nil {
+		api.SessionAuth = func(token string) (interface{}, error) {
+			return nil, errors.NotImplemented("api key auth (session) Cookie from header param [Cookie] has not yet been implemented")
+		}
+	}
+	// Applies when the "share" query is set
+	if api.ShareAuth == nil {
+		api.ShareAuth = func(token string) (interface{}, error) {
+			return nil, errors.NotImplemented("api key auth (share) share from query param [share] has not yet been implemented")
+		}
+	}
+	// Applies when the "Authorization" header is set
+	if api.TokenAuth == nil {
+		api.TokenAuth = func(token string) (interface{}, error) {
+			return nil, errors.NotImplemented("api key auth (token) Authorization from header param [Authorization] has not yet been implemented")
+		}
+	}
+
+	// Set your custom authorizer if needed. Default one is security.Authorized()
+	// Expected interface runtime.Authorizer
+	//
+	// Example:
+	// api.APIAuthorizer = security.Authorized()
+
 	if api.GetImageHandler == nil {
-		api.GetImageHandler = operations.GetImageHandlerFunc(func(params operations.GetImageParams) middleware.Responder {
+		api.GetImageHandler = operations.GetImageHandlerFunc(func(params operations.GetImageParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation operations.GetImage has not yet been implemented")
 		})
 	}
 	if api.GetImagesHandler == nil {
-		api.GetImagesHandler = operations.GetImagesHandlerFunc(func(params operations.GetImagesParams) middleware.Responder {
+		api.GetImagesHandler = operations.GetImagesHandlerFunc(func(params operations.GetImagesParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation operations.GetImages has not yet been implemented")
 		})
 	}
@@ -55,9 +80,15 @@ func configureAPI(api *operations.SdImageViewerAPI) http.Handler {
 	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
 }
 
+// GetCertificate returns certificates of HTTPS connections if it is not nil, which allows replacing certificates
+// without restarting the server.
+var GetCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)
+
 // The TLS configuration before HTTPS server starts.
 func configureTLS(tlsConfig *tls.Config) {
-	// Make all necessary changes to the TLS configuration here.
+	if GetCertificate != nil {
+		tlsConfig.GetCertificate = GetCertificate
+	}
 }
 
 // As soon as server is initialized but not run yet, this function will be called.
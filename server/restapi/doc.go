@@ -12,8 +12,13 @@
 //	  - application/json
 //
 //	Produces:
-//	  - image/png
+//	  - application/zip
 //	  - application/json
+//	  - application/x-ndjson
+//	  - text/csv
+//	  - text/plain
+//	  - image/png
+//	  - image/jpeg
 //
 // swagger:meta
 package restapi
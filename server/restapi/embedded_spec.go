@@ -58,45 +58,135 @@ func init() {
         }
       }
     },
-    "/image/{id}": {
+    "/collections": {
       "get": {
-        "description": "Get an image.",
-        "produces": [
-          "image/png",
-          "application/json"
+        "description": "List collections sorted by their names. Images in collections are only given by the detail of a collection.",
+        "operationId": "getCollections",
+        "responses": {
+          "200": {
+            "description": "A list of collections.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Collection"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Create a collection.",
+        "operationId": "createCollection",
+        "parameters": [
+          {
+            "name": "collection",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/CollectionRequest"
+            }
+          }
         ],
-        "operationId": "getImage",
+        "responses": {
+          "201": {
+            "description": "The created collection.",
+            "schema": {
+              "$ref": "#/definitions/Collection"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/collections/{id}": {
+      "get": {
+        "description": "Get a collection with IDs of its images in order.",
+        "operationId": "getCollection",
         "parameters": [
           {
             "type": "string",
-            "description": "ID of the image file.",
+            "description": "ID of the collection.",
             "name": "id",
             "in": "path",
             "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The collection.",
+            "schema": {
+              "$ref": "#/definitions/Collection"
+            }
           },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "Rename a collection, and change its description and cover image.",
+        "operationId": "updateCollection",
+        "parameters": [
           {
             "type": "string",
-            "name": "If-Modified-Since",
-            "in": "header"
+            "description": "ID of the collection.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "collection",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/CollectionRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "Requested image file.",
+            "description": "The updated collection.",
             "schema": {
-              "type": "file"
-            },
-            "headers": {
-              "Cache-Control": {
-                "type": "string"
-              },
-              "Last-Modified": {
-                "type": "string"
-              }
+              "$ref": "#/definitions/Collection"
             }
           },
-          "304": {
-            "description": "Requested image is not modified."
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Delete a collection. Images in the collection are not deleted.",
+        "operationId": "deleteCollection",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the collection.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Deleted."
           },
           "default": {
             "description": "Error Response",
@@ -107,10 +197,124 @@ func init() {
         }
       }
     },
-    "/images": {
-      "get": {
-        "description": "List images.",
-        "operationId": "getImages",
+    "/collections/{id}/images": {
+      "put": {
+        "description": "Replace images in a collection with the given ones in the given order, e.g. to reorder them.",
+        "operationId": "setCollectionImages",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the collection.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "images",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/CollectionImages"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The updated collection.",
+            "schema": {
+              "$ref": "#/definitions/Collection"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Add images to a collection at the given position, or at the end if it isn't given. Images already in the collection are moved to the position.",
+        "operationId": "addCollectionImages",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the collection.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "images",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/CollectionImages"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The updated collection.",
+            "schema": {
+              "$ref": "#/definitions/Collection"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Remove images from a collection.",
+        "operationId": "removeCollectionImages",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the collection.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "IDs of the removed images.",
+            "name": "image",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The updated collection.",
+            "schema": {
+              "$ref": "#/definitions/Collection"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/downloads": {
+      "post": {
+        "description": "Download images as a ZIP archive streamed on the fly, which has a manifest of their prompts and parameters. Images are given by their IDs in the body, or by the same search parameters as GET /images otherwise.",
+        "produces": [
+          "application/zip",
+          "application/json"
+        ],
+        "operationId": "downloadImages",
         "parameters": [
           {
             "type": "string",
@@ -118,6 +322,12 @@ func init() {
             "name": "query",
             "in": "query"
           },
+          {
+            "type": "string",
+            "description": "Query string searching all indexed fields, e.g. prompt:\"red dress\" -negative:blurry steps:\u003e30 rating:\u003e=4. Annotations are searched with favorite:true, rating, tag and note. An invalid query string results in 400 with the position of the problem.",
+            "name": "q",
+            "in": "query"
+          },
           {
             "enum": [
               "small",
@@ -150,15 +360,33 @@ func init() {
             "in": "query"
           },
           {
-            "type": "integer",
-            "description": "The number of items one page has at most.",
-            "name": "limit",
+            "type": "boolean",
+            "description": "Retrieving only favourite images if true.",
+            "name": "favorite",
             "in": "query"
           },
           {
+            "maximum": 5,
+            "minimum": 1,
             "type": "integer",
-            "description": "Requesting page number.",
-            "name": "page",
+            "description": "Retrieving images rated the given value or higher.",
+            "name": "minRating",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Retrieving images having all the given tags.",
+            "name": "tag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images in the collection of the given ID.",
+            "name": "collection",
             "in": "query"
           },
           {
@@ -170,13 +398,32 @@ func init() {
             "default": "desc",
             "name": "order",
             "in": "query"
+          },
+          {
+            "enum": [
+              "created",
+              "rating",
+              "position"
+            ],
+            "type": "string",
+            "default": "created",
+            "description": "The sort key. Images having the same rating are sorted by their creation times. Position sorts images in the order of the collection regardless of the order parameter, and requires the collection parameter.",
+            "name": "sort",
+            "in": "query"
+          },
+          {
+            "name": "options",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/DownloadRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "A list of images.",
+            "description": "A ZIP archive of the images.",
             "schema": {
-              "$ref": "#/definitions/ImageList"
+              "type": "file"
             }
           },
           "default": {
@@ -187,169 +434,41 @@ func init() {
           }
         }
       }
-    }
-  },
-  "definitions": {
-    "Image": {
-      "required": [
-        "id"
-      ],
-      "properties": {
-        "checkpoint": {
-          "type": "string"
-        },
-        "creation-time": {
-          "type": "string",
-          "format": "date-time"
-        },
-        "id": {
-          "description": "ID of the image file.",
-          "type": "string"
-        },
-        "negative-prompt": {
-          "type": "string"
-        },
-        "pixel": {
-          "type": "integer"
-        },
-        "prompt": {
-          "type": "string"
-        }
-      },
-      "additionalProperties": true
-    },
-    "ImageList": {
-      "properties": {
-        "items": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/Image"
-          }
-        },
-        "metadata": {
-          "$ref": "#/definitions/Metadata"
-        }
-      }
     },
-    "Metadata": {
-      "required": [
-        "currentPage",
-        "totalPages",
-        "totalItems"
-      ],
-      "properties": {
-        "currentPage": {
-          "description": "The the current page you are at.",
-          "type": "integer",
-          "example": 2
-        },
-        "totalItems": {
-          "description": "The total number of items available.",
-          "type": "integer",
-          "format": "int",
-          "example": 46
-        },
-        "totalPages": {
-          "description": "The total number of pages.",
-          "type": "integer",
-          "example": 16
-        }
-      }
-    },
-    "StandardError": {
-      "required": [
-        "message"
-      ],
-      "properties": {
-        "message": {
-          "description": "The error message.",
-          "type": "string"
-        }
-      }
-    }
-  }
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "consumes": [
-    "application/json"
-  ],
-  "produces": [
-    "application/json"
-  ],
-  "schemes": [
-    "http"
-  ],
-  "swagger": "2.0",
-  "info": {
-    "title": "SD Image Viewer API",
-    "version": "2023-04-27"
-  },
-  "host": "localhost:8080",
-  "basePath": "/api/v1",
-  "paths": {
-    "/checkpoints": {
-      "get": {
-        "description": "Get a list of checkpoints.",
-        "operationId": "getCheckpoints",
-        "responses": {
-          "200": {
-            "description": "A list of checkpoint names.",
-            "schema": {
-              "type": "array",
-              "items": {
-                "type": "string"
-              }
-            }
-          },
-          "default": {
-            "description": "Error Response",
-            "schema": {
-              "$ref": "#/definitions/StandardError"
-            }
-          }
-        }
-      }
-    },
-    "/image/{id}": {
+    "/duplicates": {
       "get": {
-        "description": "Get an image.",
-        "produces": [
-          "application/json",
-          "image/png"
-        ],
-        "operationId": "getImage",
+        "description": "List groups of exact and near duplicates, which are images whose perceptual hashes are within the given Hamming distance from the hash of the oldest image in each group. Larger groups come first, and images in each group are sorted in ascending order of distances from the oldest one, which comes first.",
+        "operationId": "getDuplicates",
         "parameters": [
           {
-            "type": "string",
-            "description": "ID of the image file.",
-            "name": "id",
-            "in": "path",
-            "required": true
+            "maximum": 7,
+            "type": "integer",
+            "default": 4,
+            "description": "The maximum Hamming distance between the 64-bit hashes. Zero finds only exact duplicates.",
+            "name": "distance",
+            "in": "query"
           },
           {
-            "type": "string",
-            "name": "If-Modified-Since",
-            "in": "header"
+            "minimum": 1,
+            "type": "integer",
+            "description": "The number of groups one page has at most.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Requesting page number.",
+            "name": "page",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "Requested image file.",
+            "description": "A list of groups of duplicates.",
             "schema": {
-              "type": "file"
-            },
-            "headers": {
-              "Cache-Control": {
-                "type": "string"
-              },
-              "Last-Modified": {
-                "type": "string"
-              }
+              "$ref": "#/definitions/DuplicateList"
             }
           },
-          "304": {
-            "description": "Requested image is not modified."
-          },
           "default": {
             "description": "Error Response",
             "schema": {
@@ -359,10 +478,16 @@ func init() {
         }
       }
     },
-    "/images": {
+    "/exports": {
       "get": {
-        "description": "List images.",
-        "operationId": "getImages",
+        "description": "Export metadata of images matching the same search parameters as GET /images, which is streamed while searching images page by page. JSON Lines have the full metadata of each image, CSV has the given columns, and prompts are lines read by the \"Prompts from file or textbox\" script of AUTOMATIC1111's web UI.",
+        "produces": [
+          "application/x-ndjson",
+          "text/csv",
+          "text/plain",
+          "application/json"
+        ],
+        "operationId": "exportImages",
         "parameters": [
           {
             "type": "string",
@@ -370,6 +495,12 @@ func init() {
             "name": "query",
             "in": "query"
           },
+          {
+            "type": "string",
+            "description": "Query string searching all indexed fields, e.g. prompt:\"red dress\" -negative:blurry steps:\u003e30 rating:\u003e=4. Annotations are searched with favorite:true, rating, tag and note. An invalid query string results in 400 with the position of the problem.",
+            "name": "q",
+            "in": "query"
+          },
           {
             "enum": [
               "small",
@@ -402,15 +533,33 @@ func init() {
             "in": "query"
           },
           {
-            "type": "integer",
-            "description": "The number of items one page has at most.",
-            "name": "limit",
+            "type": "boolean",
+            "description": "Retrieving only favourite images if true.",
+            "name": "favorite",
             "in": "query"
           },
           {
+            "maximum": 5,
+            "minimum": 1,
             "type": "integer",
-            "description": "Requesting page number.",
-            "name": "page",
+            "description": "Retrieving images rated the given value or higher.",
+            "name": "minRating",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Retrieving images having all the given tags.",
+            "name": "tag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images in the collection of the given ID.",
+            "name": "collection",
             "in": "query"
           },
           {
@@ -422,13 +571,47 @@ func init() {
             "default": "desc",
             "name": "order",
             "in": "query"
+          },
+          {
+            "enum": [
+              "created",
+              "rating",
+              "position"
+            ],
+            "type": "string",
+            "default": "created",
+            "description": "The sort key. Images having the same rating are sorted by their creation times. Position sorts images in the order of the collection regardless of the order parameter, and requires the collection parameter.",
+            "name": "sort",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "jsonl",
+              "csv",
+              "prompts"
+            ],
+            "type": "string",
+            "default": "jsonl",
+            "description": "The export format.",
+            "name": "format",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "csv",
+            "description": "Columns of CSV exports, e.g. id, prompt, negative-prompt, checkpoint, creation-time, pixel, parameters, loras, favorite, rating, tags, note, or names of parameters used in query strings such as cfg-scale. Defaults to id, prompt, negative-prompt, checkpoint, sampler, steps, cfg-scale, seed, size and creation-time.",
+            "name": "columns",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "A list of images.",
+            "description": "The exported images.",
             "schema": {
-              "$ref": "#/definitions/ImageList"
+              "type": "file"
             }
           },
           "default": {
@@ -439,75 +622,3875 @@ func init() {
           }
         }
       }
-    }
-  },
-  "definitions": {
-    "Image": {
-      "required": [
-        "id"
-      ],
-      "properties": {
-        "checkpoint": {
-          "type": "string"
-        },
-        "creation-time": {
-          "type": "string",
-          "format": "date-time"
-        },
-        "id": {
-          "description": "ID of the image file.",
-          "type": "string"
-        },
-        "negative-prompt": {
-          "type": "string"
-        },
-        "pixel": {
-          "type": "integer"
-        },
-        "prompt": {
-          "type": "string"
-        }
-      },
-      "additionalProperties": true
     },
-    "ImageList": {
-      "properties": {
-        "items": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/Image"
+    "/image/{id}": {
+      "get": {
+        "description": "Get an image. Conditional requests with ETags and modification times and byte range requests are supported as specified in RFC 7232 and RFC 7233.",
+        "produces": [
+          "image/png",
+          "image/jpeg",
+          "application/json"
+        ],
+        "operationId": "getImage",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the image file.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "Requesting a thumbnail of the given width instead of the original image, which is rounded up to 128, 256, 512 or 1024. Thumbnails are JPEG images unless the images have transparency.",
+            "name": "w",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "If-Modified-Since",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "If-None-Match",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "Requesting a byte range, e.g. bytes=0-1023. Multiple ranges result in a multipart response.",
+            "name": "Range",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "An ETag or a modification time; the whole image is returned if the image has been modified.",
+            "name": "If-Range",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "If-Unmodified-Since",
+            "in": "header"
           }
-        },
-        "metadata": {
-          "$ref": "#/definitions/Metadata"
-        }
-      }
-    },
-    "Metadata": {
-      "required": [
-        "currentPage",
-        "totalPages",
-        "totalItems"
-      ],
-      "properties": {
-        "currentPage": {
-          "description": "The the current page you are at.",
-          "type": "integer",
-          "example": 2
-        },
-        "totalItems": {
-          "description": "The total number of items available.",
-          "type": "integer",
-          "format": "int",
-          "example": 46
-        },
-        "totalPages": {
+        ],
+        "responses": {
+          "200": {
+            "description": "Requested image file.",
+            "schema": {
+              "type": "file"
+            },
+            "headers": {
+              "Accept-Ranges": {
+                "type": "string"
+              },
+              "Cache-Control": {
+                "type": "string"
+              },
+              "ETag": {
+                "type": "string",
+                "description": "A strong ETag given by the hash of the content."
+              },
+              "Last-Modified": {
+                "type": "string"
+              }
+            }
+          },
+          "206": {
+            "description": "The requested range of the image file.",
+            "schema": {
+              "type": "file"
+            },
+            "headers": {
+              "Content-Range": {
+                "type": "string"
+              },
+              "ETag": {
+                "type": "string"
+              }
+            }
+          },
+          "304": {
+            "description": "Requested image is not modified."
+          },
+          "412": {
+            "description": "The image doesn't satisfy If-Match or If-Unmodified-Since."
+          },
+          "416": {
+            "description": "The requested range is not satisfiable.",
+            "headers": {
+              "Content-Range": {
+                "type": "string"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "head": {
+        "description": "Get headers of an image, which are the same as the GET method.",
+        "produces": [
+          "image/png",
+          "image/jpeg",
+          "application/json"
+        ],
+        "operationId": "headImage",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the image file.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "Requesting headers of a thumbnail of the given width.",
+            "name": "w",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "If-Modified-Since",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "If-None-Match",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "Range",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "If-Range",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "If-Unmodified-Since",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Headers of the requested image file.",
+            "headers": {
+              "Accept-Ranges": {
+                "type": "string"
+              },
+              "Cache-Control": {
+                "type": "string"
+              },
+              "Content-Length": {
+                "type": "integer"
+              },
+              "ETag": {
+                "type": "string"
+              },
+              "Last-Modified": {
+                "type": "string"
+              }
+            }
+          },
+          "206": {
+            "description": "Headers of the requested range of the image file.",
+            "headers": {
+              "Content-Range": {
+                "type": "string"
+              }
+            }
+          },
+          "304": {
+            "description": "Requested image is not modified."
+          },
+          "412": {
+            "description": "The image doesn't satisfy If-Match or If-Unmodified-Since."
+          },
+          "416": {
+            "description": "The requested range is not satisfiable."
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/images": {
+      "get": {
+        "description": "List images.",
+        "operationId": "getImages",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Search query.",
+            "name": "query",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Query string searching all indexed fields, e.g. prompt:\"red dress\" -negative:blurry steps:\u003e30 rating:\u003e=4. Annotations are searched with favorite:true, rating, tag and note. An invalid query string results in 400 with the position of the problem.",
+            "name": "q",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "small",
+              "medium",
+              "large"
+            ],
+            "type": "string",
+            "description": "Retrieving the given sized images.",
+            "name": "size",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images that use the given checkpoint.",
+            "name": "checkpoint",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created before the given date time.",
+            "name": "before",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created after the given date time.",
+            "name": "after",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Retrieving only favourite images if true.",
+            "name": "favorite",
+            "in": "query"
+          },
+          {
+            "maximum": 5,
+            "minimum": 1,
+            "type": "integer",
+            "description": "Retrieving images rated the given value or higher.",
+            "name": "minRating",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Retrieving images having all the given tags.",
+            "name": "tag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images in the collection of the given ID.",
+            "name": "collection",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The number of items one page has at most.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Requesting page number.",
+            "name": "page",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "asc",
+              "desc"
+            ],
+            "type": "string",
+            "default": "desc",
+            "name": "order",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "created",
+              "rating",
+              "position"
+            ],
+            "type": "string",
+            "default": "created",
+            "description": "The sort key. Images having the same rating are sorted by their creation times. Position sorts images in the order of the collection regardless of the order parameter, and requires the collection parameter.",
+            "name": "sort",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "enum": [
+                "checkpoint",
+                "sampler",
+                "lora",
+                "size",
+                "created"
+              ],
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Facets counting all images matching the query, which are returned in facets of the response. The created facet is a histogram of creation dates.",
+            "name": "facets",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "day",
+              "week",
+              "month"
+            ],
+            "type": "string",
+            "default": "month",
+            "description": "The interval of periods of the created facet.",
+            "name": "interval",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "default": 10,
+            "description": "The maximum number of the most frequent terms of each facet, and the number of the latest periods of the created facet.",
+            "name": "facetSize",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A list of images.",
+            "schema": {
+              "$ref": "#/definitions/ImageList"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Move images to the trash. They are removed from the index immediately, and can be restored until they are purged. Deleting images is disabled in read-only mode. Images in S3 buckets or archives can't be deleted, which results in 501.",
+        "operationId": "deleteImages",
+        "parameters": [
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "IDs of the deleted images.",
+            "name": "image",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The items in the trash.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/TrashItem"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/images/{id}": {
+      "get": {
+        "description": "Get indexed metadata of an image with IDs of the previous and next images in the list of images matching the given search parameters.",
+        "operationId": "getImageDetail",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the image file.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Search query.",
+            "name": "query",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Query string searching all indexed fields, e.g. prompt:\"red dress\" -negative:blurry steps:\u003e30 rating:\u003e=4. Annotations are searched with favorite:true, rating, tag and note. An invalid query string results in 400 with the position of the problem.",
+            "name": "q",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "small",
+              "medium",
+              "large"
+            ],
+            "type": "string",
+            "description": "Retrieving the given sized images.",
+            "name": "size",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images that use the given checkpoint.",
+            "name": "checkpoint",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created before the given date time.",
+            "name": "before",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created after the given date time.",
+            "name": "after",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Retrieving only favourite images if true.",
+            "name": "favorite",
+            "in": "query"
+          },
+          {
+            "maximum": 5,
+            "minimum": 1,
+            "type": "integer",
+            "description": "Retrieving images rated the given value or higher.",
+            "name": "minRating",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Retrieving images having all the given tags.",
+            "name": "tag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images in the collection of the given ID.",
+            "name": "collection",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "asc",
+              "desc"
+            ],
+            "type": "string",
+            "default": "desc",
+            "name": "order",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "created",
+              "rating",
+              "position"
+            ],
+            "type": "string",
+            "default": "created",
+            "description": "The sort key. Images having the same rating are sorted by their creation times. Position sorts images in the order of the collection regardless of the order parameter, and requires the collection parameter.",
+            "name": "sort",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The image and its neighbours.",
+            "schema": {
+              "$ref": "#/definitions/ImageDetail"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Move an image to the trash. It is removed from the index immediately, and can be restored until it is purged. Deleting images is disabled in read-only mode. Images in S3 buckets or archives can't be deleted, which results in 501.",
+        "operationId": "deleteImage",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the image file.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The item in the trash.",
+            "schema": {
+              "$ref": "#/definitions/TrashItem"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/images/{id}/annotations": {
+      "put": {
+        "description": "Replace the annotation of an image, i.e. whether it is a favourite, its rating, tags and a note. An empty annotation removes it. Annotations are kept when images are indexed again or moved.",
+        "operationId": "putAnnotation",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the image file.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "annotation",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/Annotation"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The updated annotation, whose tags and note are normalized.",
+            "schema": {
+              "$ref": "#/definitions/Annotation"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/images/{id}/related": {
+      "get": {
+        "description": "List images whose prompts share terms with the prompt of an image in descending order of relevance. Terms rare in the library weigh more than common ones, and boilerplate tags such as \"masterpiece\" and \"best quality\" are ignored.",
+        "operationId": "getRelatedImages",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the image file.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The number of images one page has at most.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Requesting page number.",
+            "name": "page",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A list of related images. Facets aren't computed.",
+            "schema": {
+              "$ref": "#/definitions/ImageList"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/images/{id}/similar": {
+      "get": {
+        "description": "List images whose perceptual hashes are within the given Hamming distance from the hash of an image, e.g. those generated from the same seed or upscaled by hires passes, in ascending order of distances.",
+        "operationId": "getSimilarImages",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the image file.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "maximum": 7,
+            "type": "integer",
+            "default": 4,
+            "description": "The maximum Hamming distance between the 64-bit hashes.",
+            "name": "distance",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The similar images, which are empty if the image doesn't have a hash, i.e. its pixels couldn't be decoded.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/SimilarImage"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/index/failures": {
+      "get": {
+        "description": "List image files that failed to be indexed.",
+        "operationId": "getFailures",
+        "parameters": [
+          {
+            "enum": [
+              "no_parameters",
+              "not_supported_parameters",
+              "io",
+              "corrupt"
+            ],
+            "type": "string",
+            "description": "Retrieving failures of the given class.",
+            "name": "class",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The number of items one page has at most.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Requesting page number.",
+            "name": "page",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A list of failures.",
+            "schema": {
+              "$ref": "#/definitions/FailureList"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/login": {
+      "post": {
+        "security": [],
+        "description": "Log in with a password, and get a session cookie. Authentication is required only if users are registered with the user command.",
+        "operationId": "login",
+        "parameters": [
+          {
+            "name": "credentials",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/Credentials"
+            }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Logged in.",
+            "headers": {
+              "Set-Cookie": {
+                "type": "string"
+              }
+            }
+          },
+          "401": {
+            "description": "The user name or the password is wrong.",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "429": {
+            "description": "Logins from the client or as the user failed too many times. They are refused until Retry-After seconds pass.",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            },
+            "headers": {
+              "Retry-After": {
+                "type": "integer",
+                "description": "Seconds to wait before logging in again."
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/logout": {
+      "post": {
+        "security": [],
+        "description": "Log out, and remove the session cookie.",
+        "operationId": "logout",
+        "responses": {
+          "204": {
+            "description": "Logged out.",
+            "headers": {
+              "Set-Cookie": {
+                "type": "string"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/shares": {
+      "post": {
+        "description": "Create a signed, expiring share link of one image or one search. Share links can't create other share links.",
+        "operationId": "createShare",
+        "parameters": [
+          {
+            "name": "share",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ShareRequest"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "The created share link.",
+            "schema": {
+              "$ref": "#/definitions/Share"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/stats": {
+      "get": {
+        "description": "Get statistics of images matching the same search parameters as GET /images, i.e. images created in each period, the most used checkpoints with their images in each period and average steps and CFG scales, the most used samplers and tags of prompts, and the mix of resolutions and size classes. Results are cached until the index changes.",
+        "operationId": "getStats",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Search query.",
+            "name": "query",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Query string searching all indexed fields, e.g. prompt:\"red dress\" -negative:blurry steps:\u003e30 rating:\u003e=4. Annotations are searched with favorite:true, rating, tag and note. An invalid query string results in 400 with the position of the problem.",
+            "name": "q",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "small",
+              "medium",
+              "large"
+            ],
+            "type": "string",
+            "description": "Retrieving the given sized images.",
+            "name": "size",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images that use the given checkpoint.",
+            "name": "checkpoint",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created before the given date time.",
+            "name": "before",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created after the given date time.",
+            "name": "after",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Retrieving only favourite images if true.",
+            "name": "favorite",
+            "in": "query"
+          },
+          {
+            "maximum": 5,
+            "minimum": 1,
+            "type": "integer",
+            "description": "Retrieving images rated the given value or higher.",
+            "name": "minRating",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Retrieving images having all the given tags.",
+            "name": "tag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images in the collection of the given ID.",
+            "name": "collection",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "day",
+              "week",
+              "month"
+            ],
+            "type": "string",
+            "default": "day",
+            "description": "The interval of periods counting images.",
+            "name": "interval",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "default": 30,
+            "description": "The number of the latest periods. Zero means all periods.",
+            "name": "periods",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "default": 10,
+            "description": "The maximum number of the most frequent terms of each list, e.g. checkpoints. Zero means all.",
+            "name": "top",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Statistics of the images.",
+            "schema": {
+              "$ref": "#/definitions/Stats"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/suggest": {
+      "get": {
+        "description": "Suggest terms of a field starting with a prefix to autocomplete queries, with the numbers of images having them. If no terms start with the prefix, terms close to it in edit distance are returned as corrections.",
+        "operationId": "getSuggestions",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The prefix of terms, e.g. the word being typed. Terms of prompts are lower-case words, and prefixes of them are case-insensitive. The most frequent terms are returned without prefixes.",
+            "name": "prefix",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "prompt",
+              "checkpoint",
+              "sampler",
+              "lora"
+            ],
+            "type": "string",
+            "default": "prompt",
+            "description": "The field whose terms are suggested.",
+            "name": "field",
+            "in": "query"
+          },
+          {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "default": 10,
+            "description": "The maximum number of terms.",
+            "name": "size",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Suggested terms.",
+            "schema": {
+              "$ref": "#/definitions/Suggestions"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/trash": {
+      "get": {
+        "description": "List images in the trash from the most recently deleted one. The trash is disabled in read-only mode.",
+        "operationId": "getTrash",
+        "responses": {
+          "200": {
+            "description": "The items in the trash.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/TrashItem"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/trash/restore": {
+      "post": {
+        "description": "Restore images in the trash to their original paths, and index them again. Restoring images is disabled in read-only mode.",
+        "operationId": "restoreTrash",
+        "parameters": [
+          {
+            "name": "items",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/TrashRestore"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The restored items.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/TrashItem"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "Annotation": {
+      "properties": {
+        "favorite": {
+          "type": "boolean"
+        },
+        "note": {
+          "description": "A note of up to 4096 characters.",
+          "type": "string"
+        },
+        "rating": {
+          "description": "The rating from 1 to 5, and 0 means not rated.",
+          "type": "integer",
+          "maximum": 5
+        },
+        "tags": {
+          "description": "At most 50 tags of up to 64 characters. Duplicated and empty tags are removed.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "CheckpointStats": {
+      "required": [
+        "name",
+        "count",
+        "averageSteps",
+        "averageCfgScale",
+        "created"
+      ],
+      "properties": {
+        "averageCfgScale": {
+          "description": "The average CFG scale, which is zero if no images have it.",
+          "type": "number"
+        },
+        "averageSteps": {
+          "description": "The average sampling steps, which is zero if no images have them.",
+          "type": "number"
+        },
+        "count": {
+          "description": "The number of images generated with the checkpoint.",
+          "type": "integer"
+        },
+        "created": {
+          "description": "The numbers of images generated with the checkpoint in the same periods as created of Stats.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "name": {
+          "type": "string",
+          "example": "DreamShaper"
+        }
+      }
+    },
+    "Collection": {
+      "required": [
+        "id",
+        "name",
+        "count",
+        "created",
+        "updated"
+      ],
+      "properties": {
+        "count": {
+          "description": "The number of images in the collection.",
+          "type": "integer"
+        },
+        "cover": {
+          "description": "ID of the cover image, which is the first image unless it is given. It is empty if there are no images.",
+          "type": "string"
+        },
+        "created": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "description": {
+          "type": "string"
+        },
+        "id": {
+          "type": "string"
+        },
+        "images": {
+          "description": "IDs of images in order, which are only given by the detail of a collection.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "name": {
+          "type": "string"
+        },
+        "updated": {
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "CollectionImages": {
+      "required": [
+        "images"
+      ],
+      "properties": {
+        "images": {
+          "description": "IDs of indexed images.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "position": {
+          "description": "The 1-based position where the images are inserted, which is only used to add images. The images are appended if it isn't given.",
+          "type": "integer",
+          "minimum": 1
+        }
+      }
+    },
+    "CollectionRequest": {
+      "required": [
+        "name"
+      ],
+      "properties": {
+        "cover": {
+          "description": "ID of the cover image, which must be in the collection. The first image is used if it is empty.",
+          "type": "string"
+        },
+        "description": {
+          "description": "The description of up to 4096 characters.",
+          "type": "string"
+        },
+        "name": {
+          "description": "The name of up to 256 characters.",
+          "type": "string"
+        }
+      }
+    },
+    "Credentials": {
+      "required": [
+        "user",
+        "password"
+      ],
+      "properties": {
+        "password": {
+          "type": "string"
+        },
+        "user": {
+          "type": "string"
+        }
+      }
+    },
+    "DownloadRequest": {
+      "properties": {
+        "flatten": {
+          "description": "Store images at the root of the archive if true, where images of the same name are numbered. Otherwise, they are stored in the folder structure of the libraries.",
+          "type": "boolean"
+        },
+        "images": {
+          "description": "IDs of the downloaded images in order. The search parameters are ignored if they are given.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "manifest": {
+          "description": "The format of the manifest of prompts and parameters, which is stored as manifest.json or manifest.csv.",
+          "type": "string",
+          "default": "json",
+          "enum": [
+            "json",
+            "csv",
+            "none"
+          ]
+        },
+        "stripMetadata": {
+          "description": "Remove generation parameters and other metadata embedded in the images if true.",
+          "type": "boolean"
+        }
+      }
+    },
+    "DuplicateGroup": {
+      "required": [
+        "exact",
+        "images"
+      ],
+      "properties": {
+        "exact": {
+          "description": "True if all the images have the same hash.",
+          "type": "boolean"
+        },
+        "images": {
+          "description": "The images from the oldest one, whose distance is zero.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/SimilarImage"
+          }
+        }
+      }
+    },
+    "DuplicateList": {
+      "properties": {
+        "items": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DuplicateGroup"
+          }
+        },
+        "metadata": {
+          "$ref": "#/definitions/Metadata"
+        }
+      }
+    },
+    "Facet": {
+      "required": [
+        "term",
+        "count"
+      ],
+      "properties": {
+        "count": {
+          "description": "The number of images having the term.",
+          "type": "integer",
+          "example": 1204
+        },
+        "term": {
+          "type": "string",
+          "example": "DreamShaper"
+        }
+      }
+    },
+    "Facets": {
+      "description": "Counts of images by terms of the requested facets.",
+      "properties": {
+        "checkpoint": {
+          "description": "The most used checkpoints.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "created": {
+          "description": "Periods in chronological order, whose terms are their first dates, e.g. 2026-01-01. Periods without images are omitted.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "lora": {
+          "description": "The most used LoRAs.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "sampler": {
+          "description": "The most used samplers.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "size": {
+          "description": "Size classes, i.e. small, medium and large, from the smallest.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        }
+      }
+    },
+    "Failure": {
+      "required": [
+        "id",
+        "class"
+      ],
+      "properties": {
+        "class": {
+          "description": "The class of the error.",
+          "type": "string",
+          "enum": [
+            "no_parameters",
+            "not_supported_parameters",
+            "io",
+            "corrupt"
+          ]
+        },
+        "failed-at": {
+          "description": "The time when the file failed to be parsed.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "fingerprint": {
+          "description": "The fingerprint of the file when it failed to be parsed.",
+          "type": "string"
+        },
+        "id": {
+          "description": "ID of the image file.",
+          "type": "string"
+        },
+        "message": {
+          "description": "The error message.",
+          "type": "string"
+        },
+        "mod-time": {
+          "description": "The modification time of the file when it failed to be parsed.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "size": {
+          "description": "The size of the file when it failed to be parsed.",
+          "type": "integer"
+        }
+      }
+    },
+    "FailureList": {
+      "properties": {
+        "items": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Failure"
+          }
+        },
+        "metadata": {
+          "$ref": "#/definitions/Metadata"
+        }
+      }
+    },
+    "Image": {
+      "required": [
+        "id"
+      ],
+      "properties": {
+        "annotation": {
+          "$ref": "#/definitions/Annotation"
+        },
+        "checkpoint": {
+          "type": "string"
+        },
+        "creation-time": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "hash": {
+          "description": "The perceptual hash of 64 bits in hexadecimal, which is omitted if the pixels of the image couldn't be decoded.",
+          "type": "string"
+        },
+        "id": {
+          "description": "ID of the image file.",
+          "type": "string"
+        },
+        "negative-prompt": {
+          "type": "string"
+        },
+        "parameters": {
+          "description": "The raw text of generation parameters.",
+          "type": "string"
+        },
+        "pixel": {
+          "type": "integer"
+        },
+        "prompt": {
+          "type": "string"
+        }
+      },
+      "additionalProperties": true
+    },
+    "ImageDetail": {
+      "required": [
+        "image"
+      ],
+      "properties": {
+        "image": {
+          "$ref": "#/definitions/Image"
+        },
+        "next": {
+          "description": "ID of the next image in the list, which is omitted if the image is the last one or doesn't match the search parameters.",
+          "type": "string"
+        },
+        "previous": {
+          "description": "ID of the previous image in the list, which is omitted if the image is the first one or doesn't match the search parameters.",
+          "type": "string"
+        }
+      }
+    },
+    "ImageList": {
+      "properties": {
+        "facets": {
+          "$ref": "#/definitions/Facets"
+        },
+        "items": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Image"
+          }
+        },
+        "metadata": {
+          "$ref": "#/definitions/Metadata"
+        }
+      }
+    },
+    "Metadata": {
+      "required": [
+        "currentPage",
+        "totalPages",
+        "totalItems"
+      ],
+      "properties": {
+        "currentPage": {
+          "description": "The the current page you are at.",
+          "type": "integer",
+          "example": 2
+        },
+        "totalItems": {
+          "description": "The total number of items available.",
+          "type": "integer",
+          "format": "int",
+          "example": 46
+        },
+        "totalPages": {
+          "description": "The total number of pages.",
+          "type": "integer",
+          "example": 16
+        }
+      }
+    },
+    "Share": {
+      "required": [
+        "token",
+        "url",
+        "expires"
+      ],
+      "properties": {
+        "expires": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "token": {
+          "description": "The token given as the share parameter.",
+          "type": "string"
+        },
+        "url": {
+          "description": "The shared URL relative to the server, which is the image itself or the web UI showing the search.",
+          "type": "string"
+        }
+      }
+    },
+    "ShareRequest": {
+      "properties": {
+        "expiresIn": {
+          "description": "The number of hours until the link expires.",
+          "type": "integer",
+          "default": 168,
+          "minimum": 1
+        },
+        "image": {
+          "description": "ID of the shared image.",
+          "type": "string"
+        },
+        "q": {
+          "description": "Query string of the shared search, which is given as the q parameter of GET /images.",
+          "type": "string"
+        }
+      }
+    },
+    "SimilarImage": {
+      "required": [
+        "image",
+        "distance"
+      ],
+      "properties": {
+        "distance": {
+          "description": "The Hamming distance from the hash of the compared image.",
+          "type": "integer"
+        },
+        "image": {
+          "$ref": "#/definitions/Image"
+        }
+      }
+    },
+    "StandardError": {
+      "required": [
+        "message"
+      ],
+      "properties": {
+        "message": {
+          "description": "The error message.",
+          "type": "string"
+        },
+        "position": {
+          "description": "The 1-based position of the problem in the query string, which is given for invalid query strings.",
+          "type": "integer"
+        }
+      }
+    },
+    "Stats": {
+      "required": [
+        "total",
+        "created",
+        "checkpoints",
+        "samplers",
+        "promptTags",
+        "resolutions",
+        "sizes"
+      ],
+      "properties": {
+        "checkpoints": {
+          "description": "The most used checkpoints.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/CheckpointStats"
+          }
+        },
+        "created": {
+          "description": "The numbers of images created in each period in chronological order. Terms are the first dates of the periods.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "promptTags": {
+          "description": "The most used tags of prompts separated by commas, which are in lower case without emphasis, weights, and LoRAs.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "resolutions": {
+          "description": "The most used resolutions, e.g. 512x768.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "samplers": {
+          "description": "The most used samplers.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "sizes": {
+          "description": "The numbers of images in each size class.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "total": {
+          "description": "The number of images.",
+          "type": "integer"
+        }
+      }
+    },
+    "Suggestions": {
+      "required": [
+        "items"
+      ],
+      "properties": {
+        "corrections": {
+          "description": "Terms close to the prefix in edit distance, e.g. \"did you mean\" candidates for a misspelt word, which are only given when no terms start with the prefix.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "items": {
+          "description": "Terms starting with the prefix in descending order of the numbers of images having them.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        }
+      }
+    },
+    "TrashItem": {
+      "required": [
+        "id",
+        "image",
+        "deleted"
+      ],
+      "properties": {
+        "deleted": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "id": {
+          "description": "ID of the item in the trash.",
+          "type": "string"
+        },
+        "image": {
+          "description": "ID of the image, which is restored to the same ID.",
+          "type": "string"
+        }
+      }
+    },
+    "TrashRestore": {
+      "required": [
+        "items"
+      ],
+      "properties": {
+        "items": {
+          "description": "IDs of the restored items.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    }
+  },
+  "securityDefinitions": {
+    "session": {
+      "description": "A session cookie named session, which is set by POST /login. It is used by the web UI.",
+      "type": "apiKey",
+      "name": "Cookie",
+      "in": "header"
+    },
+    "share": {
+      "description": "A signed share link token given by POST /shares, which grants read access to one image or to the images matching one search until it expires.",
+      "type": "apiKey",
+      "name": "share",
+      "in": "query"
+    },
+    "token": {
+      "description": "An API token created by the user command, which is given as a bearer token, e.g. \"Authorization: Bearer sdv_0123...\". It is used by scripts.",
+      "type": "apiKey",
+      "name": "Authorization",
+      "in": "header"
+    }
+  },
+  "security": [
+    {
+      "session": []
+    },
+    {
+      "token": []
+    },
+    {
+      "share": []
+    }
+  ]
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "consumes": [
+    "application/json"
+  ],
+  "produces": [
+    "application/json"
+  ],
+  "schemes": [
+    "http"
+  ],
+  "swagger": "2.0",
+  "info": {
+    "title": "SD Image Viewer API",
+    "version": "2023-04-27"
+  },
+  "host": "localhost:8080",
+  "basePath": "/api/v1",
+  "paths": {
+    "/checkpoints": {
+      "get": {
+        "description": "Get a list of checkpoints.",
+        "operationId": "getCheckpoints",
+        "responses": {
+          "200": {
+            "description": "A list of checkpoint names.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "type": "string"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/collections": {
+      "get": {
+        "description": "List collections sorted by their names. Images in collections are only given by the detail of a collection.",
+        "operationId": "getCollections",
+        "responses": {
+          "200": {
+            "description": "A list of collections.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/Collection"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Create a collection.",
+        "operationId": "createCollection",
+        "parameters": [
+          {
+            "name": "collection",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/CollectionRequest"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "The created collection.",
+            "schema": {
+              "$ref": "#/definitions/Collection"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/collections/{id}": {
+      "get": {
+        "description": "Get a collection with IDs of its images in order.",
+        "operationId": "getCollection",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the collection.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The collection.",
+            "schema": {
+              "$ref": "#/definitions/Collection"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "Rename a collection, and change its description and cover image.",
+        "operationId": "updateCollection",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the collection.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "collection",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/CollectionRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The updated collection.",
+            "schema": {
+              "$ref": "#/definitions/Collection"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Delete a collection. Images in the collection are not deleted.",
+        "operationId": "deleteCollection",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the collection.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Deleted."
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/collections/{id}/images": {
+      "put": {
+        "description": "Replace images in a collection with the given ones in the given order, e.g. to reorder them.",
+        "operationId": "setCollectionImages",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the collection.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "images",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/CollectionImages"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The updated collection.",
+            "schema": {
+              "$ref": "#/definitions/Collection"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Add images to a collection at the given position, or at the end if it isn't given. Images already in the collection are moved to the position.",
+        "operationId": "addCollectionImages",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the collection.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "images",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/CollectionImages"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The updated collection.",
+            "schema": {
+              "$ref": "#/definitions/Collection"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Remove images from a collection.",
+        "operationId": "removeCollectionImages",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the collection.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "IDs of the removed images.",
+            "name": "image",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The updated collection.",
+            "schema": {
+              "$ref": "#/definitions/Collection"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/downloads": {
+      "post": {
+        "description": "Download images as a ZIP archive streamed on the fly, which has a manifest of their prompts and parameters. Images are given by their IDs in the body, or by the same search parameters as GET /images otherwise.",
+        "produces": [
+          "application/json",
+          "application/zip"
+        ],
+        "operationId": "downloadImages",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Search query.",
+            "name": "query",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Query string searching all indexed fields, e.g. prompt:\"red dress\" -negative:blurry steps:\u003e30 rating:\u003e=4. Annotations are searched with favorite:true, rating, tag and note. An invalid query string results in 400 with the position of the problem.",
+            "name": "q",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "small",
+              "medium",
+              "large"
+            ],
+            "type": "string",
+            "description": "Retrieving the given sized images.",
+            "name": "size",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images that use the given checkpoint.",
+            "name": "checkpoint",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created before the given date time.",
+            "name": "before",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created after the given date time.",
+            "name": "after",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Retrieving only favourite images if true.",
+            "name": "favorite",
+            "in": "query"
+          },
+          {
+            "maximum": 5,
+            "minimum": 1,
+            "type": "integer",
+            "description": "Retrieving images rated the given value or higher.",
+            "name": "minRating",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Retrieving images having all the given tags.",
+            "name": "tag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images in the collection of the given ID.",
+            "name": "collection",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "asc",
+              "desc"
+            ],
+            "type": "string",
+            "default": "desc",
+            "name": "order",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "created",
+              "rating",
+              "position"
+            ],
+            "type": "string",
+            "default": "created",
+            "description": "The sort key. Images having the same rating are sorted by their creation times. Position sorts images in the order of the collection regardless of the order parameter, and requires the collection parameter.",
+            "name": "sort",
+            "in": "query"
+          },
+          {
+            "name": "options",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/DownloadRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A ZIP archive of the images.",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/duplicates": {
+      "get": {
+        "description": "List groups of exact and near duplicates, which are images whose perceptual hashes are within the given Hamming distance from the hash of the oldest image in each group. Larger groups come first, and images in each group are sorted in ascending order of distances from the oldest one, which comes first.",
+        "operationId": "getDuplicates",
+        "parameters": [
+          {
+            "maximum": 7,
+            "minimum": 0,
+            "type": "integer",
+            "default": 4,
+            "description": "The maximum Hamming distance between the 64-bit hashes. Zero finds only exact duplicates.",
+            "name": "distance",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The number of groups one page has at most.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "Requesting page number.",
+            "name": "page",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A list of groups of duplicates.",
+            "schema": {
+              "$ref": "#/definitions/DuplicateList"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/exports": {
+      "get": {
+        "description": "Export metadata of images matching the same search parameters as GET /images, which is streamed while searching images page by page. JSON Lines have the full metadata of each image, CSV has the given columns, and prompts are lines read by the \"Prompts from file or textbox\" script of AUTOMATIC1111's web UI.",
+        "produces": [
+          "application/json",
+          "application/x-ndjson",
+          "text/csv",
+          "text/plain"
+        ],
+        "operationId": "exportImages",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Search query.",
+            "name": "query",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Query string searching all indexed fields, e.g. prompt:\"red dress\" -negative:blurry steps:\u003e30 rating:\u003e=4. Annotations are searched with favorite:true, rating, tag and note. An invalid query string results in 400 with the position of the problem.",
+            "name": "q",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "small",
+              "medium",
+              "large"
+            ],
+            "type": "string",
+            "description": "Retrieving the given sized images.",
+            "name": "size",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images that use the given checkpoint.",
+            "name": "checkpoint",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created before the given date time.",
+            "name": "before",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created after the given date time.",
+            "name": "after",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Retrieving only favourite images if true.",
+            "name": "favorite",
+            "in": "query"
+          },
+          {
+            "maximum": 5,
+            "minimum": 1,
+            "type": "integer",
+            "description": "Retrieving images rated the given value or higher.",
+            "name": "minRating",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Retrieving images having all the given tags.",
+            "name": "tag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images in the collection of the given ID.",
+            "name": "collection",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "asc",
+              "desc"
+            ],
+            "type": "string",
+            "default": "desc",
+            "name": "order",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "created",
+              "rating",
+              "position"
+            ],
+            "type": "string",
+            "default": "created",
+            "description": "The sort key. Images having the same rating are sorted by their creation times. Position sorts images in the order of the collection regardless of the order parameter, and requires the collection parameter.",
+            "name": "sort",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "jsonl",
+              "csv",
+              "prompts"
+            ],
+            "type": "string",
+            "default": "jsonl",
+            "description": "The export format.",
+            "name": "format",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "csv",
+            "description": "Columns of CSV exports, e.g. id, prompt, negative-prompt, checkpoint, creation-time, pixel, parameters, loras, favorite, rating, tags, note, or names of parameters used in query strings such as cfg-scale. Defaults to id, prompt, negative-prompt, checkpoint, sampler, steps, cfg-scale, seed, size and creation-time.",
+            "name": "columns",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The exported images.",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/image/{id}": {
+      "get": {
+        "description": "Get an image. Conditional requests with ETags and modification times and byte range requests are supported as specified in RFC 7232 and RFC 7233.",
+        "produces": [
+          "application/json",
+          "image/jpeg",
+          "image/png"
+        ],
+        "operationId": "getImage",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the image file.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "Requesting a thumbnail of the given width instead of the original image, which is rounded up to 128, 256, 512 or 1024. Thumbnails are JPEG images unless the images have transparency.",
+            "name": "w",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "If-Modified-Since",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "If-None-Match",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "Requesting a byte range, e.g. bytes=0-1023. Multiple ranges result in a multipart response.",
+            "name": "Range",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "An ETag or a modification time; the whole image is returned if the image has been modified.",
+            "name": "If-Range",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "If-Unmodified-Since",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Requested image file.",
+            "schema": {
+              "type": "file"
+            },
+            "headers": {
+              "Accept-Ranges": {
+                "type": "string"
+              },
+              "Cache-Control": {
+                "type": "string"
+              },
+              "ETag": {
+                "type": "string",
+                "description": "A strong ETag given by the hash of the content."
+              },
+              "Last-Modified": {
+                "type": "string"
+              }
+            }
+          },
+          "206": {
+            "description": "The requested range of the image file.",
+            "schema": {
+              "type": "file"
+            },
+            "headers": {
+              "Content-Range": {
+                "type": "string"
+              },
+              "ETag": {
+                "type": "string"
+              }
+            }
+          },
+          "304": {
+            "description": "Requested image is not modified."
+          },
+          "412": {
+            "description": "The image doesn't satisfy If-Match or If-Unmodified-Since."
+          },
+          "416": {
+            "description": "The requested range is not satisfiable.",
+            "headers": {
+              "Content-Range": {
+                "type": "string"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "head": {
+        "description": "Get headers of an image, which are the same as the GET method.",
+        "produces": [
+          "application/json",
+          "image/jpeg",
+          "image/png"
+        ],
+        "operationId": "headImage",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the image file.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "Requesting headers of a thumbnail of the given width.",
+            "name": "w",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "If-Modified-Since",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "If-None-Match",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "Range",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "If-Range",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "name": "If-Unmodified-Since",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Headers of the requested image file.",
+            "headers": {
+              "Accept-Ranges": {
+                "type": "string"
+              },
+              "Cache-Control": {
+                "type": "string"
+              },
+              "Content-Length": {
+                "type": "integer"
+              },
+              "ETag": {
+                "type": "string"
+              },
+              "Last-Modified": {
+                "type": "string"
+              }
+            }
+          },
+          "206": {
+            "description": "Headers of the requested range of the image file.",
+            "headers": {
+              "Content-Range": {
+                "type": "string"
+              }
+            }
+          },
+          "304": {
+            "description": "Requested image is not modified."
+          },
+          "412": {
+            "description": "The image doesn't satisfy If-Match or If-Unmodified-Since."
+          },
+          "416": {
+            "description": "The requested range is not satisfiable."
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/images": {
+      "get": {
+        "description": "List images.",
+        "operationId": "getImages",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Search query.",
+            "name": "query",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Query string searching all indexed fields, e.g. prompt:\"red dress\" -negative:blurry steps:\u003e30 rating:\u003e=4. Annotations are searched with favorite:true, rating, tag and note. An invalid query string results in 400 with the position of the problem.",
+            "name": "q",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "small",
+              "medium",
+              "large"
+            ],
+            "type": "string",
+            "description": "Retrieving the given sized images.",
+            "name": "size",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images that use the given checkpoint.",
+            "name": "checkpoint",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created before the given date time.",
+            "name": "before",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created after the given date time.",
+            "name": "after",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Retrieving only favourite images if true.",
+            "name": "favorite",
+            "in": "query"
+          },
+          {
+            "maximum": 5,
+            "minimum": 1,
+            "type": "integer",
+            "description": "Retrieving images rated the given value or higher.",
+            "name": "minRating",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Retrieving images having all the given tags.",
+            "name": "tag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images in the collection of the given ID.",
+            "name": "collection",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The number of items one page has at most.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "Requesting page number.",
+            "name": "page",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "asc",
+              "desc"
+            ],
+            "type": "string",
+            "default": "desc",
+            "name": "order",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "created",
+              "rating",
+              "position"
+            ],
+            "type": "string",
+            "default": "created",
+            "description": "The sort key. Images having the same rating are sorted by their creation times. Position sorts images in the order of the collection regardless of the order parameter, and requires the collection parameter.",
+            "name": "sort",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "enum": [
+                "checkpoint",
+                "sampler",
+                "lora",
+                "size",
+                "created"
+              ],
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Facets counting all images matching the query, which are returned in facets of the response. The created facet is a histogram of creation dates.",
+            "name": "facets",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "day",
+              "week",
+              "month"
+            ],
+            "type": "string",
+            "default": "month",
+            "description": "The interval of periods of the created facet.",
+            "name": "interval",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "default": 10,
+            "description": "The maximum number of the most frequent terms of each facet, and the number of the latest periods of the created facet.",
+            "name": "facetSize",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A list of images.",
+            "schema": {
+              "$ref": "#/definitions/ImageList"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Move images to the trash. They are removed from the index immediately, and can be restored until they are purged. Deleting images is disabled in read-only mode. Images in S3 buckets or archives can't be deleted, which results in 501.",
+        "operationId": "deleteImages",
+        "parameters": [
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "IDs of the deleted images.",
+            "name": "image",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The items in the trash.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/TrashItem"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/images/{id}": {
+      "get": {
+        "description": "Get indexed metadata of an image with IDs of the previous and next images in the list of images matching the given search parameters.",
+        "operationId": "getImageDetail",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the image file.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Search query.",
+            "name": "query",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Query string searching all indexed fields, e.g. prompt:\"red dress\" -negative:blurry steps:\u003e30 rating:\u003e=4. Annotations are searched with favorite:true, rating, tag and note. An invalid query string results in 400 with the position of the problem.",
+            "name": "q",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "small",
+              "medium",
+              "large"
+            ],
+            "type": "string",
+            "description": "Retrieving the given sized images.",
+            "name": "size",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images that use the given checkpoint.",
+            "name": "checkpoint",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created before the given date time.",
+            "name": "before",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created after the given date time.",
+            "name": "after",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Retrieving only favourite images if true.",
+            "name": "favorite",
+            "in": "query"
+          },
+          {
+            "maximum": 5,
+            "minimum": 1,
+            "type": "integer",
+            "description": "Retrieving images rated the given value or higher.",
+            "name": "minRating",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Retrieving images having all the given tags.",
+            "name": "tag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images in the collection of the given ID.",
+            "name": "collection",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "asc",
+              "desc"
+            ],
+            "type": "string",
+            "default": "desc",
+            "name": "order",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "created",
+              "rating",
+              "position"
+            ],
+            "type": "string",
+            "default": "created",
+            "description": "The sort key. Images having the same rating are sorted by their creation times. Position sorts images in the order of the collection regardless of the order parameter, and requires the collection parameter.",
+            "name": "sort",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The image and its neighbours.",
+            "schema": {
+              "$ref": "#/definitions/ImageDetail"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Move an image to the trash. It is removed from the index immediately, and can be restored until it is purged. Deleting images is disabled in read-only mode. Images in S3 buckets or archives can't be deleted, which results in 501.",
+        "operationId": "deleteImage",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the image file.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The item in the trash.",
+            "schema": {
+              "$ref": "#/definitions/TrashItem"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/images/{id}/annotations": {
+      "put": {
+        "description": "Replace the annotation of an image, i.e. whether it is a favourite, its rating, tags and a note. An empty annotation removes it. Annotations are kept when images are indexed again or moved.",
+        "operationId": "putAnnotation",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the image file.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "annotation",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/Annotation"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The updated annotation, whose tags and note are normalized.",
+            "schema": {
+              "$ref": "#/definitions/Annotation"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/images/{id}/related": {
+      "get": {
+        "description": "List images whose prompts share terms with the prompt of an image in descending order of relevance. Terms rare in the library weigh more than common ones, and boilerplate tags such as \"masterpiece\" and \"best quality\" are ignored.",
+        "operationId": "getRelatedImages",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the image file.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The number of images one page has at most.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "Requesting page number.",
+            "name": "page",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A list of related images. Facets aren't computed.",
+            "schema": {
+              "$ref": "#/definitions/ImageList"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/images/{id}/similar": {
+      "get": {
+        "description": "List images whose perceptual hashes are within the given Hamming distance from the hash of an image, e.g. those generated from the same seed or upscaled by hires passes, in ascending order of distances.",
+        "operationId": "getSimilarImages",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "ID of the image file.",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "maximum": 7,
+            "minimum": 0,
+            "type": "integer",
+            "default": 4,
+            "description": "The maximum Hamming distance between the 64-bit hashes.",
+            "name": "distance",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The similar images, which are empty if the image doesn't have a hash, i.e. its pixels couldn't be decoded.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/SimilarImage"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/index/failures": {
+      "get": {
+        "description": "List image files that failed to be indexed.",
+        "operationId": "getFailures",
+        "parameters": [
+          {
+            "enum": [
+              "no_parameters",
+              "not_supported_parameters",
+              "io",
+              "corrupt"
+            ],
+            "type": "string",
+            "description": "Retrieving failures of the given class.",
+            "name": "class",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The number of items one page has at most.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "Requesting page number.",
+            "name": "page",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A list of failures.",
+            "schema": {
+              "$ref": "#/definitions/FailureList"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/login": {
+      "post": {
+        "security": [],
+        "description": "Log in with a password, and get a session cookie. Authentication is required only if users are registered with the user command.",
+        "operationId": "login",
+        "parameters": [
+          {
+            "name": "credentials",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/Credentials"
+            }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Logged in.",
+            "headers": {
+              "Set-Cookie": {
+                "type": "string"
+              }
+            }
+          },
+          "401": {
+            "description": "The user name or the password is wrong.",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          },
+          "429": {
+            "description": "Logins from the client or as the user failed too many times. They are refused until Retry-After seconds pass.",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            },
+            "headers": {
+              "Retry-After": {
+                "type": "integer",
+                "description": "Seconds to wait before logging in again."
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/logout": {
+      "post": {
+        "security": [],
+        "description": "Log out, and remove the session cookie.",
+        "operationId": "logout",
+        "responses": {
+          "204": {
+            "description": "Logged out.",
+            "headers": {
+              "Set-Cookie": {
+                "type": "string"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/shares": {
+      "post": {
+        "description": "Create a signed, expiring share link of one image or one search. Share links can't create other share links.",
+        "operationId": "createShare",
+        "parameters": [
+          {
+            "name": "share",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ShareRequest"
+            }
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "The created share link.",
+            "schema": {
+              "$ref": "#/definitions/Share"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/stats": {
+      "get": {
+        "description": "Get statistics of images matching the same search parameters as GET /images, i.e. images created in each period, the most used checkpoints with their images in each period and average steps and CFG scales, the most used samplers and tags of prompts, and the mix of resolutions and size classes. Results are cached until the index changes.",
+        "operationId": "getStats",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Search query.",
+            "name": "query",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Query string searching all indexed fields, e.g. prompt:\"red dress\" -negative:blurry steps:\u003e30 rating:\u003e=4. Annotations are searched with favorite:true, rating, tag and note. An invalid query string results in 400 with the position of the problem.",
+            "name": "q",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "small",
+              "medium",
+              "large"
+            ],
+            "type": "string",
+            "description": "Retrieving the given sized images.",
+            "name": "size",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images that use the given checkpoint.",
+            "name": "checkpoint",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created before the given date time.",
+            "name": "before",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Retrieving images created after the given date time.",
+            "name": "after",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "description": "Retrieving only favourite images if true.",
+            "name": "favorite",
+            "in": "query"
+          },
+          {
+            "maximum": 5,
+            "minimum": 1,
+            "type": "integer",
+            "description": "Retrieving images rated the given value or higher.",
+            "name": "minRating",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Retrieving images having all the given tags.",
+            "name": "tag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Retrieving images in the collection of the given ID.",
+            "name": "collection",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "day",
+              "week",
+              "month"
+            ],
+            "type": "string",
+            "default": "day",
+            "description": "The interval of periods counting images.",
+            "name": "interval",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "default": 30,
+            "description": "The number of the latest periods. Zero means all periods.",
+            "name": "periods",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "default": 10,
+            "description": "The maximum number of the most frequent terms of each list, e.g. checkpoints. Zero means all.",
+            "name": "top",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Statistics of the images.",
+            "schema": {
+              "$ref": "#/definitions/Stats"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/suggest": {
+      "get": {
+        "description": "Suggest terms of a field starting with a prefix to autocomplete queries, with the numbers of images having them. If no terms start with the prefix, terms close to it in edit distance are returned as corrections.",
+        "operationId": "getSuggestions",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The prefix of terms, e.g. the word being typed. Terms of prompts are lower-case words, and prefixes of them are case-insensitive. The most frequent terms are returned without prefixes.",
+            "name": "prefix",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "prompt",
+              "checkpoint",
+              "sampler",
+              "lora"
+            ],
+            "type": "string",
+            "default": "prompt",
+            "description": "The field whose terms are suggested.",
+            "name": "field",
+            "in": "query"
+          },
+          {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "default": 10,
+            "description": "The maximum number of terms.",
+            "name": "size",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Suggested terms.",
+            "schema": {
+              "$ref": "#/definitions/Suggestions"
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/trash": {
+      "get": {
+        "description": "List images in the trash from the most recently deleted one. The trash is disabled in read-only mode.",
+        "operationId": "getTrash",
+        "responses": {
+          "200": {
+            "description": "The items in the trash.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/TrashItem"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    },
+    "/trash/restore": {
+      "post": {
+        "description": "Restore images in the trash to their original paths, and index them again. Restoring images is disabled in read-only mode.",
+        "operationId": "restoreTrash",
+        "parameters": [
+          {
+            "name": "items",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/TrashRestore"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The restored items.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/TrashItem"
+              }
+            }
+          },
+          "default": {
+            "description": "Error Response",
+            "schema": {
+              "$ref": "#/definitions/StandardError"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "Annotation": {
+      "properties": {
+        "favorite": {
+          "type": "boolean"
+        },
+        "note": {
+          "description": "A note of up to 4096 characters.",
+          "type": "string"
+        },
+        "rating": {
+          "description": "The rating from 1 to 5, and 0 means not rated.",
+          "type": "integer",
+          "maximum": 5,
+          "minimum": 0
+        },
+        "tags": {
+          "description": "At most 50 tags of up to 64 characters. Duplicated and empty tags are removed.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "CheckpointStats": {
+      "required": [
+        "name",
+        "count",
+        "averageSteps",
+        "averageCfgScale",
+        "created"
+      ],
+      "properties": {
+        "averageCfgScale": {
+          "description": "The average CFG scale, which is zero if no images have it.",
+          "type": "number"
+        },
+        "averageSteps": {
+          "description": "The average sampling steps, which is zero if no images have them.",
+          "type": "number"
+        },
+        "count": {
+          "description": "The number of images generated with the checkpoint.",
+          "type": "integer"
+        },
+        "created": {
+          "description": "The numbers of images generated with the checkpoint in the same periods as created of Stats.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "name": {
+          "type": "string",
+          "example": "DreamShaper"
+        }
+      }
+    },
+    "Collection": {
+      "required": [
+        "id",
+        "name",
+        "count",
+        "created",
+        "updated"
+      ],
+      "properties": {
+        "count": {
+          "description": "The number of images in the collection.",
+          "type": "integer"
+        },
+        "cover": {
+          "description": "ID of the cover image, which is the first image unless it is given. It is empty if there are no images.",
+          "type": "string"
+        },
+        "created": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "description": {
+          "type": "string"
+        },
+        "id": {
+          "type": "string"
+        },
+        "images": {
+          "description": "IDs of images in order, which are only given by the detail of a collection.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "name": {
+          "type": "string"
+        },
+        "updated": {
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "CollectionImages": {
+      "required": [
+        "images"
+      ],
+      "properties": {
+        "images": {
+          "description": "IDs of indexed images.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "position": {
+          "description": "The 1-based position where the images are inserted, which is only used to add images. The images are appended if it isn't given.",
+          "type": "integer",
+          "minimum": 1
+        }
+      }
+    },
+    "CollectionRequest": {
+      "required": [
+        "name"
+      ],
+      "properties": {
+        "cover": {
+          "description": "ID of the cover image, which must be in the collection. The first image is used if it is empty.",
+          "type": "string"
+        },
+        "description": {
+          "description": "The description of up to 4096 characters.",
+          "type": "string"
+        },
+        "name": {
+          "description": "The name of up to 256 characters.",
+          "type": "string"
+        }
+      }
+    },
+    "Credentials": {
+      "required": [
+        "user",
+        "password"
+      ],
+      "properties": {
+        "password": {
+          "type": "string"
+        },
+        "user": {
+          "type": "string"
+        }
+      }
+    },
+    "DownloadRequest": {
+      "properties": {
+        "flatten": {
+          "description": "Store images at the root of the archive if true, where images of the same name are numbered. Otherwise, they are stored in the folder structure of the libraries.",
+          "type": "boolean"
+        },
+        "images": {
+          "description": "IDs of the downloaded images in order. The search parameters are ignored if they are given.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "manifest": {
+          "description": "The format of the manifest of prompts and parameters, which is stored as manifest.json or manifest.csv.",
+          "type": "string",
+          "default": "json",
+          "enum": [
+            "json",
+            "csv",
+            "none"
+          ]
+        },
+        "stripMetadata": {
+          "description": "Remove generation parameters and other metadata embedded in the images if true.",
+          "type": "boolean"
+        }
+      }
+    },
+    "DuplicateGroup": {
+      "required": [
+        "exact",
+        "images"
+      ],
+      "properties": {
+        "exact": {
+          "description": "True if all the images have the same hash.",
+          "type": "boolean"
+        },
+        "images": {
+          "description": "The images from the oldest one, whose distance is zero.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/SimilarImage"
+          }
+        }
+      }
+    },
+    "DuplicateList": {
+      "properties": {
+        "items": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DuplicateGroup"
+          }
+        },
+        "metadata": {
+          "$ref": "#/definitions/Metadata"
+        }
+      }
+    },
+    "Facet": {
+      "required": [
+        "term",
+        "count"
+      ],
+      "properties": {
+        "count": {
+          "description": "The number of images having the term.",
+          "type": "integer",
+          "example": 1204
+        },
+        "term": {
+          "type": "string",
+          "example": "DreamShaper"
+        }
+      }
+    },
+    "Facets": {
+      "description": "Counts of images by terms of the requested facets.",
+      "properties": {
+        "checkpoint": {
+          "description": "The most used checkpoints.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "created": {
+          "description": "Periods in chronological order, whose terms are their first dates, e.g. 2026-01-01. Periods without images are omitted.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "lora": {
+          "description": "The most used LoRAs.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "sampler": {
+          "description": "The most used samplers.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "size": {
+          "description": "Size classes, i.e. small, medium and large, from the smallest.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        }
+      }
+    },
+    "Failure": {
+      "required": [
+        "id",
+        "class"
+      ],
+      "properties": {
+        "class": {
+          "description": "The class of the error.",
+          "type": "string",
+          "enum": [
+            "no_parameters",
+            "not_supported_parameters",
+            "io",
+            "corrupt"
+          ]
+        },
+        "failed-at": {
+          "description": "The time when the file failed to be parsed.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "fingerprint": {
+          "description": "The fingerprint of the file when it failed to be parsed.",
+          "type": "string"
+        },
+        "id": {
+          "description": "ID of the image file.",
+          "type": "string"
+        },
+        "message": {
+          "description": "The error message.",
+          "type": "string"
+        },
+        "mod-time": {
+          "description": "The modification time of the file when it failed to be parsed.",
+          "type": "string",
+          "format": "date-time"
+        },
+        "size": {
+          "description": "The size of the file when it failed to be parsed.",
+          "type": "integer"
+        }
+      }
+    },
+    "FailureList": {
+      "properties": {
+        "items": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Failure"
+          }
+        },
+        "metadata": {
+          "$ref": "#/definitions/Metadata"
+        }
+      }
+    },
+    "Image": {
+      "required": [
+        "id"
+      ],
+      "properties": {
+        "annotation": {
+          "$ref": "#/definitions/Annotation"
+        },
+        "checkpoint": {
+          "type": "string"
+        },
+        "creation-time": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "hash": {
+          "description": "The perceptual hash of 64 bits in hexadecimal, which is omitted if the pixels of the image couldn't be decoded.",
+          "type": "string"
+        },
+        "id": {
+          "description": "ID of the image file.",
+          "type": "string"
+        },
+        "negative-prompt": {
+          "type": "string"
+        },
+        "parameters": {
+          "description": "The raw text of generation parameters.",
+          "type": "string"
+        },
+        "pixel": {
+          "type": "integer"
+        },
+        "prompt": {
+          "type": "string"
+        }
+      },
+      "additionalProperties": true
+    },
+    "ImageDetail": {
+      "required": [
+        "image"
+      ],
+      "properties": {
+        "image": {
+          "$ref": "#/definitions/Image"
+        },
+        "next": {
+          "description": "ID of the next image in the list, which is omitted if the image is the last one or doesn't match the search parameters.",
+          "type": "string"
+        },
+        "previous": {
+          "description": "ID of the previous image in the list, which is omitted if the image is the first one or doesn't match the search parameters.",
+          "type": "string"
+        }
+      }
+    },
+    "ImageList": {
+      "properties": {
+        "facets": {
+          "$ref": "#/definitions/Facets"
+        },
+        "items": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Image"
+          }
+        },
+        "metadata": {
+          "$ref": "#/definitions/Metadata"
+        }
+      }
+    },
+    "Metadata": {
+      "required": [
+        "currentPage",
+        "totalPages",
+        "totalItems"
+      ],
+      "properties": {
+        "currentPage": {
+          "description": "The the current page you are at.",
+          "type": "integer",
+          "example": 2
+        },
+        "totalItems": {
+          "description": "The total number of items available.",
+          "type": "integer",
+          "format": "int",
+          "example": 46
+        },
+        "totalPages": {
           "description": "The total number of pages.",
           "type": "integer",
           "example": 16
         }
       }
     },
+    "Share": {
+      "required": [
+        "token",
+        "url",
+        "expires"
+      ],
+      "properties": {
+        "expires": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "token": {
+          "description": "The token given as the share parameter.",
+          "type": "string"
+        },
+        "url": {
+          "description": "The shared URL relative to the server, which is the image itself or the web UI showing the search.",
+          "type": "string"
+        }
+      }
+    },
+    "ShareRequest": {
+      "properties": {
+        "expiresIn": {
+          "description": "The number of hours until the link expires.",
+          "type": "integer",
+          "default": 168,
+          "minimum": 1
+        },
+        "image": {
+          "description": "ID of the shared image.",
+          "type": "string"
+        },
+        "q": {
+          "description": "Query string of the shared search, which is given as the q parameter of GET /images.",
+          "type": "string"
+        }
+      }
+    },
+    "SimilarImage": {
+      "required": [
+        "image",
+        "distance"
+      ],
+      "properties": {
+        "distance": {
+          "description": "The Hamming distance from the hash of the compared image.",
+          "type": "integer"
+        },
+        "image": {
+          "$ref": "#/definitions/Image"
+        }
+      }
+    },
     "StandardError": {
       "required": [
         "message"
@@ -516,9 +4499,159 @@ func init() {
         "message": {
           "description": "The error message.",
           "type": "string"
+        },
+        "position": {
+          "description": "The 1-based position of the problem in the query string, which is given for invalid query strings.",
+          "type": "integer"
+        }
+      }
+    },
+    "Stats": {
+      "required": [
+        "total",
+        "created",
+        "checkpoints",
+        "samplers",
+        "promptTags",
+        "resolutions",
+        "sizes"
+      ],
+      "properties": {
+        "checkpoints": {
+          "description": "The most used checkpoints.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/CheckpointStats"
+          }
+        },
+        "created": {
+          "description": "The numbers of images created in each period in chronological order. Terms are the first dates of the periods.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "promptTags": {
+          "description": "The most used tags of prompts separated by commas, which are in lower case without emphasis, weights, and LoRAs.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "resolutions": {
+          "description": "The most used resolutions, e.g. 512x768.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "samplers": {
+          "description": "The most used samplers.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "sizes": {
+          "description": "The numbers of images in each size class.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "total": {
+          "description": "The number of images.",
+          "type": "integer"
+        }
+      }
+    },
+    "Suggestions": {
+      "required": [
+        "items"
+      ],
+      "properties": {
+        "corrections": {
+          "description": "Terms close to the prefix in edit distance, e.g. \"did you mean\" candidates for a misspelt word, which are only given when no terms start with the prefix.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        },
+        "items": {
+          "description": "Terms starting with the prefix in descending order of the numbers of images having them.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Facet"
+          }
+        }
+      }
+    },
+    "TrashItem": {
+      "required": [
+        "id",
+        "image",
+        "deleted"
+      ],
+      "properties": {
+        "deleted": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "id": {
+          "description": "ID of the item in the trash.",
+          "type": "string"
+        },
+        "image": {
+          "description": "ID of the image, which is restored to the same ID.",
+          "type": "string"
+        }
+      }
+    },
+    "TrashRestore": {
+      "required": [
+        "items"
+      ],
+      "properties": {
+        "items": {
+          "description": "IDs of the restored items.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         }
       }
     }
-  }
+  },
+  "securityDefinitions": {
+    "session": {
+      "description": "A session cookie named session, which is set by POST /login. It is used by the web UI.",
+      "type": "apiKey",
+      "name": "Cookie",
+      "in": "header"
+    },
+    "share": {
+      "description": "A signed share link token given by POST /shares, which grants read access to one image or to the images matching one search until it expires.",
+      "type": "apiKey",
+      "name": "share",
+      "in": "query"
+    },
+    "token": {
+      "description": "An API token created by the user command, which is given as a bearer token, e.g. \"Authorization: Bearer sdv_0123...\". It is used by scripts.",
+      "type": "apiKey",
+      "name": "Authorization",
+      "in": "header"
+    }
+  },
+  "security": [
+    {
+      "session": []
+    },
+    {
+      "token": []
+    },
+    {
+      "share": []
+    }
+  ]
 }`))
 }
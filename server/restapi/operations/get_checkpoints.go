@@ -12,16 +12,16 @@ import (
 )
 
 // GetCheckpointsHandlerFunc turns a function with the right signature into a get checkpoints handler
-type GetCheckpointsHandlerFunc func(GetCheckpointsParams) middleware.Responder
+type GetCheckpointsHandlerFunc func(GetCheckpointsParams, interface{}) middleware.Responder
 
 // Handle executing the request and returning a response
-func (fn GetCheckpointsHandlerFunc) Handle(params GetCheckpointsParams) middleware.Responder {
-	return fn(params)
+func (fn GetCheckpointsHandlerFunc) Handle(params GetCheckpointsParams, principal interface{}) middleware.Responder {
+	return fn(params, principal)
 }
 
 // GetCheckpointsHandler interface for that can handle valid get checkpoints params
 type GetCheckpointsHandler interface {
-	Handle(GetCheckpointsParams) middleware.Responder
+	Handle(GetCheckpointsParams, interface{}) middleware.Responder
 }
 
 // NewGetCheckpoints creates a new http.Handler for the get checkpoints operation
@@ -45,12 +45,25 @@ func (o *GetCheckpoints) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		*r = *rCtx
 	}
 	var Params = NewGetCheckpointsParams()
+	uprinc, aCtx, err := o.Context.Authorize(r, route)
+	if err != nil {
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+	if aCtx != nil {
+		*r = *aCtx
+	}
+	var principal interface{}
+	if uprinc != nil {
+		principal = uprinc.(interface{}) // this is really a interface{}, I promise
+	}
+
 	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
 	}
 
-	res := o.Handler.Handle(Params) // actually handle the request
+	res := o.Handler.Handle(Params, principal) // actually handle the request
 	o.Context.Respond(rw, r, route.Produces, route, res)
 
 }
@@ -12,16 +12,16 @@ import (
 )
 
 // GetImageHandlerFunc turns a function with the right signature into a get image handler
-type GetImageHandlerFunc func(GetImageParams) middleware.Responder
+type GetImageHandlerFunc func(GetImageParams, interface{}) middleware.Responder
 
 // Handle executing the request and returning a response
-func (fn GetImageHandlerFunc) Handle(params GetImageParams) middleware.Responder {
-	return fn(params)
+func (fn GetImageHandlerFunc) Handle(params GetImageParams, principal interface{}) middleware.Responder {
+	return fn(params, principal)
 }
 
 // GetImageHandler interface for that can handle valid get image params
 type GetImageHandler interface {
-	Handle(GetImageParams) middleware.Responder
+	Handle(GetImageParams, interface{}) middleware.Responder
 }
 
 // NewGetImage creates a new http.Handler for the get image operation
@@ -32,7 +32,7 @@ func NewGetImage(ctx *middleware.Context, handler GetImageHandler) *GetImage {
 /*
 	GetImage swagger:route GET /image/{id} getImage
 
-Get an image.
+Get an image. Conditional requests with ETags and modification times and byte range requests are supported as specified in RFC 7232 and RFC 7233.
 */
 type GetImage struct {
 	Context *middleware.Context
@@ -45,12 +45,25 @@ func (o *GetImage) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		*r = *rCtx
 	}
 	var Params = NewGetImageParams()
+	uprinc, aCtx, err := o.Context.Authorize(r, route)
+	if err != nil {
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+	if aCtx != nil {
+		*r = *aCtx
+	}
+	var principal interface{}
+	if uprinc != nil {
+		principal = uprinc.(interface{}) // this is really a interface{}, I promise
+	}
+
 	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
 	}
 
-	res := o.Handler.Handle(Params) // actually handle the request
+	res := o.Handler.Handle(Params, principal) // actually handle the request
 	o.Context.Respond(rw, r, route.Produces, route, res)
 
 }
@@ -9,8 +9,11 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // NewGetImageParams creates a new GetImageParams object
@@ -30,15 +33,39 @@ type GetImageParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*
+	  In: header
+	*/
+	IfMatch *string
 	/*
 	  In: header
 	*/
 	IfModifiedSince *string
+	/*
+	  In: header
+	*/
+	IfNoneMatch *string
+	/*An ETag or a modification time; the whole image is returned if the image has been modified.
+	  In: header
+	*/
+	IfRange *string
+	/*
+	  In: header
+	*/
+	IfUnmodifiedSince *string
+	/*Requesting a byte range, e.g. bytes=0-1023. Multiple ranges result in a multipart response.
+	  In: header
+	*/
+	Range *string
 	/*ID of the image file.
 	  Required: true
 	  In: path
 	*/
 	ID string
+	/*Requesting a thumbnail of the given width instead of the original image, which is rounded up to 128, 256, 512 or 1024. Thumbnails are JPEG images unless the images have transparency.
+	  In: query
+	*/
+	W *int64
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -50,20 +77,64 @@ func (o *GetImageParams) BindRequest(r *http.Request, route *middleware.MatchedR
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	if err := o.bindIfMatch(r.Header[http.CanonicalHeaderKey("If-Match")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := o.bindIfModifiedSince(r.Header[http.CanonicalHeaderKey("If-Modified-Since")], true, route.Formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := o.bindIfNoneMatch(r.Header[http.CanonicalHeaderKey("If-None-Match")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := o.bindIfRange(r.Header[http.CanonicalHeaderKey("If-Range")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := o.bindIfUnmodifiedSince(r.Header[http.CanonicalHeaderKey("If-Unmodified-Since")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := o.bindRange(r.Header[http.CanonicalHeaderKey("Range")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rID, rhkID, _ := route.Params.GetOK("id")
 	if err := o.bindID(rID, rhkID, route.Formats); err != nil {
 		res = append(res, err)
 	}
+
+	qW, qhkW, _ := qs.GetOK("w")
+	if err := o.bindW(qW, qhkW, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+// bindIfMatch binds and validates parameter IfMatch from header.
+func (o *GetImageParams) bindIfMatch(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.IfMatch = &raw
+
+	return nil
+}
+
 // bindIfModifiedSince binds and validates parameter IfModifiedSince from header.
 func (o *GetImageParams) bindIfModifiedSince(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -81,6 +152,74 @@ func (o *GetImageParams) bindIfModifiedSince(rawData []string, hasKey bool, form
 	return nil
 }
 
+// bindIfNoneMatch binds and validates parameter IfNoneMatch from header.
+func (o *GetImageParams) bindIfNoneMatch(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.IfNoneMatch = &raw
+
+	return nil
+}
+
+// bindIfRange binds and validates parameter IfRange from header.
+func (o *GetImageParams) bindIfRange(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.IfRange = &raw
+
+	return nil
+}
+
+// bindIfUnmodifiedSince binds and validates parameter IfUnmodifiedSince from header.
+func (o *GetImageParams) bindIfUnmodifiedSince(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.IfUnmodifiedSince = &raw
+
+	return nil
+}
+
+// bindRange binds and validates parameter Range from header.
+func (o *GetImageParams) bindRange(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.Range = &raw
+
+	return nil
+}
+
 // bindID binds and validates parameter ID from path.
 func (o *GetImageParams) bindID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -94,3 +233,40 @@ func (o *GetImageParams) bindID(rawData []string, hasKey bool, formats strfmt.Re
 
 	return nil
 }
+
+// bindW binds and validates parameter W from query.
+func (o *GetImageParams) bindW(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("w", "query", "int64", raw)
+	}
+	o.W = &value
+
+	if err := o.validateW(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateW carries on validations for parameter W
+func (o *GetImageParams) validateW(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("w", "query", *o.W, 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -23,10 +23,18 @@ GetImageOK Requested image file.
 swagger:response getImageOK
 */
 type GetImageOK struct {
+	/*
+
+	 */
+	AcceptRanges string `json:"Accept-Ranges"`
 	/*
 
 	 */
 	CacheControl string `json:"Cache-Control"`
+	/*A strong ETag given by the hash of the content.
+
+	 */
+	ETag string `json:"ETag"`
 	/*
 
 	 */
@@ -44,6 +52,17 @@ func NewGetImageOK() *GetImageOK {
 	return &GetImageOK{}
 }
 
+// WithAcceptRanges adds the acceptRanges to the get image o k response
+func (o *GetImageOK) WithAcceptRanges(acceptRanges string) *GetImageOK {
+	o.AcceptRanges = acceptRanges
+	return o
+}
+
+// SetAcceptRanges sets the acceptRanges to the get image o k response
+func (o *GetImageOK) SetAcceptRanges(acceptRanges string) {
+	o.AcceptRanges = acceptRanges
+}
+
 // WithCacheControl adds the cacheControl to the get image o k response
 func (o *GetImageOK) WithCacheControl(cacheControl string) *GetImageOK {
 	o.CacheControl = cacheControl
@@ -55,6 +74,17 @@ func (o *GetImageOK) SetCacheControl(cacheControl string) {
 	o.CacheControl = cacheControl
 }
 
+// WithETag adds the eTag to the get image o k response
+func (o *GetImageOK) WithETag(eTag string) *GetImageOK {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the get image o k response
+func (o *GetImageOK) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
 // WithLastModified adds the lastModified to the get image o k response
 func (o *GetImageOK) WithLastModified(lastModified string) *GetImageOK {
 	o.LastModified = lastModified
@@ -80,6 +110,13 @@ func (o *GetImageOK) SetPayload(payload io.ReadCloser) {
 // WriteResponse to the client
 func (o *GetImageOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header Accept-Ranges
+
+	acceptRanges := o.AcceptRanges
+	if acceptRanges != "" {
+		rw.Header().Set("Accept-Ranges", acceptRanges)
+	}
+
 	// response header Cache-Control
 
 	cacheControl := o.CacheControl
@@ -87,6 +124,13 @@ func (o *GetImageOK) WriteResponse(rw http.ResponseWriter, producer runtime.Prod
 		rw.Header().Set("Cache-Control", cacheControl)
 	}
 
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
 	// response header Last-Modified
 
 	lastModified := o.LastModified
@@ -101,6 +145,93 @@ func (o *GetImageOK) WriteResponse(rw http.ResponseWriter, producer runtime.Prod
 	}
 }
 
+// GetImagePartialContentCode is the HTTP code returned for type GetImagePartialContent
+const GetImagePartialContentCode int = 206
+
+/*
+GetImagePartialContent The requested range of the image file.
+
+swagger:response getImagePartialContent
+*/
+type GetImagePartialContent struct {
+	/*
+
+	 */
+	ContentRange string `json:"Content-Range"`
+	/*
+
+	 */
+	ETag string `json:"ETag"`
+
+	/*
+	  In: Body
+	*/
+	Payload io.ReadCloser `json:"body,omitempty"`
+}
+
+// NewGetImagePartialContent creates GetImagePartialContent with default headers values
+func NewGetImagePartialContent() *GetImagePartialContent {
+
+	return &GetImagePartialContent{}
+}
+
+// WithContentRange adds the contentRange to the get image partial content response
+func (o *GetImagePartialContent) WithContentRange(contentRange string) *GetImagePartialContent {
+	o.ContentRange = contentRange
+	return o
+}
+
+// SetContentRange sets the contentRange to the get image partial content response
+func (o *GetImagePartialContent) SetContentRange(contentRange string) {
+	o.ContentRange = contentRange
+}
+
+// WithETag adds the eTag to the get image partial content response
+func (o *GetImagePartialContent) WithETag(eTag string) *GetImagePartialContent {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the get image partial content response
+func (o *GetImagePartialContent) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
+// WithPayload adds the payload to the get image partial content response
+func (o *GetImagePartialContent) WithPayload(payload io.ReadCloser) *GetImagePartialContent {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get image partial content response
+func (o *GetImagePartialContent) SetPayload(payload io.ReadCloser) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetImagePartialContent) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	// response header Content-Range
+
+	contentRange := o.ContentRange
+	if contentRange != "" {
+		rw.Header().Set("Content-Range", contentRange)
+	}
+
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
+	rw.WriteHeader(206)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
 // GetImageNotModifiedCode is the HTTP code returned for type GetImageNotModified
 const GetImageNotModifiedCode int = 304
 
@@ -126,6 +257,78 @@ func (o *GetImageNotModified) WriteResponse(rw http.ResponseWriter, producer run
 	rw.WriteHeader(304)
 }
 
+// GetImagePreconditionFailedCode is the HTTP code returned for type GetImagePreconditionFailed
+const GetImagePreconditionFailedCode int = 412
+
+/*
+GetImagePreconditionFailed The image doesn't satisfy If-Match or If-Unmodified-Since.
+
+swagger:response getImagePreconditionFailed
+*/
+type GetImagePreconditionFailed struct {
+}
+
+// NewGetImagePreconditionFailed creates GetImagePreconditionFailed with default headers values
+func NewGetImagePreconditionFailed() *GetImagePreconditionFailed {
+
+	return &GetImagePreconditionFailed{}
+}
+
+// WriteResponse to the client
+func (o *GetImagePreconditionFailed) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(412)
+}
+
+// GetImageRequestedRangeNotSatisfiableCode is the HTTP code returned for type GetImageRequestedRangeNotSatisfiable
+const GetImageRequestedRangeNotSatisfiableCode int = 416
+
+/*
+GetImageRequestedRangeNotSatisfiable The requested range is not satisfiable.
+
+swagger:response getImageRequestedRangeNotSatisfiable
+*/
+type GetImageRequestedRangeNotSatisfiable struct {
+	/*
+
+	 */
+	ContentRange string `json:"Content-Range"`
+}
+
+// NewGetImageRequestedRangeNotSatisfiable creates GetImageRequestedRangeNotSatisfiable with default headers values
+func NewGetImageRequestedRangeNotSatisfiable() *GetImageRequestedRangeNotSatisfiable {
+
+	return &GetImageRequestedRangeNotSatisfiable{}
+}
+
+// WithContentRange adds the contentRange to the get image requested range not satisfiable response
+func (o *GetImageRequestedRangeNotSatisfiable) WithContentRange(contentRange string) *GetImageRequestedRangeNotSatisfiable {
+	o.ContentRange = contentRange
+	return o
+}
+
+// SetContentRange sets the contentRange to the get image requested range not satisfiable response
+func (o *GetImageRequestedRangeNotSatisfiable) SetContentRange(contentRange string) {
+	o.ContentRange = contentRange
+}
+
+// WriteResponse to the client
+func (o *GetImageRequestedRangeNotSatisfiable) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	// response header Content-Range
+
+	contentRange := o.ContentRange
+	if contentRange != "" {
+		rw.Header().Set("Content-Range", contentRange)
+	}
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(416)
+}
+
 /*
 GetImageDefault Error Response
 
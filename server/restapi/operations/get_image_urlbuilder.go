@@ -10,11 +10,14 @@ import (
 	"net/url"
 	golangswaggerpaths "path"
 	"strings"
+
+	"github.com/go-openapi/swag"
 )
 
 // GetImageURL generates an URL for the get image operation
 type GetImageURL struct {
 	ID string
+	W  *int64
 
 	_basePath string
 	// avoid unkeyed usage
@@ -55,6 +58,18 @@ func (o *GetImageURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var wQ string
+	if o.W != nil {
+		wQ = swag.FormatInt64(*o.W)
+	}
+	if wQ != "" {
+		qs.Set("w", wQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -12,16 +12,16 @@ import (
 )
 
 // GetImagesHandlerFunc turns a function with the right signature into a get images handler
-type GetImagesHandlerFunc func(GetImagesParams) middleware.Responder
+type GetImagesHandlerFunc func(GetImagesParams, interface{}) middleware.Responder
 
 // Handle executing the request and returning a response
-func (fn GetImagesHandlerFunc) Handle(params GetImagesParams) middleware.Responder {
-	return fn(params)
+func (fn GetImagesHandlerFunc) Handle(params GetImagesParams, principal interface{}) middleware.Responder {
+	return fn(params, principal)
 }
 
 // GetImagesHandler interface for that can handle valid get images params
 type GetImagesHandler interface {
-	Handle(GetImagesParams) middleware.Responder
+	Handle(GetImagesParams, interface{}) middleware.Responder
 }
 
 // NewGetImages creates a new http.Handler for the get images operation
@@ -45,12 +45,25 @@ func (o *GetImages) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		*r = *rCtx
 	}
 	var Params = NewGetImagesParams()
+	uprinc, aCtx, err := o.Context.Authorize(r, route)
+	if err != nil {
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+	if aCtx != nil {
+		*r = *aCtx
+	}
+	var principal interface{}
+	if uprinc != nil {
+		principal = uprinc.(interface{}) // this is really a interface{}, I promise
+	}
+
 	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
 		o.Context.Respond(rw, r, route.Produces, route, err)
 		return
 	}
 
-	res := o.Handler.Handle(Params) // actually handle the request
+	res := o.Handler.Handle(Params, principal) // actually handle the request
 	o.Context.Respond(rw, r, route.Produces, route, res)
 
 }
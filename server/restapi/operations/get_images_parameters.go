@@ -8,6 +8,7 @@ package operations
 import (
 	"net/http"
 
+	"fmt"
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
@@ -23,11 +24,20 @@ func NewGetImagesParams() GetImagesParams {
 	var (
 		// initialize parameters with default values
 
-		orderDefault = string("desc")
+		facetSizeDefault = int64(10)
+		intervalDefault  = string("month")
+		orderDefault     = string("desc")
+		sortDefault      = string("created")
 	)
 
 	return GetImagesParams{
+		FacetSize: &facetSizeDefault,
+
+		Interval: &intervalDefault,
+
 		Order: &orderDefault,
+
+		Sort: &sortDefault,
 	}
 }
 
@@ -52,10 +62,37 @@ type GetImagesParams struct {
 	  In: query
 	*/
 	Checkpoint *string
+	/*Retrieving images in the collection of the given ID.
+	  In: query
+	*/
+	Collection *string
+	/*The maximum number of the most frequent terms of each facet, and the number of the latest periods of the created facet.
+	  In: query
+	  Default: 10
+	*/
+	FacetSize *int64
+	/*Facets counting all images matching the query, which are returned in facets of the response. The created facet is a histogram of creation dates.
+	  Collection Format: multi
+	  In: query
+	*/
+	Facets []string
+	/*Retrieving only favourite images if true.
+	  In: query
+	*/
+	Favorite *bool
+	/*The interval of periods of the created facet.
+	  In: query
+	  Default: "month"
+	*/
+	Interval *string
 	/*The number of items one page has at most.
 	  In: query
 	*/
 	Limit *int64
+	/*Retrieving images rated the given value or higher.
+	  In: query
+	*/
+	MinRating *int64
 	/*
 	  In: query
 	  Default: "desc"
@@ -65,6 +102,10 @@ type GetImagesParams struct {
 	  In: query
 	*/
 	Page *int64
+	/*Query string searching all indexed fields, e.g. prompt:"red dress" -negative:blurry steps:>30 rating:>=4. Annotations are searched with favorite:true, rating, tag and note. An invalid query string results in 400 with the position of the problem.
+	  In: query
+	*/
+	Q *string
 	/*Search query.
 	  In: query
 	*/
@@ -73,6 +114,16 @@ type GetImagesParams struct {
 	  In: query
 	*/
 	Size *string
+	/*The sort key. Images having the same rating are sorted by their creation times. Position sorts images in the order of the collection regardless of the order parameter, and requires the collection parameter.
+	  In: query
+	  Default: "created"
+	*/
+	Sort *string
+	/*Retrieving images having all the given tags.
+	  Collection Format: multi
+	  In: query
+	*/
+	Tag []string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -101,11 +152,41 @@ func (o *GetImagesParams) BindRequest(r *http.Request, route *middleware.Matched
 		res = append(res, err)
 	}
 
+	qCollection, qhkCollection, _ := qs.GetOK("collection")
+	if err := o.bindCollection(qCollection, qhkCollection, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qFacetSize, qhkFacetSize, _ := qs.GetOK("facetSize")
+	if err := o.bindFacetSize(qFacetSize, qhkFacetSize, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qFacets, qhkFacets, _ := qs.GetOK("facets")
+	if err := o.bindFacets(qFacets, qhkFacets, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qFavorite, qhkFavorite, _ := qs.GetOK("favorite")
+	if err := o.bindFavorite(qFavorite, qhkFavorite, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qInterval, qhkInterval, _ := qs.GetOK("interval")
+	if err := o.bindInterval(qInterval, qhkInterval, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qLimit, qhkLimit, _ := qs.GetOK("limit")
 	if err := o.bindLimit(qLimit, qhkLimit, route.Formats); err != nil {
 		res = append(res, err)
 	}
 
+	qMinRating, qhkMinRating, _ := qs.GetOK("minRating")
+	if err := o.bindMinRating(qMinRating, qhkMinRating, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qOrder, qhkOrder, _ := qs.GetOK("order")
 	if err := o.bindOrder(qOrder, qhkOrder, route.Formats); err != nil {
 		res = append(res, err)
@@ -116,6 +197,11 @@ func (o *GetImagesParams) BindRequest(r *http.Request, route *middleware.Matched
 		res = append(res, err)
 	}
 
+	qQ, qhkQ, _ := qs.GetOK("q")
+	if err := o.bindQ(qQ, qhkQ, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qQuery, qhkQuery, _ := qs.GetOK("query")
 	if err := o.bindQuery(qQuery, qhkQuery, route.Formats); err != nil {
 		res = append(res, err)
@@ -125,6 +211,16 @@ func (o *GetImagesParams) BindRequest(r *http.Request, route *middleware.Matched
 	if err := o.bindSize(qSize, qhkSize, route.Formats); err != nil {
 		res = append(res, err)
 	}
+
+	qSort, qhkSort, _ := qs.GetOK("sort")
+	if err := o.bindSort(qSort, qhkSort, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qTag, qhkTag, _ := qs.GetOK("tag")
+	if err := o.bindTag(qTag, qhkTag, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -223,6 +319,129 @@ func (o *GetImagesParams) bindCheckpoint(rawData []string, hasKey bool, formats
 	return nil
 }
 
+// bindCollection binds and validates parameter Collection from query.
+func (o *GetImagesParams) bindCollection(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.Collection = &raw
+
+	return nil
+}
+
+// bindFacetSize binds and validates parameter FacetSize from query.
+func (o *GetImagesParams) bindFacetSize(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetImagesParams()
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("facetSize", "query", "int64", raw)
+	}
+	o.FacetSize = &value
+
+	return nil
+}
+
+// bindFacets binds and validates array parameter Facets from query.
+//
+// Arrays are parsed according to CollectionFormat: "multi" (defaults to "csv" when empty).
+func (o *GetImagesParams) bindFacets(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	// CollectionFormat: multi
+	facetsIC := rawData
+	if len(facetsIC) == 0 {
+		return nil
+	}
+
+	var facetsIR []string
+	for i, facetsIV := range facetsIC {
+		facetsI := facetsIV
+		if err := validate.EnumCase(fmt.Sprintf("%s.%v", "facets", i), "query", facetsI, []interface{}{"checkpoint", "sampler", "lora", "size", "created"}, true); err != nil {
+			return err
+		}
+
+		facetsIR = append(facetsIR, facetsI)
+	}
+
+	o.Facets = facetsIR
+
+	return nil
+}
+
+// bindFavorite binds and validates parameter Favorite from query.
+func (o *GetImagesParams) bindFavorite(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("favorite", "query", "bool", raw)
+	}
+	o.Favorite = &value
+
+	return nil
+}
+
+// bindInterval binds and validates parameter Interval from query.
+func (o *GetImagesParams) bindInterval(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetImagesParams()
+		return nil
+	}
+	o.Interval = &raw
+
+	if err := o.validateInterval(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateInterval carries on validations for parameter Interval
+func (o *GetImagesParams) validateInterval(formats strfmt.Registry) error {
+
+	if err := validate.EnumCase("interval", "query", *o.Interval, []interface{}{"day", "week", "month"}, true); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // bindLimit binds and validates parameter Limit from query.
 func (o *GetImagesParams) bindLimit(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -243,6 +462,61 @@ func (o *GetImagesParams) bindLimit(rawData []string, hasKey bool, formats strfm
 	}
 	o.Limit = &value
 
+	if err := o.validateLimit(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateLimit carries on validations for parameter Limit
+func (o *GetImagesParams) validateLimit(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("limit", "query", *o.Limit, 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindMinRating binds and validates parameter MinRating from query.
+func (o *GetImagesParams) bindMinRating(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("minRating", "query", "int64", raw)
+	}
+	o.MinRating = &value
+
+	if err := o.validateMinRating(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateMinRating carries on validations for parameter MinRating
+func (o *GetImagesParams) validateMinRating(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("minRating", "query", *o.MinRating, 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("minRating", "query", *o.MinRating, 5, false); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -299,6 +573,38 @@ func (o *GetImagesParams) bindPage(rawData []string, hasKey bool, formats strfmt
 	}
 	o.Page = &value
 
+	if err := o.validatePage(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validatePage carries on validations for parameter Page
+func (o *GetImagesParams) validatePage(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("page", "query", *o.Page, 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindQ binds and validates parameter Q from query.
+func (o *GetImagesParams) bindQ(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.Q = &raw
+
 	return nil
 }
 
@@ -351,3 +657,58 @@ func (o *GetImagesParams) validateSize(formats strfmt.Registry) error {
 
 	return nil
 }
+
+// bindSort binds and validates parameter Sort from query.
+func (o *GetImagesParams) bindSort(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetImagesParams()
+		return nil
+	}
+	o.Sort = &raw
+
+	if err := o.validateSort(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateSort carries on validations for parameter Sort
+func (o *GetImagesParams) validateSort(formats strfmt.Registry) error {
+
+	if err := validate.EnumCase("sort", "query", *o.Sort, []interface{}{"created", "rating", "position"}, true); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindTag binds and validates array parameter Tag from query.
+//
+// Arrays are parsed according to CollectionFormat: "multi" (defaults to "csv" when empty).
+func (o *GetImagesParams) bindTag(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	// CollectionFormat: multi
+	tagIC := rawData
+	if len(tagIC) == 0 {
+		return nil
+	}
+
+	var tagIR []string
+	for _, tagIV := range tagIC {
+		tagI := tagIV
+
+		tagIR = append(tagIR, tagI)
+	}
+
+	o.Tag = tagIR
+
+	return nil
+}
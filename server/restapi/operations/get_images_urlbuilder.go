@@ -19,11 +19,20 @@ type GetImagesURL struct {
 	After      *strfmt.DateTime
 	Before     *strfmt.DateTime
 	Checkpoint *string
+	Collection *string
+	FacetSize  *int64
+	Facets     []string
+	Favorite   *bool
+	Interval   *string
 	Limit      *int64
+	MinRating  *int64
 	Order      *string
 	Page       *int64
+	Q          *string
 	Query      *string
 	Size       *string
+	Sort       *string
+	Tag        []string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -83,6 +92,52 @@ func (o *GetImagesURL) Build() (*url.URL, error) {
 		qs.Set("checkpoint", checkpointQ)
 	}
 
+	var collectionQ string
+	if o.Collection != nil {
+		collectionQ = *o.Collection
+	}
+	if collectionQ != "" {
+		qs.Set("collection", collectionQ)
+	}
+
+	var facetSizeQ string
+	if o.FacetSize != nil {
+		facetSizeQ = swag.FormatInt64(*o.FacetSize)
+	}
+	if facetSizeQ != "" {
+		qs.Set("facetSize", facetSizeQ)
+	}
+
+	var facetsIR []string
+	for _, facetsI := range o.Facets {
+		facetsIS := facetsI
+		if facetsIS != "" {
+			facetsIR = append(facetsIR, facetsIS)
+		}
+	}
+
+	facets := swag.JoinByFormat(facetsIR, "multi")
+
+	for _, qsv := range facets {
+		qs.Add("facets", qsv)
+	}
+
+	var favoriteQ string
+	if o.Favorite != nil {
+		favoriteQ = swag.FormatBool(*o.Favorite)
+	}
+	if favoriteQ != "" {
+		qs.Set("favorite", favoriteQ)
+	}
+
+	var intervalQ string
+	if o.Interval != nil {
+		intervalQ = *o.Interval
+	}
+	if intervalQ != "" {
+		qs.Set("interval", intervalQ)
+	}
+
 	var limitQ string
 	if o.Limit != nil {
 		limitQ = swag.FormatInt64(*o.Limit)
@@ -91,6 +146,14 @@ func (o *GetImagesURL) Build() (*url.URL, error) {
 		qs.Set("limit", limitQ)
 	}
 
+	var minRatingQ string
+	if o.MinRating != nil {
+		minRatingQ = swag.FormatInt64(*o.MinRating)
+	}
+	if minRatingQ != "" {
+		qs.Set("minRating", minRatingQ)
+	}
+
 	var orderQ string
 	if o.Order != nil {
 		orderQ = *o.Order
@@ -107,6 +170,14 @@ func (o *GetImagesURL) Build() (*url.URL, error) {
 		qs.Set("page", pageQ)
 	}
 
+	var qQ string
+	if o.Q != nil {
+		qQ = *o.Q
+	}
+	if qQ != "" {
+		qs.Set("q", qQ)
+	}
+
 	var queryQ string
 	if o.Query != nil {
 		queryQ = *o.Query
@@ -123,6 +194,28 @@ func (o *GetImagesURL) Build() (*url.URL, error) {
 		qs.Set("size", sizeQ)
 	}
 
+	var sortQ string
+	if o.Sort != nil {
+		sortQ = *o.Sort
+	}
+	if sortQ != "" {
+		qs.Set("sort", sortQ)
+	}
+
+	var tagIR []string
+	for _, tagI := range o.Tag {
+		tagIS := tagI
+		if tagIS != "" {
+			tagIR = append(tagIR, tagIS)
+		}
+	}
+
+	tag := swag.JoinByFormat(tagIR, "multi")
+
+	for _, qsv := range tag {
+		qs.Add("tag", qsv)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
@@ -41,17 +41,114 @@ func NewSdImageViewerAPI(spec *loads.Document) *SdImageViewerAPI {
 		JSONConsumer: runtime.JSONConsumer(),
 
 		BinProducer:  runtime.ByteStreamProducer(),
+		CSVProducer:  runtime.CSVProducer(),
 		JSONProducer: runtime.JSONProducer(),
+		TxtProducer:  runtime.TextProducer(),
 
-		GetCheckpointsHandler: GetCheckpointsHandlerFunc(func(params GetCheckpointsParams) middleware.Responder {
+		AddCollectionImagesHandler: AddCollectionImagesHandlerFunc(func(params AddCollectionImagesParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation AddCollectionImages has not yet been implemented")
+		}),
+		CreateCollectionHandler: CreateCollectionHandlerFunc(func(params CreateCollectionParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation CreateCollection has not yet been implemented")
+		}),
+		CreateShareHandler: CreateShareHandlerFunc(func(params CreateShareParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation CreateShare has not yet been implemented")
+		}),
+		DeleteCollectionHandler: DeleteCollectionHandlerFunc(func(params DeleteCollectionParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation DeleteCollection has not yet been implemented")
+		}),
+		DeleteImageHandler: DeleteImageHandlerFunc(func(params DeleteImageParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation DeleteImage has not yet been implemented")
+		}),
+		DeleteImagesHandler: DeleteImagesHandlerFunc(func(params DeleteImagesParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation DeleteImages has not yet been implemented")
+		}),
+		DownloadImagesHandler: DownloadImagesHandlerFunc(func(params DownloadImagesParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation DownloadImages has not yet been implemented")
+		}),
+		ExportImagesHandler: ExportImagesHandlerFunc(func(params ExportImagesParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation ExportImages has not yet been implemented")
+		}),
+		GetCheckpointsHandler: GetCheckpointsHandlerFunc(func(params GetCheckpointsParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation GetCheckpoints has not yet been implemented")
 		}),
-		GetImageHandler: GetImageHandlerFunc(func(params GetImageParams) middleware.Responder {
+		GetCollectionHandler: GetCollectionHandlerFunc(func(params GetCollectionParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation GetCollection has not yet been implemented")
+		}),
+		GetCollectionsHandler: GetCollectionsHandlerFunc(func(params GetCollectionsParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation GetCollections has not yet been implemented")
+		}),
+		GetDuplicatesHandler: GetDuplicatesHandlerFunc(func(params GetDuplicatesParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation GetDuplicates has not yet been implemented")
+		}),
+		GetFailuresHandler: GetFailuresHandlerFunc(func(params GetFailuresParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation GetFailures has not yet been implemented")
+		}),
+		GetImageHandler: GetImageHandlerFunc(func(params GetImageParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation GetImage has not yet been implemented")
 		}),
-		GetImagesHandler: GetImagesHandlerFunc(func(params GetImagesParams) middleware.Responder {
+		GetImageDetailHandler: GetImageDetailHandlerFunc(func(params GetImageDetailParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation GetImageDetail has not yet been implemented")
+		}),
+		GetImagesHandler: GetImagesHandlerFunc(func(params GetImagesParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation GetImages has not yet been implemented")
 		}),
+		GetRelatedImagesHandler: GetRelatedImagesHandlerFunc(func(params GetRelatedImagesParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation GetRelatedImages has not yet been implemented")
+		}),
+		GetSimilarImagesHandler: GetSimilarImagesHandlerFunc(func(params GetSimilarImagesParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation GetSimilarImages has not yet been implemented")
+		}),
+		GetStatsHandler: GetStatsHandlerFunc(func(params GetStatsParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation GetStats has not yet been implemented")
+		}),
+		GetSuggestionsHandler: GetSuggestionsHandlerFunc(func(params GetSuggestionsParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation GetSuggestions has not yet been implemented")
+		}),
+		GetTrashHandler: GetTrashHandlerFunc(func(params GetTrashParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation GetTrash has not yet been implemented")
+		}),
+		HeadImageHandler: HeadImageHandlerFunc(func(params HeadImageParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation HeadImage has not yet been implemented")
+		}),
+		LoginHandler: LoginHandlerFunc(func(params LoginParams) middleware.Responder {
+			return middleware.NotImplemented("operation Login has not yet been implemented")
+		}),
+		LogoutHandler: LogoutHandlerFunc(func(params LogoutParams) middleware.Responder {
+			return middleware.NotImplemented("operation Logout has not yet been implemented")
+		}),
+		PutAnnotationHandler: PutAnnotationHandlerFunc(func(params PutAnnotationParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation PutAnnotation has not yet been implemented")
+		}),
+		RemoveCollectionImagesHandler: RemoveCollectionImagesHandlerFunc(func(params RemoveCollectionImagesParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation RemoveCollectionImages has not yet been implemented")
+		}),
+		RestoreTrashHandler: RestoreTrashHandlerFunc(func(params RestoreTrashParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation RestoreTrash has not yet been implemented")
+		}),
+		SetCollectionImagesHandler: SetCollectionImagesHandlerFunc(func(params SetCollectionImagesParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation SetCollectionImages has not yet been implemented")
+		}),
+		UpdateCollectionHandler: UpdateCollectionHandlerFunc(func(params UpdateCollectionParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation UpdateCollection has not yet been implemented")
+		}),
+
+		// Applies when the "Cookie" header is set
+		SessionAuth: func(token string) (interface{}, error) {
+			return nil, errors.NotImplemented("api key auth (session) Cookie from header param [Cookie] has not yet been implemented")
+		},
+
+		// Applies when the "share" query is set
+		ShareAuth: func(token string) (interface{}, error) {
+			return nil, errors.NotImplemented("api key auth (share) share from query param [share] has not yet been implemented")
+		},
+
+		// Applies when the "Authorization" header is set
+		TokenAuth: func(token string) (interface{}, error) {
+			return nil, errors.NotImplemented("api key auth (token) Authorization from header param [Authorization] has not yet been implemented")
+		},
+		// default authorizer is authorized meaning no requirements
+		APIAuthorizer: security.Authorized(),
 	}
 }
 
@@ -85,18 +182,94 @@ type SdImageViewerAPI struct {
 	JSONConsumer runtime.Consumer
 
 	// BinProducer registers a producer for the following mime types:
+	//   - application/zip
+	//   - application/x-ndjson
 	//   - image/png
+	//   - image/jpeg
 	BinProducer runtime.Producer
+	// CSVProducer registers a producer for the following mime types:
+	//   - text/csv
+	CSVProducer runtime.Producer
 	// JSONProducer registers a producer for the following mime types:
 	//   - application/json
 	JSONProducer runtime.Producer
-
+	// TxtProducer registers a producer for the following mime types:
+	//   - text/plain
+	TxtProducer runtime.Producer
+
+	// SessionAuth registers a function that takes a token and returns a principal
+	// it performs authentication based on an api key Cookie provided in the header
+	SessionAuth func(string) (interface{}, error)
+
+	// ShareAuth registers a function that takes a token and returns a principal
+	// it performs authentication based on an api key share provided in the query
+	ShareAuth func(string) (interface{}, error)
+
+	// TokenAuth registers a function that takes a token and returns a principal
+	// it performs authentication based on an api key Authorization provided in the header
+	TokenAuth func(string) (interface{}, error)
+
+	// APIAuthorizer provides access control (ACL/RBAC/ABAC) by providing access to the request and authenticated principal
+	APIAuthorizer runtime.Authorizer
+
+	// AddCollectionImagesHandler sets the operation handler for the add collection images operation
+	AddCollectionImagesHandler AddCollectionImagesHandler
+	// CreateCollectionHandler sets the operation handler for the create collection operation
+	CreateCollectionHandler CreateCollectionHandler
+	// CreateShareHandler sets the operation handler for the create share operation
+	CreateShareHandler CreateShareHandler
+	// DeleteCollectionHandler sets the operation handler for the delete collection operation
+	DeleteCollectionHandler DeleteCollectionHandler
+	// DeleteImageHandler sets the operation handler for the delete image operation
+	DeleteImageHandler DeleteImageHandler
+	// DeleteImagesHandler sets the operation handler for the delete images operation
+	DeleteImagesHandler DeleteImagesHandler
+	// DownloadImagesHandler sets the operation handler for the download images operation
+	DownloadImagesHandler DownloadImagesHandler
+	// ExportImagesHandler sets the operation handler for the export images operation
+	ExportImagesHandler ExportImagesHandler
 	// GetCheckpointsHandler sets the operation handler for the get checkpoints operation
 	GetCheckpointsHandler GetCheckpointsHandler
+	// GetCollectionHandler sets the operation handler for the get collection operation
+	GetCollectionHandler GetCollectionHandler
+	// GetCollectionsHandler sets the operation handler for the get collections operation
+	GetCollectionsHandler GetCollectionsHandler
+	// GetDuplicatesHandler sets the operation handler for the get duplicates operation
+	GetDuplicatesHandler GetDuplicatesHandler
+	// GetFailuresHandler sets the operation handler for the get failures operation
+	GetFailuresHandler GetFailuresHandler
 	// GetImageHandler sets the operation handler for the get image operation
 	GetImageHandler GetImageHandler
+	// GetImageDetailHandler sets the operation handler for the get image detail operation
+	GetImageDetailHandler GetImageDetailHandler
 	// GetImagesHandler sets the operation handler for the get images operation
 	GetImagesHandler GetImagesHandler
+	// GetRelatedImagesHandler sets the operation handler for the get related images operation
+	GetRelatedImagesHandler GetRelatedImagesHandler
+	// GetSimilarImagesHandler sets the operation handler for the get similar images operation
+	GetSimilarImagesHandler GetSimilarImagesHandler
+	// GetStatsHandler sets the operation handler for the get stats operation
+	GetStatsHandler GetStatsHandler
+	// GetSuggestionsHandler sets the operation handler for the get suggestions operation
+	GetSuggestionsHandler GetSuggestionsHandler
+	// GetTrashHandler sets the operation handler for the get trash operation
+	GetTrashHandler GetTrashHandler
+	// HeadImageHandler sets the operation handler for the head image operation
+	HeadImageHandler HeadImageHandler
+	// LoginHandler sets the operation handler for the login operation
+	LoginHandler LoginHandler
+	// LogoutHandler sets the operation handler for the logout operation
+	LogoutHandler LogoutHandler
+	// PutAnnotationHandler sets the operation handler for the put annotation operation
+	PutAnnotationHandler PutAnnotationHandler
+	// RemoveCollectionImagesHandler sets the operation handler for the remove collection images operation
+	RemoveCollectionImagesHandler RemoveCollectionImagesHandler
+	// RestoreTrashHandler sets the operation handler for the restore trash operation
+	RestoreTrashHandler RestoreTrashHandler
+	// SetCollectionImagesHandler sets the operation handler for the set collection images operation
+	SetCollectionImagesHandler SetCollectionImagesHandler
+	// UpdateCollectionHandler sets the operation handler for the update collection operation
+	UpdateCollectionHandler UpdateCollectionHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -173,19 +346,113 @@ func (o *SdImageViewerAPI) Validate() error {
 	if o.BinProducer == nil {
 		unregistered = append(unregistered, "BinProducer")
 	}
+	if o.CSVProducer == nil {
+		unregistered = append(unregistered, "CSVProducer")
+	}
 	if o.JSONProducer == nil {
 		unregistered = append(unregistered, "JSONProducer")
 	}
+	if o.TxtProducer == nil {
+		unregistered = append(unregistered, "TxtProducer")
+	}
 
+	if o.SessionAuth == nil {
+		unregistered = append(unregistered, "SessionAuth")
+	}
+	if o.ShareAuth == nil {
+		unregistered = append(unregistered, "ShareAuth")
+	}
+	if o.TokenAuth == nil {
+		unregistered = append(unregistered, "TokenAuth")
+	}
+
+	if o.AddCollectionImagesHandler == nil {
+		unregistered = append(unregistered, "AddCollectionImagesHandler")
+	}
+	if o.CreateCollectionHandler == nil {
+		unregistered = append(unregistered, "CreateCollectionHandler")
+	}
+	if o.CreateShareHandler == nil {
+		unregistered = append(unregistered, "CreateShareHandler")
+	}
+	if o.DeleteCollectionHandler == nil {
+		unregistered = append(unregistered, "DeleteCollectionHandler")
+	}
+	if o.DeleteImageHandler == nil {
+		unregistered = append(unregistered, "DeleteImageHandler")
+	}
+	if o.DeleteImagesHandler == nil {
+		unregistered = append(unregistered, "DeleteImagesHandler")
+	}
+	if o.DownloadImagesHandler == nil {
+		unregistered = append(unregistered, "DownloadImagesHandler")
+	}
+	if o.ExportImagesHandler == nil {
+		unregistered = append(unregistered, "ExportImagesHandler")
+	}
 	if o.GetCheckpointsHandler == nil {
 		unregistered = append(unregistered, "GetCheckpointsHandler")
 	}
+	if o.GetCollectionHandler == nil {
+		unregistered = append(unregistered, "GetCollectionHandler")
+	}
+	if o.GetCollectionsHandler == nil {
+		unregistered = append(unregistered, "GetCollectionsHandler")
+	}
+	if o.GetDuplicatesHandler == nil {
+		unregistered = append(unregistered, "GetDuplicatesHandler")
+	}
+	if o.GetFailuresHandler == nil {
+		unregistered = append(unregistered, "GetFailuresHandler")
+	}
 	if o.GetImageHandler == nil {
 		unregistered = append(unregistered, "GetImageHandler")
 	}
+	if o.GetImageDetailHandler == nil {
+		unregistered = append(unregistered, "GetImageDetailHandler")
+	}
 	if o.GetImagesHandler == nil {
 		unregistered = append(unregistered, "GetImagesHandler")
 	}
+	if o.GetRelatedImagesHandler == nil {
+		unregistered = append(unregistered, "GetRelatedImagesHandler")
+	}
+	if o.GetSimilarImagesHandler == nil {
+		unregistered = append(unregistered, "GetSimilarImagesHandler")
+	}
+	if o.GetStatsHandler == nil {
+		unregistered = append(unregistered, "GetStatsHandler")
+	}
+	if o.GetSuggestionsHandler == nil {
+		unregistered = append(unregistered, "GetSuggestionsHandler")
+	}
+	if o.GetTrashHandler == nil {
+		unregistered = append(unregistered, "GetTrashHandler")
+	}
+	if o.HeadImageHandler == nil {
+		unregistered = append(unregistered, "HeadImageHandler")
+	}
+	if o.LoginHandler == nil {
+		unregistered = append(unregistered, "LoginHandler")
+	}
+	if o.LogoutHandler == nil {
+		unregistered = append(unregistered, "LogoutHandler")
+	}
+	if o.PutAnnotationHandler == nil {
+		unregistered = append(unregistered, "PutAnnotationHandler")
+	}
+	if o.RemoveCollectionImagesHandler == nil {
+		unregistered = append(unregistered, "RemoveCollectionImagesHandler")
+	}
+	if o.RestoreTrashHandler == nil {
+		unregistered = append(unregistered, "RestoreTrashHandler")
+	}
+	if o.SetCollectionImagesHandler == nil {
+		unregistered = append(unregistered, "SetCollectionImagesHandler")
+	}
+	if o.UpdateCollectionHandler == nil {
+		unregistered = append(unregistered, "UpdateCollectionHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -201,12 +468,29 @@ func (o *SdImageViewerAPI) ServeErrorFor(operationID string) func(http.ResponseW
 
 // AuthenticatorsFor gets the authenticators for the specified security schemes
 func (o *SdImageViewerAPI) AuthenticatorsFor(schemes map[string]spec.SecurityScheme) map[string]runtime.Authenticator {
-	return nil
+	result := make(map[string]runtime.Authenticator)
+	for name := range schemes {
+		switch name {
+		case "session":
+			scheme := schemes[name]
+			result[name] = o.APIKeyAuthenticator(scheme.Name, scheme.In, o.SessionAuth)
+
+		case "share":
+			scheme := schemes[name]
+			result[name] = o.APIKeyAuthenticator(scheme.Name, scheme.In, o.ShareAuth)
+
+		case "token":
+			scheme := schemes[name]
+			result[name] = o.APIKeyAuthenticator(scheme.Name, scheme.In, o.TokenAuth)
+
+		}
+	}
+	return result
 }
 
 // Authorizer returns the registered authorizer
 func (o *SdImageViewerAPI) Authorizer() runtime.Authorizer {
-	return nil
+	return o.APIAuthorizer
 }
 
 // ConsumersFor gets the consumers for the specified media types.
@@ -232,10 +516,20 @@ func (o *SdImageViewerAPI) ProducersFor(mediaTypes []string) map[string]runtime.
 	result := make(map[string]runtime.Producer, len(mediaTypes))
 	for _, mt := range mediaTypes {
 		switch mt {
-		case "image/png":
-			result["image/png"] = o.BinProducer
+		case "application/zip":
+			result["application/zip"] = o.BinProducer
 		case "application/json":
 			result["application/json"] = o.JSONProducer
+		case "application/x-ndjson":
+			result["application/x-ndjson"] = o.BinProducer
+		case "text/csv":
+			result["text/csv"] = o.CSVProducer
+		case "text/plain":
+			result["text/plain"] = o.TxtProducer
+		case "image/png":
+			result["image/png"] = o.BinProducer
+		case "image/jpeg":
+			result["image/jpeg"] = o.BinProducer
 		}
 
 		if p, ok := o.customProducers[mt]; ok {
@@ -276,6 +570,38 @@ func (o *SdImageViewerAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/collections/{id}/images"] = NewAddCollectionImages(o.context, o.AddCollectionImagesHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/collections"] = NewCreateCollection(o.context, o.CreateCollectionHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/shares"] = NewCreateShare(o.context, o.CreateShareHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/collections/{id}"] = NewDeleteCollection(o.context, o.DeleteCollectionHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/images/{id}"] = NewDeleteImage(o.context, o.DeleteImageHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/images"] = NewDeleteImages(o.context, o.DeleteImagesHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/downloads"] = NewDownloadImages(o.context, o.DownloadImagesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/exports"] = NewExportImages(o.context, o.ExportImagesHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -283,11 +609,83 @@ func (o *SdImageViewerAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/collections/{id}"] = NewGetCollection(o.context, o.GetCollectionHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/collections"] = NewGetCollections(o.context, o.GetCollectionsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/duplicates"] = NewGetDuplicates(o.context, o.GetDuplicatesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/index/failures"] = NewGetFailures(o.context, o.GetFailuresHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/image/{id}"] = NewGetImage(o.context, o.GetImageHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/images/{id}"] = NewGetImageDetail(o.context, o.GetImageDetailHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/images"] = NewGetImages(o.context, o.GetImagesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/images/{id}/related"] = NewGetRelatedImages(o.context, o.GetRelatedImagesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/images/{id}/similar"] = NewGetSimilarImages(o.context, o.GetSimilarImagesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/stats"] = NewGetStats(o.context, o.GetStatsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/suggest"] = NewGetSuggestions(o.context, o.GetSuggestionsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/trash"] = NewGetTrash(o.context, o.GetTrashHandler)
+	if o.handlers["HEAD"] == nil {
+		o.handlers["HEAD"] = make(map[string]http.Handler)
+	}
+	o.handlers["HEAD"]["/image/{id}"] = NewHeadImage(o.context, o.HeadImageHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/login"] = NewLogin(o.context, o.LoginHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/logout"] = NewLogout(o.context, o.LogoutHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/images/{id}/annotations"] = NewPutAnnotation(o.context, o.PutAnnotationHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/collections/{id}/images"] = NewRemoveCollectionImages(o.context, o.RemoveCollectionImagesHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/trash/restore"] = NewRestoreTrash(o.context, o.RestoreTrashHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/collections/{id}/images"] = NewSetCollectionImages(o.context, o.SetCollectionImagesHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/collections/{id}"] = NewUpdateCollection(o.context, o.UpdateCollectionHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
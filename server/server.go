@@ -9,6 +9,8 @@
 package server
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"log"
 	"mime"
@@ -18,18 +20,23 @@ import (
 	"strings"
 	"time"
 
-	"github.com/blevesearch/bleve/v2"
-	"github.com/blevesearch/bleve/v2/search/query"
 	"github.com/go-openapi/loads"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 
+	"github.com/jkawamoto/sd-image-viewer/auth"
+	"github.com/jkawamoto/sd-image-viewer/catalog"
 	"github.com/jkawamoto/sd-image-viewer/frontend"
 	"github.com/jkawamoto/sd-image-viewer/image"
 	"github.com/jkawamoto/sd-image-viewer/server/models"
 	"github.com/jkawamoto/sd-image-viewer/server/restapi"
 	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+	"github.com/jkawamoto/sd-image-viewer/source"
+	"github.com/jkawamoto/sd-image-viewer/thumbnail"
+	"github.com/jkawamoto/sd-image-viewer/trash"
+	"github.com/jkawamoto/sd-image-viewer/userdata"
 )
 
 const (
@@ -39,20 +46,51 @@ const (
 
 var gmt = time.FixedZone("GMT", 0)
 
+// NewServer creates a server. Thumbnails of images are stored in thumbs, and annotations and collections are stored in
+// data. Deleted images are moved to bin, and deleting images is disabled if it is nil. Requests are authenticated with
+// users in the given store if any users are registered. Requests are logged to accessLogger, and the others are logged
+// to logger.
 func NewServer(
-	host string, port int, index bleve.Index, pathPrefix string, logger *log.Logger,
+	host string, port int, c catalog.Catalog, src source.Source, thumbs *thumbnail.Cache, users *auth.Store,
+	data *userdata.Store, bin *trash.Trash, logger, accessLogger *log.Logger,
 ) (*restapi.Server, error) {
-	query.SetLog(logger)
-
 	swaggerSpec, err := loads.Embedded(restapi.SwaggerJSON, restapi.FlatSwaggerJSON)
 	if err != nil {
 		return nil, err
 	}
 
 	api := operations.NewSdImageViewerAPI(swaggerSpec)
-	api.GetImageHandler = GetImageHandler(pathPrefix, logger)
-	api.GetImagesHandler = GetImagesHandler(index, pathPrefix, logger)
-	api.GetCheckpointsHandler = GetCheckpointsHandler(index, logger)
+	buffers := newBufferSlots(maxBufferedFiles)
+	api.GetImageHandler = GetImageHandler(c, src, thumbs, buffers, logger)
+	api.HeadImageHandler = HeadImageHandler(c, src, thumbs, buffers, logger)
+	api.GetImagesHandler = GetImagesHandler(c, data, logger)
+	api.GetImageDetailHandler = GetImageDetailHandler(c, data, logger)
+	api.PutAnnotationHandler = PutAnnotationHandler(c, src, data, logger)
+	api.DownloadImagesHandler = DownloadImagesHandler(c, src, data, logger)
+	api.ExportImagesHandler = ExportImagesHandler(c, data, logger)
+	api.GetSimilarImagesHandler = GetSimilarImagesHandler(c, logger)
+	api.GetDuplicatesHandler = GetDuplicatesHandler(c, logger)
+	api.GetRelatedImagesHandler = GetRelatedImagesHandler(c, logger)
+	api.GetSuggestionsHandler = GetSuggestionsHandler(c, logger)
+	api.GetStatsHandler = GetStatsHandler(c, data, logger)
+	api.DeleteImageHandler = DeleteImageHandler(c, bin, logger)
+	api.DeleteImagesHandler = DeleteImagesHandler(c, bin, logger)
+	api.GetTrashHandler = GetTrashHandler(bin, logger)
+	api.RestoreTrashHandler = RestoreTrashHandler(c, src, data, bin, logger)
+	api.GetCollectionsHandler = GetCollectionsHandler(data, logger)
+	api.CreateCollectionHandler = CreateCollectionHandler(data, logger)
+	api.GetCollectionHandler = GetCollectionHandler(data, logger)
+	api.UpdateCollectionHandler = UpdateCollectionHandler(data, logger)
+	api.DeleteCollectionHandler = DeleteCollectionHandler(data, logger)
+	api.AddCollectionImagesHandler = AddCollectionImagesHandler(c, data, logger)
+	api.SetCollectionImagesHandler = SetCollectionImagesHandler(c, data, logger)
+	api.RemoveCollectionImagesHandler = RemoveCollectionImagesHandler(data, logger)
+	api.GetCheckpointsHandler = GetCheckpointsHandler(c, logger)
+	api.GetFailuresHandler = GetFailuresHandler(c, logger)
+	api.LoginHandler = LoginHandler(users, newLoginThrottle(), logger)
+	api.LogoutHandler = LogoutHandler()
+	api.CreateShareHandler = CreateShareHandler(users, c, logger)
+	configureAuth(api, users, c)
 	api.Logger = logger.Printf
 
 	server := restapi.NewServer(api)
@@ -61,6 +99,7 @@ func NewServer(
 	server.KeepAlive = 3 * time.Minute
 	server.ReadTimeout = 30 * time.Second
 	server.WriteTimeout = 60 * time.Second
+	server.GracefulTimeout = 15 * time.Second
 	server.ConfigureAPI()
 
 	mux := http.NewServeMux()
@@ -94,98 +133,223 @@ func NewServer(
 	}))
 	mux.Handle("/api/v1/", server.GetHandler())
 
-	server.SetHandler(withLogger(mux, logger))
+	server.SetHandler(withLogger(mux, accessLogger))
 
 	return server, nil
 }
 
-func GetImageHandler(pathPrefix string, logger *log.Logger) operations.GetImageHandlerFunc {
-	return func(params operations.GetImageParams) middleware.Responder {
-		name := filepath.Join(pathPrefix, params.ID)
-
-		info, err := os.Stat(name)
-		if os.IsNotExist(err) {
-			logger.Printf("Requested file doesn't exist: %v", err)
-			return operations.NewGetImageDefault(http.StatusNotFound).WithPayload(&models.StandardError{
-				Message: swag.String(err.Error()),
-			})
-		} else if err != nil {
-			logger.Printf("Failed to stat the requested file: %v", err)
-			return operations.NewGetImageDefault(http.StatusInternalServerError).WithPayload(&models.StandardError{
+func GetImagesHandler(c catalog.Catalog, data *userdata.Store, logger *log.Logger) operations.GetImagesHandlerFunc {
+	return func(params operations.GetImagesParams, _ interface{}) middleware.Responder {
+		filter, err := newFilter(params.Query, params.Q, params.Size, params.Checkpoint, params.Before, params.After)
+		if err != nil {
+			return operations.NewGetImagesDefault(http.StatusBadRequest).WithPayload(queryError(err))
+		}
+		setAnnotationFilter(&filter, params.Favorite, params.MinRating, params.Tag)
+		if code, err := setCollectionFilter(data, &filter, params.Collection, params.Sort); err != nil {
+			return operations.NewGetImagesDefault(code).WithPayload(&models.StandardError{
 				Message: swag.String(err.Error()),
 			})
 		}
 
-		if since := swag.StringValue(params.IfModifiedSince); since != "" {
-			t, err := time.Parse(time.RFC1123, since)
-			if err != nil {
-				logger.Printf("Failed to parse the given If-Modified-Since header value: %v", err)
-			} else if !info.ModTime().After(t) {
-				return operations.NewGetImageNotModified()
-			}
+		page := int(swag.Int64Value(params.Page))
+		limit := defaultLimit
+		if params.Limit != nil {
+			limit = int(swag.Int64Value(params.Limit))
+		}
+
+		req := &catalog.SearchRequest{
+			Filter: filter,
+			Offset: limit * page,
+			Limit:  limit,
+			Sort:   swag.StringValue(params.Sort),
+		}
+		if swag.StringValue(params.Order) == "asc" {
+			req.Order = catalog.Ascending
+		}
+		for _, name := range params.Facets {
+			req.Facets = append(req.Facets, &catalog.FacetRequest{
+				Name:     name,
+				Size:     int(swag.Int64Value(params.FacetSize)),
+				Interval: swag.StringValue(params.Interval),
+			})
 		}
 
-		f, err := os.Open(name)
+		res, err := c.Search(params.HTTPRequest.Context(), req)
 		if err != nil {
-			logger.Printf("Failed to open the requested file: %v", err)
+			logger.Printf("Failed to search images: %v", err)
 			return operations.NewGetImagesDefault(http.StatusInternalServerError).WithPayload(&models.StandardError{
 				Message: swag.String(err.Error()),
 			})
 		}
 
-		return operations.NewGetImageOK().
-			WithPayload(f).
-			WithCacheControl(cacheMaxAge).
-			WithLastModified(info.ModTime().In(gmt).Format(time.RFC1123))
+		items := make([]*models.Image, len(res.Hits))
+		for i, v := range res.Hits {
+			items[i] = toImage(v.ID, v.Image)
+		}
+
+		return operations.NewGetImagesOK().WithPayload(&models.ImageList{
+			Items: items,
+			Metadata: &models.Metadata{
+				CurrentPage: swag.Int64(int64(page)),
+				TotalItems:  swag.Int64(int64(res.Total)),
+				TotalPages:  totalPages(res.Total, limit),
+			},
+			Facets: toFacets(res.Facets),
+		})
 	}
 }
 
-func GetImagesHandler(index bleve.Index, pathPrefix string, logger *log.Logger) operations.GetImagesHandlerFunc {
-	return func(params operations.GetImagesParams) middleware.Responder {
-		var queries []query.Query
-		if params.Query != nil {
-			q := query.NewMatchPhraseQuery(swag.StringValue(params.Query))
-			q.FieldVal = "prompt"
-			q.Analyzer = image.Analyzer(q.FieldVal)
+func GetImageDetailHandler(
+	c catalog.Catalog, data *userdata.Store, logger *log.Logger,
+) operations.GetImageDetailHandlerFunc {
+	return func(params operations.GetImageDetailParams, _ interface{}) middleware.Responder {
+		filter, err := newFilter(params.Query, params.Q, params.Size, params.Checkpoint, params.Before, params.After)
+		if err != nil {
+			return operations.NewGetImageDetailDefault(http.StatusBadRequest).WithPayload(queryError(err))
+		}
+		setAnnotationFilter(&filter, params.Favorite, params.MinRating, params.Tag)
+		if code, err := setCollectionFilter(data, &filter, params.Collection, params.Sort); err != nil {
+			return operations.NewGetImageDetailDefault(code).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		}
 
-			queries = append(queries, q)
+		req := &catalog.SearchRequest{Filter: filter, Sort: swag.StringValue(params.Sort)}
+		if swag.StringValue(params.Order) == "asc" {
+			req.Order = catalog.Ascending
 		}
-		if params.Size != nil {
-			var min, max *float64
-			switch swag.StringValue(params.Size) {
-			case "small":
-				max = swag.Float64(512 * 768)
-			case "medium":
-				min = swag.Float64(512 * 768)
-				max = swag.Float64(512 * 768 * 4)
-			case "large":
-				min = swag.Float64(512 * 768 * 4)
-			}
-			q := query.NewNumericRangeInclusiveQuery(min, max, swag.Bool(false), swag.Bool(true))
-			q.FieldVal = "pixel"
-			queries = append(queries, q)
+
+		res, err := c.Lookup(params.HTTPRequest.Context(), params.ID, req)
+		if err != nil {
+			logger.Printf("Failed to look up an image: %v", err)
+			return operations.NewGetImageDetailDefault(http.StatusInternalServerError).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		} else if res == nil {
+			return operations.NewGetImageDetailDefault(http.StatusNotFound).WithPayload(&models.StandardError{
+				Message: swag.String(fmt.Sprintf("image %v is not found", params.ID)),
+			})
 		}
-		if params.Checkpoint != nil {
-			q := query.NewTermQuery(swag.StringValue(params.Checkpoint))
-			q.FieldVal = "checkpoint"
 
-			queries = append(queries, q)
+		img := toImage(params.ID, res.Image)
+		// raw parameters are only given by the detail of an image to keep lists small.
+		img.Parameters = res.Image.Parameters
+		return operations.NewGetImageDetailOK().WithPayload(&models.ImageDetail{
+			Image:    img,
+			Previous: res.Previous,
+			Next:     res.Next,
+		})
+	}
+}
+
+// newFilter returns a filter of the given search parameters shared by the list and the detail of images.
+func newFilter(query, q, size, checkpoint *string, before, after *strfmt.DateTime) (catalog.Filter, error) {
+	var filter catalog.Filter
+	filter.Prompt = swag.StringValue(query)
+	// an empty query string, e.g. given by an empty search box, doesn't restrict anything.
+	if strings.TrimSpace(swag.StringValue(q)) != "" {
+		e, err := catalog.ParseQuery(*q)
+		if err != nil {
+			return filter, err
 		}
-		if params.After != nil || params.Before != nil {
-			var before, after time.Time
-			if params.Before != nil {
-				before = time.Time(*params.Before)
-			}
-			if params.After != nil {
-				after = time.Time(*params.After)
-			}
-			q := query.NewDateRangeInclusiveQuery(after, before, swag.Bool(true), swag.Bool(false))
-			q.FieldVal = "creation-time"
+		filter.Query = e
+	}
+	filter.SetSize(swag.StringValue(size))
+	filter.Checkpoint = swag.StringValue(checkpoint)
+	if before != nil {
+		filter.Before = time.Time(*before)
+	}
+	if after != nil {
+		filter.After = time.Time(*after)
+	}
+	return filter, nil
+}
+
+// setAnnotationFilter restricts the filter to images having the given annotations.
+func setAnnotationFilter(filter *catalog.Filter, favorite *bool, minRating *int64, tags []string) {
+	filter.Favorite = swag.BoolValue(favorite)
+	filter.MinRating = int(swag.Int64Value(minRating))
+	filter.Tags = tags
+}
+
+// queryError returns an error response of an invalid query string with the position of the problem.
+func queryError(err error) *models.StandardError {
+	res := &models.StandardError{Message: swag.String(err.Error())}
+	var qerr *catalog.QueryError
+	if errors.As(err, &qerr) {
+		res.Position = int64(qerr.Pos + 1)
+	}
+	return res
+}
+
+// toImage converts an image of the given ID.
+func toImage(id string, img *image.Image) *models.Image {
+	metadata := make(map[string]any, len(img.Metadata))
+	for k, v := range img.Metadata {
+		metadata[k] = v
+	}
+
+	return &models.Image{
+		ID:                        swag.String(id),
+		Prompt:                    img.Prompt,
+		NegativePrompt:            img.NegativePrompt,
+		Checkpoint:                img.Checkpoint,
+		CreationTime:              strfmt.DateTime(img.CreationTime),
+		Pixel:                     int64(img.Pixel),
+		Hash:                      img.Hash,
+		Annotation:                toAnnotation(img.Annotation),
+		ImageAdditionalProperties: metadata,
+	}
+}
+
+// jsonResponder writes a response in JSON regardless of the negotiated content type. Errors of operations producing
+// files are negotiated to the types of the files, e.g. text/csv, whose producers can't write error payloads.
+type jsonResponder struct {
+	middleware.Responder
+}
 
-			queries = append(queries, q)
+// WriteResponse implements middleware.Responder.
+func (r jsonResponder) WriteResponse(rw http.ResponseWriter, _ runtime.Producer) {
+	rw.Header().Set("Content-Type", runtime.JSONMime)
+	r.Responder.WriteResponse(rw, runtime.JSONProducer())
+}
+
+// toFacets converts counts of the requested facets. It returns nil if no facets were requested.
+func toFacets(facets map[string][]*catalog.Facet) *models.Facets {
+	if facets == nil {
+		return nil
+	}
+
+	convert := func(name string) []*models.Facet {
+		v, ok := facets[name]
+		if !ok {
+			return nil
 		}
-		if len(queries) == 0 {
-			queries = append(queries, query.NewMatchAllQuery())
+		return toFacetList(v)
+	}
+	return &models.Facets{
+		Checkpoint: convert(catalog.FacetCheckpoint),
+		Sampler:    convert(catalog.FacetSampler),
+		Lora:       convert(catalog.FacetLoRA),
+		Size:       convert(catalog.FacetSize),
+		Created:    convert(catalog.FacetCreated),
+	}
+}
+
+// toFacetList converts terms and their counts.
+func toFacetList(facets []*catalog.Facet) []*models.Facet {
+	res := make([]*models.Facet, len(facets))
+	for i, f := range facets {
+		res[i] = &models.Facet{Term: swag.String(f.Term), Count: swag.Int64(int64(f.Count))}
+	}
+	return res
+}
+
+func GetFailuresHandler(c catalog.Catalog, logger *log.Logger) operations.GetFailuresHandlerFunc {
+	return func(params operations.GetFailuresParams, _ interface{}) middleware.Responder {
+		var classes []string
+		if params.Class != nil {
+			classes = append(classes, swag.StringValue(params.Class))
 		}
 
 		page := int(swag.Int64Value(params.Page))
@@ -194,110 +358,57 @@ func GetImagesHandler(index bleve.Index, pathPrefix string, logger *log.Logger)
 			limit = int(swag.Int64Value(params.Limit))
 		}
 
-		req := bleve.NewSearchRequestOptions(query.NewConjunctionQuery(queries), limit, limit*page, false)
-		req.Fields = []string{"*"}
-		if swag.StringValue(params.Order) == "asc" {
-			req.SortBy([]string{"creation-time"})
-		} else {
-			req.SortBy([]string{"-creation-time"})
-		}
-
-		res, err := index.SearchInContext(params.HTTPRequest.Context(), req)
+		res, err := c.SearchFailures(params.HTTPRequest.Context(), &catalog.FailureRequest{
+			Classes: classes,
+			Offset:  limit * page,
+			Limit:   limit,
+		})
 		if err != nil {
-			logger.Printf("Failed to search images: %v", err)
-			return operations.NewGetImagesDefault(http.StatusInternalServerError).WithPayload(&models.StandardError{
+			logger.Printf("Failed to search failures: %v", err)
+			return operations.NewGetFailuresDefault(http.StatusInternalServerError).WithPayload(&models.StandardError{
 				Message: swag.String(err.Error()),
 			})
 		}
 
-		items := make([]*models.Image, len(res.Hits))
+		items := make([]*models.Failure, len(res.Hits))
 		for i, v := range res.Hits {
-			id, err := filepath.Rel(pathPrefix, v.ID)
-			if err != nil {
-				logger.Printf("Failed to get a relative path: %v", err)
-				return operations.NewGetImagesDefault(http.StatusInternalServerError).WithPayload(&models.StandardError{
-					Message: swag.String(err.Error()),
-				})
-			}
-
-			items[i] = &models.Image{
-				ID:                        swag.String(id),
-				Prompt:                    getString(v.Fields, "prompt"),
-				NegativePrompt:            getString(v.Fields, "negative-prompt"),
-				Checkpoint:                getString(v.Fields, "checkpoint"),
-				CreationTime:              strfmt.DateTime(getDateTime(v.Fields, "creation-time")),
-				Pixel:                     int64(getInt(v.Fields, "pixel")),
-				ImageAdditionalProperties: getMap(v.Fields, "metadata"),
+			items[i] = &models.Failure{
+				ID:          swag.String(v.ID),
+				Class:       swag.String(v.Failure.Class),
+				Message:     v.Failure.Message,
+				Size:        v.Failure.Size,
+				ModTime:     strfmt.DateTime(v.Failure.ModTime),
+				Fingerprint: v.Failure.Fingerprint,
+				FailedAt:    strfmt.DateTime(v.Failure.FailedAt),
 			}
 		}
 
-		return operations.NewGetImagesOK().WithPayload(&models.ImageList{
+		return operations.NewGetFailuresOK().WithPayload(&models.FailureList{
 			Items: items,
 			Metadata: &models.Metadata{
 				CurrentPage: swag.Int64(int64(page)),
 				TotalItems:  swag.Int64(int64(res.Total)),
-				TotalPages:  swag.Int64(int64(res.Total/uint64(limit)) + 1),
+				TotalPages:  totalPages(res.Total, limit),
 			},
 		})
 	}
 }
 
-func getString(m map[string]any, key string) string {
-	v, _ := m[key].(string)
-	return v
+// totalPages returns the number of pages of the given size having the given number of items.
+func totalPages(total, limit int) *int64 {
+	return swag.Int64(int64((total + limit - 1) / limit))
 }
 
-func getInt(m map[string]any, key string) int {
-	v, _ := m[key].(int)
-	return v
-}
-
-func getDateTime(m map[string]any, key string) time.Time {
-	v, _ := m[key].(string)
-	t, _ := time.Parse(time.RFC3339, v)
-	return t
-}
-
-func getMap(m map[string]any, key string) map[string]any {
-	res := make(map[string]any)
-	for k, v := range m {
-		if strings.HasPrefix(k, key) {
-			k = strings.TrimPrefix(k, key+".")
-			res[k] = v
-		}
-	}
-	return res
-}
-
-func GetCheckpointsHandler(index bleve.Index, logger *log.Logger) operations.GetCheckpointsHandlerFunc {
-	return func(params operations.GetCheckpointsParams) middleware.Responder {
-		fields, err := index.FieldDict("checkpoint")
+func GetCheckpointsHandler(c catalog.Catalog, logger *log.Logger) operations.GetCheckpointsHandlerFunc {
+	return func(params operations.GetCheckpointsParams, _ interface{}) middleware.Responder {
+		names, err := c.Terms(params.HTTPRequest.Context(), catalog.FieldCheckpoint)
 		if err != nil {
+			logger.Printf("Failed to list checkpoints: %v", err)
 			return operations.NewGetCheckpointsDefault(http.StatusInternalServerError).
 				WithPayload(&models.StandardError{
 					Message: swag.String(err.Error()),
 				})
 		}
-		defer func() {
-			if err = fields.Close(); err != nil {
-				logger.Printf("Failed to close a field dict: %v", err)
-			}
-		}()
-
-		var names []string
-		for {
-			f, err := fields.Next()
-			if err != nil {
-				return operations.NewGetCheckpointsDefault(http.StatusInternalServerError).
-					WithPayload(&models.StandardError{
-						Message: swag.String(err.Error()),
-					})
-			} else if f == nil {
-				break
-			}
-
-			names = append(names, f.Term)
-		}
 
 		return operations.NewGetCheckpointsOK().WithPayload(names)
 	}
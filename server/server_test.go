@@ -0,0 +1,136 @@
+// server_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/image"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+)
+
+func TestGetFailures(t *testing.T) {
+	c, err := catalog.NewBleveMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	docs := make(map[string]catalog.Document)
+	for i := 0; i != 4; i++ {
+		docs[fmt.Sprintf("%v.png", i)] = &image.Failure{Class: image.ClassCorrupt, FailedAt: time.Now()}
+	}
+	if err = c.Index(context.Background(), docs); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := GetFailuresHandler(c, log.New(io.Discard, "", 0))
+	cases := []struct {
+		limit int64
+		items int
+		pages int64
+	}{
+		{limit: 1, items: 1, pages: 4},
+		{limit: 2, items: 2, pages: 2},
+		{limit: 3, items: 3, pages: 2},
+		{limit: 4, items: 4, pages: 1},
+		{limit: 5, items: 4, pages: 1},
+	}
+	for _, v := range cases {
+		t.Run(fmt.Sprint(v.limit), func(t *testing.T) {
+			params := operations.NewGetFailuresParams()
+			params.HTTPRequest = httptest.NewRequest(http.MethodGet, "/api/v1/index/failures", nil)
+			params.Limit = swag.Int64(v.limit)
+
+			res, ok := handler(params, nil).(*operations.GetFailuresOK)
+			if !ok {
+				t.Fatalf("expect a list of failures, got %v", res)
+			}
+			if len(res.Payload.Items) != v.items {
+				t.Errorf("expect %v items, got %v", v.items, len(res.Payload.Items))
+			}
+			if pages := swag.Int64Value(res.Payload.Metadata.TotalPages); pages != v.pages {
+				t.Errorf("expect %v pages, got %v", v.pages, pages)
+			}
+		})
+	}
+
+	// an empty result has no pages.
+	params := operations.NewGetFailuresParams()
+	params.HTTPRequest = httptest.NewRequest(http.MethodGet, "/api/v1/index/failures?class=missing", nil)
+	params.Class = swag.String("missing")
+	res, ok := handler(params, nil).(*operations.GetFailuresOK)
+	if !ok {
+		t.Fatalf("expect a list of failures, got %v", res)
+	}
+	if pages := swag.Int64Value(res.Payload.Metadata.TotalPages); pages != 0 {
+		t.Errorf("expect no pages, got %v", pages)
+	}
+
+	// pages are given from zero by sizes of one or more.
+	route := new(middleware.MatchedRoute)
+	route.Formats = strfmt.Default
+	for _, query := range []string{"limit=0", "page=-1"} {
+		params = operations.NewGetFailuresParams()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/index/failures?"+query, nil)
+		err = params.BindRequest(req, route)
+		if err == nil {
+			t.Errorf("expect %v is rejected", query)
+		}
+	}
+}
+
+func TestGetImages(t *testing.T) {
+	c, err := catalog.NewBleveMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	docs := make(map[string]catalog.Document)
+	for i := 0; i != 4; i++ {
+		docs[fmt.Sprintf("%v.png", i)] = &image.Image{Prompt: "cat", CreationTime: time.Now()}
+	}
+	if err = c.Index(context.Background(), docs); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := GetImagesHandler(c, nil, log.New(io.Discard, "", 0))
+	for limit, pages := range map[int64]int64{1: 4, 2: 2, 3: 2, 4: 1, 5: 1} {
+		params := operations.NewGetImagesParams()
+		params.HTTPRequest = httptest.NewRequest(http.MethodGet, "/api/v1/images", nil)
+		params.Limit = swag.Int64(limit)
+
+		res, ok := handler(params, nil).(*operations.GetImagesOK)
+		if !ok {
+			t.Fatalf("expect a list of images, got %v", res)
+		}
+		if v := swag.Int64Value(res.Payload.Metadata.TotalPages); v != pages {
+			t.Errorf("expect %v pages of %v images, got %v", pages, limit, v)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// similar.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+)
+
+func GetSimilarImagesHandler(c catalog.Catalog, logger *log.Logger) operations.GetSimilarImagesHandlerFunc {
+	return func(params operations.GetSimilarImagesParams, _ interface{}) middleware.Responder {
+		res, err := c.Similar(params.HTTPRequest.Context(), params.ID, int(swag.Int64Value(params.Distance)))
+		if err != nil {
+			logger.Printf("Failed to search similar images: %v", err)
+			return operations.NewGetSimilarImagesDefault(http.StatusInternalServerError).WithPayload(
+				&models.StandardError{Message: swag.String(err.Error())},
+			)
+		} else if res == nil {
+			return operations.NewGetSimilarImagesDefault(http.StatusNotFound).WithPayload(&models.StandardError{
+				Message: swag.String(fmt.Sprintf("image %v is not found", params.ID)),
+			})
+		}
+
+		items := make([]*models.SimilarImage, len(res))
+		for i, v := range res {
+			items[i] = toSimilarImage(v)
+		}
+		return operations.NewGetSimilarImagesOK().WithPayload(items)
+	}
+}
+
+func GetDuplicatesHandler(c catalog.Catalog, logger *log.Logger) operations.GetDuplicatesHandlerFunc {
+	return func(params operations.GetDuplicatesParams, _ interface{}) middleware.Responder {
+		page := int(swag.Int64Value(params.Page))
+		limit := defaultLimit
+		if params.Limit != nil {
+			limit = int(swag.Int64Value(params.Limit))
+		}
+
+		res, err := c.Duplicates(params.HTTPRequest.Context(), int(swag.Int64Value(params.Distance)), limit*page, limit)
+		if err != nil {
+			logger.Printf("Failed to search duplicates: %v", err)
+			return operations.NewGetDuplicatesDefault(http.StatusInternalServerError).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		}
+
+		items := make([]*models.DuplicateGroup, len(res.Groups))
+		for i, g := range res.Groups {
+			group := &models.DuplicateGroup{Exact: swag.Bool(true), Images: make([]*models.SimilarImage, len(g))}
+			for j, v := range g {
+				group.Images[j] = toSimilarImage(v)
+				if v.Distance != 0 {
+					group.Exact = swag.Bool(false)
+				}
+			}
+			items[i] = group
+		}
+
+		return operations.NewGetDuplicatesOK().WithPayload(&models.DuplicateList{
+			Items: items,
+			Metadata: &models.Metadata{
+				CurrentPage: swag.Int64(int64(page)),
+				TotalItems:  swag.Int64(int64(res.Total)),
+				TotalPages:  totalPages(res.Total, limit),
+			},
+		})
+	}
+}
+
+func toSimilarImage(hit *catalog.SimilarHit) *models.SimilarImage {
+	return &models.SimilarImage{
+		Image:    toImage(hit.ID, hit.Image),
+		Distance: swag.Int64(int64(hit.Distance)),
+	}
+}
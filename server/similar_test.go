@@ -0,0 +1,80 @@
+// similar_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+)
+
+func TestGetSimilarImages(t *testing.T) {
+	ts, _ := newTestServer(t)
+	client := &testClient{t: t, url: ts.URL}
+
+	cases := []struct {
+		path   string
+		expect []string
+	}{
+		{path: "/images/a.png/similar", expect: []string{"b.png"}},
+		{path: "/images/a.png/similar?distance=0", expect: nil},
+	}
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			var res []*models.SimilarImage
+			if err := json.Unmarshal([]byte(client.expect(http.MethodGet, c.path, http.StatusOK)), &res); err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, v := range res {
+				ids = append(ids, *v.Image.ID)
+			}
+			if len(ids) != len(c.expect) || len(ids) != 0 && ids[0] != c.expect[0] {
+				t.Errorf("expect %v, got %v", c.expect, ids)
+			}
+		})
+	}
+
+	client.expect(http.MethodGet, "/images/missing.png/similar", http.StatusNotFound)
+	client.expect(http.MethodGet, "/images/a.png/similar?distance=8", http.StatusUnprocessableEntity)
+}
+
+func TestGetDuplicates(t *testing.T) {
+	ts, _ := newTestServer(t)
+	client := &testClient{t: t, url: ts.URL}
+
+	var res models.DuplicateList
+	if err := json.Unmarshal([]byte(client.expect(http.MethodGet, "/duplicates", http.StatusOK)), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 1 || len(res.Items[0].Images) != 2 || *res.Items[0].Exact {
+		t.Fatalf("expect a group of near duplicates, got %v", res.Items)
+	}
+	if img := res.Items[0].Images[1]; *img.Image.ID != "b.png" || *img.Distance != 1 {
+		t.Errorf("expect b.png at distance 1, got %v at %v", *img.Image.ID, *img.Distance)
+	}
+
+	body := client.expect(http.MethodGet, "/duplicates?distance=0", http.StatusOK)
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 0 || *res.Metadata.TotalItems != 0 || *res.Metadata.TotalPages != 0 {
+		t.Errorf("expect no exact duplicates, got %v", res.Items)
+	}
+
+	body = client.expect(http.MethodGet, "/duplicates?limit=1", http.StatusOK)
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	if *res.Metadata.TotalPages != 1 {
+		t.Errorf("expect 1 page, got %v", *res.Metadata.TotalPages)
+	}
+}
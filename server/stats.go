@@ -0,0 +1,70 @@
+// stats.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+	"github.com/jkawamoto/sd-image-viewer/userdata"
+)
+
+func GetStatsHandler(c catalog.Catalog, data *userdata.Store, logger *log.Logger) operations.GetStatsHandlerFunc {
+	return func(params operations.GetStatsParams, _ interface{}) middleware.Responder {
+		filter, err := newFilter(params.Query, params.Q, params.Size, params.Checkpoint, params.Before, params.After)
+		if err != nil {
+			return operations.NewGetStatsDefault(http.StatusBadRequest).WithPayload(queryError(err))
+		}
+		setAnnotationFilter(&filter, params.Favorite, params.MinRating, params.Tag)
+		if code, err := setCollectionFilter(data, &filter, params.Collection, nil); err != nil {
+			return operations.NewGetStatsDefault(code).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		}
+
+		res, err := c.Stats(params.HTTPRequest.Context(), &catalog.StatsRequest{
+			Filter:   filter,
+			Interval: swag.StringValue(params.Interval),
+			Periods:  int(swag.Int64Value(params.Periods)),
+			Size:     int(swag.Int64Value(params.Top)),
+		})
+		if err != nil {
+			logger.Printf("Failed to compute statistics: %v", err)
+			return operations.NewGetStatsDefault(http.StatusInternalServerError).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		}
+
+		checkpoints := make([]*models.CheckpointStats, len(res.Checkpoints))
+		for i, v := range res.Checkpoints {
+			checkpoints[i] = &models.CheckpointStats{
+				Name:            swag.String(v.Name),
+				Count:           swag.Int64(int64(v.Count)),
+				AverageSteps:    swag.Float64(v.AverageSteps),
+				AverageCfgScale: swag.Float64(v.AverageCFGScale),
+				Created:         toFacetList(v.Created),
+			}
+		}
+		return operations.NewGetStatsOK().WithPayload(&models.Stats{
+			Total:       swag.Int64(int64(res.Total)),
+			Created:     toFacetList(res.Created),
+			Checkpoints: checkpoints,
+			Samplers:    toFacetList(res.Samplers),
+			PromptTags:  toFacetList(res.PromptTags),
+			Resolutions: toFacetList(res.Resolutions),
+			Sizes:       toFacetList(res.Sizes),
+		})
+	}
+}
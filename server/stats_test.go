@@ -0,0 +1,55 @@
+// stats_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+)
+
+func TestGetStats(t *testing.T) {
+	ts, _ := newTestServer(t)
+	client := &testClient{t: t, url: ts.URL}
+
+	cases := []struct {
+		path       string
+		total      int64
+		promptTags int
+	}{
+		{path: "/stats", total: 2, promptTags: 2},
+		{path: "/stats?q=cat&interval=month", total: 1, promptTags: 1},
+		{path: "/stats?top=1", total: 2, promptTags: 1},
+		{path: "/stats?favorite=true", total: 0, promptTags: 0},
+	}
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			var res models.Stats
+			if err := json.Unmarshal([]byte(client.expect(http.MethodGet, c.path, http.StatusOK)), &res); err != nil {
+				t.Fatal(err)
+			}
+			if *res.Total != c.total {
+				t.Errorf("expect %v, got %v", c.total, *res.Total)
+			}
+			if len(res.PromptTags) != c.promptTags {
+				t.Errorf("expect %v tags, got %v", c.promptTags, len(res.PromptTags))
+			}
+			// the test images don't have checkpoints.
+			if len(res.Checkpoints) != 0 {
+				t.Errorf("expect no checkpoints, got %v", res.Checkpoints)
+			}
+		})
+	}
+
+	client.expect(http.MethodGet, "/stats?q=(cat", http.StatusBadRequest)
+	client.expect(http.MethodGet, "/stats?interval=year", http.StatusUnprocessableEntity)
+	client.expect(http.MethodGet, "/stats?collection=missing", http.StatusNotFound)
+}
@@ -0,0 +1,51 @@
+// suggest.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+)
+
+func GetSuggestionsHandler(c catalog.Catalog, logger *log.Logger) operations.GetSuggestionsHandlerFunc {
+	return func(params operations.GetSuggestionsParams, _ interface{}) middleware.Responder {
+		ctx := params.HTTPRequest.Context()
+		field, prefix := swag.StringValue(params.Field), swag.StringValue(params.Prefix)
+		size := int(swag.Int64Value(params.Size))
+
+		items, err := c.Suggest(ctx, field, prefix, size)
+		if err != nil {
+			logger.Printf("Failed to suggest terms: %v", err)
+			return operations.NewGetSuggestionsDefault(http.StatusInternalServerError).WithPayload(
+				&models.StandardError{Message: swag.String(err.Error())},
+			)
+		}
+		res := &models.Suggestions{Items: toFacetList(items)}
+
+		// the prefix may be misspelt if no terms start with it.
+		if len(items) == 0 {
+			corrections, err := c.Corrections(ctx, field, prefix, size)
+			if err != nil {
+				logger.Printf("Failed to correct terms: %v", err)
+				return operations.NewGetSuggestionsDefault(http.StatusInternalServerError).WithPayload(
+					&models.StandardError{Message: swag.String(err.Error())},
+				)
+			}
+			res.Corrections = toFacetList(corrections)
+		}
+		return operations.NewGetSuggestionsOK().WithPayload(res)
+	}
+}
@@ -0,0 +1,59 @@
+// suggest_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+)
+
+func TestGetSuggestions(t *testing.T) {
+	ts, _ := newTestServer(t)
+	client := &testClient{t: t, url: ts.URL}
+
+	join := func(facets []*models.Facet) string {
+		res := make([]string, len(facets))
+		for i, f := range facets {
+			res[i] = *f.Term
+		}
+		return strings.Join(res, ",")
+	}
+
+	cases := []struct {
+		path        string
+		items       string
+		corrections string
+	}{
+		{path: "/suggest?prefix=C", items: "cat"},
+		{path: "/suggest", items: "cat,dog"},
+		{path: "/suggest?size=1", items: "cat"},
+		{path: "/suggest?prefix=cab", corrections: "cat"},
+		{path: "/suggest?prefix=x&field=checkpoint"},
+	}
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			var res models.Suggestions
+			if err := json.Unmarshal([]byte(client.expect(http.MethodGet, c.path, http.StatusOK)), &res); err != nil {
+				t.Fatal(err)
+			}
+			if s := join(res.Items); s != c.items {
+				t.Errorf("expect %v, got %v", c.items, s)
+			}
+			if s := join(res.Corrections); s != c.corrections {
+				t.Errorf("expect corrections %v, got %v", c.corrections, s)
+			}
+		})
+	}
+
+	client.expect(http.MethodGet, "/suggest?prefix=0&field=hash", http.StatusUnprocessableEntity)
+}
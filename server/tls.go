@@ -0,0 +1,61 @@
+// tls.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jkawamoto/sd-image-viewer/server/restapi"
+)
+
+const (
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
+	httpsPort   = 443
+)
+
+// EnableTLS serves the given server over HTTPS on the given host and port in addition to HTTP, which redirects requests
+// to HTTPS. Certificates are given by getCertificate so that they can be replaced while running.
+func EnableTLS(
+	s *restapi.Server, host string, port int, getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error),
+) {
+	s.EnabledListeners = []string{schemeHTTP, schemeHTTPS}
+	s.TLSHost = host
+	s.TLSPort = port
+	restapi.GetCertificate = getCertificate
+	// the port is read on each request since a random port is chosen when the server starts listening if it is zero.
+	s.SetHandler(redirectToHTTPS(s.GetHandler(), func() int { return s.TLSPort }))
+}
+
+// redirectToHTTPS returns a handler redirecting requests over HTTP to the same host on the given HTTPS port.
+// Temporary redirects are used so that browsers don't remember them after HTTPS is disabled.
+func redirectToHTTPS(h http.Handler, port func() int) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		if req.TLS != nil {
+			h.ServeHTTP(res, req)
+			return
+		}
+
+		host := req.Host
+		if v, _, err := net.SplitHostPort(host); err == nil {
+			host = v
+		}
+		host = strings.Trim(host, "[]")
+		if p := port(); p != httpsPort {
+			host = net.JoinHostPort(host, strconv.Itoa(p))
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		http.Redirect(res, req, schemeHTTPS+"://"+host+req.URL.RequestURI(), http.StatusTemporaryRedirect)
+	}
+}
@@ -0,0 +1,196 @@
+// tls_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/jkawamoto/sd-image-viewer/auth"
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi"
+	"github.com/jkawamoto/sd-image-viewer/source"
+	"github.com/jkawamoto/sd-image-viewer/thumbnail"
+	"github.com/jkawamoto/sd-image-viewer/tlscert"
+	"github.com/jkawamoto/sd-image-viewer/userdata"
+)
+
+func TestRedirectToHTTPS(t *testing.T) {
+	cases := []struct {
+		name   string
+		url    string
+		port   int
+		expect string
+	}{
+		{
+			name:   "host name",
+			url:    "http://nas.local:8080/api/v1/images?q=cat",
+			port:   8443,
+			expect: "https://nas.local:8443/api/v1/images?q=cat",
+		},
+		{
+			name:   "default port",
+			url:    "http://nas.local/?share=x",
+			port:   443,
+			expect: "https://nas.local/?share=x",
+		},
+		{
+			name:   "IPv6 address",
+			url:    "http://[::1]:8080/",
+			port:   8443,
+			expect: "https://[::1]:8443/",
+		},
+		{
+			name:   "IPv6 address with the default port",
+			url:    "http://[::1]/",
+			port:   443,
+			expect: "https://[::1]/",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := redirectToHTTPS(http.NotFoundHandler(), func() int { return c.port })
+			res := httptest.NewRecorder()
+			h(res, httptest.NewRequest(http.MethodPost, c.url, nil))
+			if res.Code != http.StatusTemporaryRedirect {
+				t.Errorf("expect %v, got %v", http.StatusTemporaryRedirect, res.Code)
+			}
+			if loc := res.Header().Get("Location"); loc != c.expect {
+				t.Errorf("expect %v, got %v", c.expect, loc)
+			}
+		})
+	}
+
+	t.Run("https", func(t *testing.T) {
+		h := redirectToHTTPS(http.NotFoundHandler(), func() int { return httpsPort })
+		res := httptest.NewRecorder()
+		h(res, httptest.NewRequest(http.MethodGet, "https://nas.local/", nil))
+		if res.Code != http.StatusNotFound {
+			t.Errorf("expect %v, got %v", http.StatusNotFound, res.Code)
+		}
+	})
+}
+
+func TestEnableTLS(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	dir := t.TempDir()
+	certFile, keyFile, err := tlscert.SelfSigned(dir, []string{"localhost", "127.0.0.1"}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certs, err := tlscert.Load(certFile, keyFile, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := catalog.NewBleveMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	thumbs, err := thumbnail.Open(t.TempDir(), 1<<20, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := thumbs.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	users, err := auth.OpenStore(filepath.Join(dir, "users"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := userdata.Open(filepath.Join(dir, "userdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := data.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	s, err := NewServer("127.0.0.1", 0, c, source.Dir(t.TempDir()), thumbs, users, data, nil, logger, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	EnableTLS(s, "127.0.0.1", 0, certs.GetCertificate)
+	t.Cleanup(func() {
+		restapi.GetCertificate = nil
+	})
+	if err = s.Listen(); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		done <- s.Serve()
+	}()
+	t.Cleanup(func() {
+		if err := s.Shutdown(); err != nil {
+			t.Error(err)
+		}
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	})
+
+	b, err := os.ReadFile(filepath.Join(dir, tlscert.CAFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(b)
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	httpsURL := "https://localhost:" + strconv.Itoa(s.TLSPort) + "/api/v1/checkpoints"
+	res, err := client.Get(httpsURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = res.Body.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("expect %v, got %v", http.StatusOK, res.StatusCode)
+	}
+
+	res, err = client.Get("http://localhost:" + strconv.Itoa(s.Port) + "/api/v1/checkpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = res.Body.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusTemporaryRedirect {
+		t.Errorf("expect %v, got %v", http.StatusTemporaryRedirect, res.StatusCode)
+	}
+	if loc := res.Header.Get("Location"); loc != httpsURL {
+		t.Errorf("expect %v, got %v", httpsURL, loc)
+	}
+}
@@ -0,0 +1,200 @@
+// trash.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/image"
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+	"github.com/jkawamoto/sd-image-viewer/source"
+	"github.com/jkawamoto/sd-image-viewer/trash"
+	"github.com/jkawamoto/sd-image-viewer/userdata"
+)
+
+// errReadOnly is returned when modifying libraries while the server runs in read-only mode.
+var errReadOnly = errors.New("the server is running in read-only mode")
+
+// DeleteImageHandler moves images to the trash. Deleting images is disabled if bin is nil, i.e. in read-only mode.
+func DeleteImageHandler(c catalog.Catalog, bin *trash.Trash, logger *log.Logger) operations.DeleteImageHandlerFunc {
+	return func(params operations.DeleteImageParams, _ interface{}) middleware.Responder {
+		items, err := trashImages(params.HTTPRequest.Context(), c, bin, []string{params.ID})
+		if err != nil {
+			return operations.NewDeleteImageDefault(trashStatus(err, logger)).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		}
+		return operations.NewDeleteImageOK().WithPayload(items[0])
+	}
+}
+
+// DeleteImagesHandler moves images to the trash. Deleting images is disabled if bin is nil, i.e. in read-only mode.
+func DeleteImagesHandler(c catalog.Catalog, bin *trash.Trash, logger *log.Logger) operations.DeleteImagesHandlerFunc {
+	return func(params operations.DeleteImagesParams, _ interface{}) middleware.Responder {
+		items, err := trashImages(params.HTTPRequest.Context(), c, bin, params.Image)
+		if err != nil {
+			return operations.NewDeleteImagesDefault(trashStatus(err, logger)).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		}
+		return operations.NewDeleteImagesOK().WithPayload(items)
+	}
+}
+
+// GetTrashHandler lists images in the trash. Listing images is disabled if bin is nil, i.e. in read-only mode.
+func GetTrashHandler(bin *trash.Trash, logger *log.Logger) operations.GetTrashHandlerFunc {
+	return func(params operations.GetTrashParams, _ interface{}) middleware.Responder {
+		if bin == nil {
+			return operations.NewGetTrashDefault(http.StatusForbidden).WithPayload(&models.StandardError{
+				Message: swag.String(errReadOnly.Error()),
+			})
+		}
+
+		res := []*models.TrashItem{}
+		items, err := bin.List()
+		if err != nil {
+			logger.Printf("Failed to list the trash: %v", err)
+			return operations.NewGetTrashDefault(http.StatusInternalServerError).WithPayload(&models.StandardError{
+				Message: swag.String(err.Error()),
+			})
+		}
+		for _, v := range items {
+			res = append(res, toTrashItem(v))
+		}
+		return operations.NewGetTrashOK().WithPayload(res)
+	}
+}
+
+// RestoreTrashHandler restores images in the trash and indexes them so that they can be found immediately. Their
+// annotations are kept in data while they are in the trash. Restoring images is disabled if bin is nil.
+func RestoreTrashHandler(
+	c catalog.Catalog, src source.Source, data *userdata.Store, bin *trash.Trash, logger *log.Logger,
+) operations.RestoreTrashHandlerFunc {
+	return func(params operations.RestoreTrashParams, _ interface{}) middleware.Responder {
+		if bin == nil {
+			return operations.NewRestoreTrashDefault(http.StatusForbidden).WithPayload(&models.StandardError{
+				Message: swag.String(errReadOnly.Error()),
+			})
+		}
+
+		ctx := params.HTTPRequest.Context()
+		res := []*models.TrashItem{}
+		for _, id := range params.Items.Items {
+			item, err := bin.Restore(id)
+			if err != nil {
+				return operations.NewRestoreTrashDefault(trashStatus(err, logger)).WithPayload(&models.StandardError{
+					Message: swag.String(err.Error()),
+				})
+			}
+			res = append(res, toTrashItem(item))
+
+			// restored files keep their modification times, so the indexer would skip them.
+			err = data.Exclusive(func() error {
+				doc, err := restoredDocument(src, data, item.Image, logger)
+				if err != nil {
+					return err
+				}
+				return c.Index(ctx, map[string]catalog.Document{item.Image: doc})
+			})
+			if err != nil {
+				logger.Printf("Failed to index a restored image: %v", err)
+				return operations.NewRestoreTrashDefault(http.StatusInternalServerError).
+					WithPayload(&models.StandardError{
+						Message: swag.String(err.Error()),
+					})
+			}
+		}
+		return operations.NewRestoreTrashOK().WithPayload(res)
+	}
+}
+
+// trashImages moves indexed images of the given IDs to the trash and removes them from the index. It stops at the
+// first image which can't be deleted; images deleted before it stay in the trash.
+func trashImages(ctx context.Context, c catalog.Catalog, bin *trash.Trash, ids []string) ([]*models.TrashItem, error) {
+	if bin == nil {
+		return nil, errReadOnly
+	}
+
+	res := make([]*models.TrashItem, 0, len(ids))
+	for _, id := range ids {
+		img, err := c.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		} else if img == nil {
+			return nil, fmt.Errorf("%w: %v", trash.ErrNotFound, id)
+		}
+
+		item, err := bin.Delete(id)
+		if err != nil {
+			return nil, err
+		}
+		if err = c.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		res = append(res, toTrashItem(item))
+	}
+	return res, nil
+}
+
+// restoredDocument parses the restored image of the given ID and returns the document to index, which records the
+// failure if the image can't be parsed.
+func restoredDocument(
+	src source.Source, data *userdata.Store, id string, logger *log.Logger,
+) (catalog.Document, error) {
+	info, err := src.Stat(id)
+	if err != nil {
+		return nil, err
+	}
+	img, err := image.ParseImageFile(src, id)
+	if err != nil {
+		logger.Printf("Failed to parse a restored image %v: %v", id, err)
+		return image.NewFailure(err, info), nil
+	}
+	if img.Annotation, err = data.Annotation(id); err != nil {
+		logger.Printf("Failed to read the annotation of %v: %v", id, err)
+	}
+	return img, nil
+}
+
+// trashStatus returns the status code of the given error of the trash. Unexpected errors are logged.
+func trashStatus(err error, logger *log.Logger) int {
+	switch {
+	case errors.Is(err, errReadOnly):
+		return http.StatusForbidden
+	case errors.Is(err, trash.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, trash.ErrNotSupported):
+		return http.StatusNotImplemented
+	case errors.Is(err, trash.ErrExists):
+		return http.StatusConflict
+	default:
+		logger.Printf("Failed to access the trash: %v", err)
+		return http.StatusInternalServerError
+	}
+}
+
+// toTrashItem converts an item in the trash.
+func toTrashItem(item *trash.Item) *models.TrashItem {
+	deleted := strfmt.DateTime(item.Deleted)
+	return &models.TrashItem{
+		ID:      swag.String(item.ID),
+		Image:   swag.String(item.Image),
+		Deleted: &deleted,
+	}
+}
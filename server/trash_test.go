@@ -0,0 +1,122 @@
+// trash_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/jkawamoto/sd-image-viewer/server/models"
+	"github.com/jkawamoto/sd-image-viewer/server/restapi/operations"
+	"github.com/jkawamoto/sd-image-viewer/trash"
+)
+
+func TestTrash(t *testing.T) {
+	ts, _ := newTestServer(t)
+	client := &testClient{t: t, url: ts.URL}
+
+	decode := func(body string, v any) {
+		t.Helper()
+		if err := json.Unmarshal([]byte(body), v); err != nil {
+			t.Fatalf("failed to parse %v: %v", body, err)
+		}
+	}
+
+	var item models.TrashItem
+	decode(client.expect(http.MethodDelete, "/images/a.png", http.StatusOK), &item)
+	if *item.ID != "a.png" || *item.Image != "a.png" {
+		t.Errorf("expect an item of a.png, got %+v", item)
+	}
+	// deleted images disappear from the index immediately.
+	client.expect(http.MethodGet, "/images/a.png", http.StatusNotFound)
+	client.expect(http.MethodGet, "/image/a.png", http.StatusNotFound)
+	client.expect(http.MethodDelete, "/images/a.png", http.StatusNotFound)
+	client.expect(http.MethodDelete, "/images?image=b.png&image=missing.png", http.StatusNotFound)
+
+	var items []*models.TrashItem
+	decode(client.expect(http.MethodGet, "/trash", http.StatusOK), &items)
+	if len(items) != 2 {
+		t.Fatalf("expect 2 items, got %v", items)
+	}
+	var list models.ImageList
+	decode(client.expect(http.MethodGet, "/images", http.StatusOK), &list)
+	if len(list.Items) != 0 {
+		t.Errorf("expect no images, got %v", list.Items)
+	}
+
+	restore := func(body string, status int) string {
+		t.Helper()
+		res, b := client.do(http.MethodPost, "/trash/restore", body)
+		if res.StatusCode != status {
+			t.Fatalf("expect %v, got %v: %v", status, res.StatusCode, b)
+		}
+		return b
+	}
+	decode(restore(`{"items": ["a.png", "b.png"]}`, http.StatusOK), &items)
+	if len(items) != 2 || *items[0].Image != "a.png" || *items[1].Image != "b.png" {
+		t.Errorf("expect a.png and b.png are restored, got %v", items)
+	}
+	restore(`{"items": ["a.png"]}`, http.StatusNotFound)
+	decode(client.expect(http.MethodGet, "/trash", http.StatusOK), &items)
+	if len(items) != 0 {
+		t.Errorf("expect the trash is empty, got %v", items)
+	}
+
+	// restored images are indexed again, which are recorded as failures since the test images aren't PNG files.
+	var failures models.FailureList
+	decode(client.expect(http.MethodGet, "/index/failures", http.StatusOK), &failures)
+	if len(failures.Items) != 2 {
+		t.Errorf("expect restored images are indexed, got %v", failures.Items)
+	}
+}
+
+func TestTrashReadOnly(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/trash", nil)
+
+	// the trash is disabled with the same status as deleting images.
+	cases := map[string]middleware.Responder{
+		"list":   GetTrashHandler(nil, logger)(operations.GetTrashParams{HTTPRequest: req}, nil),
+		"delete": DeleteImagesHandler(nil, nil, logger)(operations.DeleteImagesParams{HTTPRequest: req}, nil),
+		"restore": RestoreTrashHandler(nil, nil, nil, nil, logger)(
+			operations.RestoreTrashParams{HTTPRequest: req}, nil,
+		),
+	}
+	for name, res := range cases {
+		rec := httptest.NewRecorder()
+		res.WriteResponse(rec, runtime.JSONProducer())
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%v: expect %v, got %v", name, http.StatusForbidden, rec.Code)
+		}
+	}
+}
+
+func TestTrashStatus(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	for err, expect := range map[error]int{
+		errReadOnly: http.StatusForbidden,
+		fmt.Errorf("%w: a.png", trash.ErrNotFound):     http.StatusNotFound,
+		fmt.Errorf("%w: a.png", trash.ErrExists):       http.StatusConflict,
+		fmt.Errorf("%w: a.png", trash.ErrNotSupported): http.StatusNotImplemented,
+		errors.New("unexpected"):                       http.StatusInternalServerError,
+	} {
+		if res := trashStatus(err, logger); res != expect {
+			t.Errorf("%v: expect %v, got %v", err, expect, res)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// archive.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package source
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/archive"
+)
+
+type archives struct {
+	Source
+
+	mu sync.Mutex
+	// lists of entries keyed by names of archives.
+	lists map[string]*entryList
+}
+
+type entryList struct {
+	modTime time.Time
+	entries map[string]fs.FileInfo
+}
+
+type watchableArchives struct {
+	*archives
+	Watcher
+}
+
+// Archives returns a source which exposes files stored in archives of the given source. Archives are treated as
+// directories and their entries have names such as "dir/old.zip!/a.png".
+func Archives(src Source) Source {
+	a := &archives{
+		Source: src,
+		lists:  make(map[string]*entryList),
+	}
+	if w, ok := src.(Watcher); ok {
+		return &watchableArchives{archives: a, Watcher: w}
+	}
+	return a
+}
+
+func (a *archives) Open(name string) (fs.File, error) {
+	archiveName, entry, ok := archive.Split(name)
+	if !ok {
+		return a.Source.Open(name)
+	}
+	return archive.Open(a.Source, archiveName, entry)
+}
+
+func (a *archives) Stat(name string) (fs.FileInfo, error) {
+	archiveName, entry, ok := archive.Split(name)
+	if !ok {
+		info, err := a.Source.Stat(name)
+		if err != nil || !archive.IsArchive(name) {
+			return info, err
+		}
+		return archiveInfo{info}, nil
+	}
+
+	entries, err := a.list(archiveName)
+	if err != nil {
+		return nil, err
+	}
+
+	info, ok := entries[entry]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return info, nil
+}
+
+// list returns entries of the given archive. Lists are cached until the archive is modified.
+func (a *archives) list(name string) (map[string]fs.FileInfo, error) {
+	info, err := a.Source.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	l, ok := a.lists[name]
+	a.mu.Unlock()
+	if ok && l.modTime.Equal(info.ModTime()) {
+		return l.entries, nil
+	}
+
+	entries, err := archive.List(a.Source, name)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	a.lists[name] = &entryList{modTime: info.ModTime(), entries: entries}
+	a.mu.Unlock()
+	return entries, nil
+}
+
+func (a *archives) Walk(ctx context.Context, fn fs.WalkDirFunc) error {
+	return a.Source.Walk(ctx, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !archive.IsArchive(name) {
+			return fn(name, d, err)
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return fn(name, d, err)
+		}
+		d = fs.FileInfoToDirEntry(archiveInfo{info})
+		if err = fn(name, d, nil); err != nil {
+			if errors.Is(err, fs.SkipDir) {
+				return nil
+			}
+			return err
+		}
+
+		err = archive.Walk(a.Source, name, func(entry string, info fs.FileInfo, r io.Reader) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			e := &Entry{DirEntry: fs.FileInfoToDirEntry(info), info: info, r: r}
+			defer e.done.Store(true)
+			if err := fn(archive.Join(name, entry), e, nil); err != nil {
+				return &walkFuncError{err}
+			}
+			return nil
+		})
+
+		var fnErr *walkFuncError
+		switch {
+		case errors.As(err, &fnErr):
+			if errors.Is(fnErr.err, fs.SkipDir) {
+				return nil
+			}
+			return fnErr.err
+		case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+			return err
+		case err != nil:
+			// report a broken archive in the same way as an unreadable directory.
+			err = fn(name, d, err)
+			if errors.Is(err, fs.SkipDir) {
+				return nil
+			}
+			return err
+		default:
+			return nil
+		}
+	})
+}
+
+// walkFuncError distinguishes errors returned by a walk function from errors reading archives.
+type walkFuncError struct {
+	err error
+}
+
+func (e *walkFuncError) Error() string {
+	return e.err.Error()
+}
+
+// archiveInfo describes an archive as a directory.
+type archiveInfo struct {
+	fs.FileInfo
+}
+
+func (archiveInfo) IsDir() bool {
+	return true
+}
+
+func (i archiveInfo) Mode() fs.FileMode {
+	return i.FileInfo.Mode() | fs.ModeDir
+}
+
+// Entry is the fs.DirEntry which Walk passes to walk functions for files stored in archives. The file being visited
+// can be read by Open without opening the archive again.
+type Entry struct {
+	fs.DirEntry
+	info fs.FileInfo
+	r    io.Reader
+	// done is set when the walk function returns, after which the reader belongs to the next entry.
+	done atomic.Bool
+}
+
+// Open returns the file being visited. It reads the contents from the archive being walked, so that it is valid only
+// until the walk function returns, and the contents can be read only once.
+func (e *Entry) Open() fs.File {
+	return &entryFile{entry: e}
+}
+
+// entryFile reads an entry being visited by Walk.
+type entryFile struct {
+	entry *Entry
+}
+
+func (f *entryFile) Read(p []byte) (int, error) {
+	if f.entry.done.Load() {
+		return 0, fs.ErrClosed
+	}
+	return f.entry.r.Read(p)
+}
+
+func (f *entryFile) Stat() (fs.FileInfo, error) {
+	return f.entry.info, nil
+}
+
+func (*entryFile) Close() error {
+	return nil
+}
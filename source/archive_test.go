@@ -0,0 +1,210 @@
+// archive_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package source
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func newTestDir(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.png"), []byte("image a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Create(filepath.Join(dir, "old.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	e, err := w.Create("dir/b.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = io.WriteString(e, "image b"); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestArchivesWalk(t *testing.T) {
+	src := Archives(Dir(newTestDir(t)))
+
+	expect := map[string]string{
+		"a.png":              "image a",
+		"old.zip!/dir/b.png": "image b",
+	}
+	res := make(map[string]string)
+	err := src.Walk(context.Background(), func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		data, err := fs.ReadFile(src, name)
+		if err != nil {
+			return err
+		}
+		res[name] = string(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != len(expect) {
+		t.Errorf("expect %v, got %v", expect, res)
+	}
+	for k, v := range expect {
+		if res[k] != v {
+			t.Errorf("expect %q, got %q", v, res[k])
+		}
+	}
+}
+
+func TestArchivesWalkEntry(t *testing.T) {
+	src := Archives(Dir(newTestDir(t)))
+
+	var visited *Entry
+	err := src.Walk(context.Background(), func(name string, d fs.DirEntry, err error) error {
+		if err != nil || name != "old.zip!/dir/b.png" {
+			return err
+		}
+
+		e, ok := d.(*Entry)
+		if !ok {
+			t.Fatalf("expect an entry, got %T", d)
+		}
+		visited = e
+		data, err := io.ReadAll(e.Open())
+		if err != nil {
+			return err
+		}
+		if string(data) != "image b" {
+			t.Errorf("expect %q, got %q", "image b", data)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if visited == nil {
+		t.Fatal("expect the entry is visited")
+	}
+	if _, err = visited.Open().Read(make([]byte, 1)); !errors.Is(err, fs.ErrClosed) {
+		t.Errorf("expect a closed error after the walk function returns, got %v", err)
+	}
+}
+
+func TestArchivesOpenWhileWalking(t *testing.T) {
+	src := Archives(Dir(newTestDir(t)))
+
+	// files opened by others while their entries are visited never share the reader of the walk.
+	err := src.Walk(context.Background(), func(name string, d fs.DirEntry, err error) error {
+		if err != nil || name != "old.zip!/dir/b.png" {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		results := make([]string, 4)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				data, err := fs.ReadFile(src, name)
+				if err != nil {
+					results[i] = err.Error()
+				} else {
+					results[i] = string(data)
+				}
+			}(i)
+		}
+		data, err := io.ReadAll(d.(*Entry).Open())
+		wg.Wait()
+		if err != nil {
+			return err
+		}
+		for _, v := range append(results, string(data)) {
+			if v != "image b" {
+				t.Errorf("expect %q, got %q", "image b", v)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestArchivesSkipDir(t *testing.T) {
+	src := Archives(Dir(newTestDir(t)))
+
+	var res []string
+	err := src.Walk(context.Background(), func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && name == "old.zip" {
+			return fs.SkipDir
+		}
+		res = append(res, name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 || res[0] != "." || res[1] != "a.png" {
+		t.Errorf("expect [. a.png], got %v", res)
+	}
+}
+
+func TestArchivesOpen(t *testing.T) {
+	src := Archives(Dir(newTestDir(t)))
+
+	data, err := fs.ReadFile(src, "old.zip!/dir/b.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "image b" {
+		t.Errorf("expect %q, got %q", "image b", data)
+	}
+
+	info, err := src.Stat("old.zip!/dir/b.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != int64(len(data)) {
+		t.Errorf("expect %v, got %v", len(data), info.Size())
+	}
+
+	if _, err = src.Stat("old.zip!/dir/c.png"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expect a not exist error, got %v", err)
+	}
+	if info, err = src.Stat("old.zip"); err != nil {
+		t.Error(err)
+	} else if !info.IsDir() {
+		t.Error("expect an archive is a directory")
+	}
+}
@@ -0,0 +1,105 @@
+// dir.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package source
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// pollInterval is the interval of scanning directories to find changes.
+const pollInterval = time.Minute
+
+type dir struct {
+	fs.FS
+}
+
+// Dir returns a source of the given local directory.
+func Dir(root string) Source {
+	return &dir{FS: os.DirFS(root)}
+}
+
+func (d *dir) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(d.FS, name)
+}
+
+func (d *dir) Walk(ctx context.Context, fn fs.WalkDirFunc) error {
+	return walk(ctx, d.FS, fn)
+}
+
+// Watch reports directories whose entries have been added, removed or renamed. Directories are scanned periodically
+// since modification times of directories change in such cases.
+func (d *dir) Watch(ctx context.Context) (<-chan string, error) {
+	prev, err := d.modTimes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+
+		send := func(name string) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case ch <- name:
+				return true
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(pollInterval):
+			}
+
+			cur, err := d.modTimes(ctx)
+			if err != nil {
+				// the next scan will find the changes.
+				continue
+			}
+			for name, t := range cur {
+				if v, ok := prev[name]; (!ok || !v.Equal(t)) && !send(name) {
+					return
+				}
+			}
+			for name := range prev {
+				if _, ok := cur[name]; !ok && !send(name) {
+					return
+				}
+			}
+			prev = cur
+		}
+	}()
+
+	return ch, nil
+}
+
+// modTimes returns modification times of all directories keyed by their names.
+func (d *dir) modTimes(ctx context.Context) (map[string]time.Time, error) {
+	res := make(map[string]time.Time)
+	err := walk(ctx, d.FS, func(name string, e fs.DirEntry, err error) error {
+		if err != nil || !e.IsDir() {
+			return err
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		res[name] = info.ModTime()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,148 @@
+// mount.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package source
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type mount struct {
+	names   []string
+	sources map[string]Source
+}
+
+// Mount returns a source which combines the given sources. Files of each source have names prefixed with the key
+// of the source, e.g. "outputs/a.png". Keys must be valid names without slashes.
+func Mount(sources map[string]Source) Source {
+	names := make([]string, 0, len(sources))
+	for k := range sources {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	return &mount{names: names, sources: sources}
+}
+
+// resolve returns the source and the name in it of the given name.
+func (m *mount) resolve(op, name string) (Source, string, error) {
+	if !fs.ValidPath(name) || name == "." {
+		return nil, "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	first, rest, _ := strings.Cut(name, "/")
+	src, ok := m.sources[first]
+	if !ok {
+		return nil, "", &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	if rest == "" {
+		rest = "."
+	}
+	return src, rest, nil
+}
+
+func (m *mount) Open(name string) (fs.File, error) {
+	src, rest, err := m.resolve("open", name)
+	if err != nil {
+		return nil, err
+	}
+	return src.Open(rest)
+}
+
+func (m *mount) Stat(name string) (fs.FileInfo, error) {
+	if name == "." {
+		return rootInfo{}, nil
+	}
+
+	src, rest, err := m.resolve("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return src.Stat(rest)
+}
+
+func (m *mount) Walk(ctx context.Context, fn fs.WalkDirFunc) error {
+	err := fn(".", fs.FileInfoToDirEntry(rootInfo{}), nil)
+	if errors.Is(err, fs.SkipDir) || errors.Is(err, fs.SkipAll) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, prefix := range m.names {
+		stop := false
+		err = m.sources[prefix].Walk(ctx, func(name string, d fs.DirEntry, err error) error {
+			if name == "." {
+				name = prefix
+			} else {
+				name = prefix + "/" + name
+			}
+
+			err = fn(name, d, err)
+			if errors.Is(err, fs.SkipAll) {
+				stop = true
+			}
+			return err
+		})
+		if err != nil || stop {
+			return err
+		}
+	}
+	return nil
+}
+
+// Watch merges changes of the mounted sources which implement Watcher. The returned channel is nil if none of them
+// implement Watcher.
+func (m *mount) Watch(ctx context.Context) (<-chan string, error) {
+	var wg sync.WaitGroup
+	ch := make(chan string)
+	watching := false
+	for _, prefix := range m.names {
+		w, ok := m.sources[prefix].(Watcher)
+		if !ok {
+			continue
+		}
+		changes, err := w.Watch(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		watching = true
+		wg.Add(1)
+		go func(prefix string) {
+			defer wg.Done()
+			for name := range changes {
+				if name == "." {
+					name = prefix
+				} else {
+					name = prefix + "/" + name
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- name:
+				}
+			}
+		}(prefix)
+	}
+
+	if !watching {
+		return nil, nil
+	}
+
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+	return ch, nil
+}
@@ -0,0 +1,61 @@
+// mount_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package source
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMount(t *testing.T) {
+	outputs := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outputs, "a.png"), []byte("image a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	src := Mount(map[string]Source{
+		"outputs": Dir(outputs),
+		"archive": Archives(Dir(newTestDir(t))),
+	})
+
+	var res []string
+	err := src.Walk(context.Background(), func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			res = append(res, name)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{"archive/a.png", "archive/old.zip!/dir/b.png", "outputs/a.png"}
+	if strings.Join(res, ",") != strings.Join(expect, ",") {
+		t.Errorf("expect %v, got %v", expect, res)
+	}
+
+	for _, name := range expect {
+		if _, err = fs.ReadFile(src, name); err != nil {
+			t.Errorf("failed to read %v: %v", name, err)
+		}
+	}
+	if _, err = src.Stat("others/a.png"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expect a not exist error, got %v", err)
+	}
+	if _, err = src.Stat("outputs/../archive/a.png"); !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("expect an invalid error, got %v", err)
+	}
+}
@@ -0,0 +1,379 @@
+// s3.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package source
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// S3Config configures a source of an S3 compatible bucket.
+type S3Config struct {
+	// Endpoint is the URL of the storage service such as "https://s3.us-east-1.amazonaws.com" or
+	// "http://localhost:9000". Buckets are accessed with path-style URLs.
+	Endpoint string
+	Region   string
+	Bucket   string
+	// Prefix is the key prefix of the library in the bucket.
+	Prefix string
+
+	// Requests are signed with AWS Signature Version 4 if an access key is given; otherwise they are anonymous.
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// S3ConfigFromEnv returns a configuration of the given bucket with the standard AWS environment variables.
+func S3ConfigFromEnv(bucket, prefix string) S3Config {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	endpoint := os.Getenv("AWS_ENDPOINT_URL_S3")
+	if endpoint == "" {
+		endpoint = os.Getenv("AWS_ENDPOINT_URL")
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%v.amazonaws.com", region)
+	}
+
+	return S3Config{
+		Endpoint:        endpoint,
+		Region:          region,
+		Bucket:          bucket,
+		Prefix:          prefix,
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+type s3 struct {
+	cfg    S3Config
+	client *http.Client
+}
+
+// NewS3 returns a source of an S3 compatible bucket. http.DefaultClient is used if client is nil.
+func NewS3(cfg S3Config, client *http.Client) Source {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	if cfg.Prefix = strings.Trim(cfg.Prefix, "/"); cfg.Prefix != "" {
+		cfg.Prefix += "/"
+	}
+	return &s3{cfg: cfg, client: client}
+}
+
+func (s *s3) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errors.New("is a directory")}
+	}
+
+	res, err := s.do(context.Background(), http.MethodGet, s.cfg.Prefix+name, nil, nil)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &object{
+		ReadCloser: res.Body,
+		info:       newObjectInfo(name, res.Header),
+		s:          s,
+		name:       name,
+		etag:       res.Header.Get("ETag"),
+	}, nil
+}
+
+func (s *s3) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return rootInfo{}, nil
+	}
+
+	res, err := s.do(context.Background(), http.MethodHead, s.cfg.Prefix+name, nil, nil)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	if err = res.Body.Close(); err != nil {
+		return nil, err
+	}
+	return newObjectInfo(name, res.Header), nil
+}
+
+// Walk visits the root and all objects under the prefix. Intermediate directories are not visited; fs.SkipDir
+// returned for an object skips the remaining objects in the same directory.
+func (s *s3) Walk(ctx context.Context, fn fs.WalkDirFunc) error {
+	err := fn(".", fs.FileInfoToDirEntry(rootInfo{}), nil)
+	if errors.Is(err, fs.SkipDir) || errors.Is(err, fs.SkipAll) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	skipped := make(map[string]struct{})
+	isSkipped := func(name string) bool {
+		for dir := path.Dir(name); ; dir = path.Dir(dir) {
+			if _, ok := skipped[dir]; ok {
+				return true
+			}
+			if dir == "." {
+				return false
+			}
+		}
+	}
+
+	var token string
+	for {
+		query := url.Values{"list-type": {"2"}}
+		if s.cfg.Prefix != "" {
+			query.Set("prefix", s.cfg.Prefix)
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		res, err := s.list(ctx, query)
+		if err != nil {
+			return fn(".", fs.FileInfoToDirEntry(rootInfo{}), err)
+		}
+
+		for _, c := range res.Contents {
+			name := strings.TrimPrefix(c.Key, s.cfg.Prefix)
+			if strings.HasSuffix(name, "/") || !fs.ValidPath(name) || isSkipped(name) {
+				continue
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			info := &objectInfo{name: path.Base(name), size: c.Size, modTime: c.LastModified}
+			err = fn(name, fs.FileInfoToDirEntry(info), nil)
+			if errors.Is(err, fs.SkipDir) {
+				skipped[path.Dir(name)] = struct{}{}
+			} else if errors.Is(err, fs.SkipAll) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+
+		if !res.IsTruncated || res.NextContinuationToken == "" {
+			return nil
+		}
+		token = res.NextContinuationToken
+	}
+}
+
+type listBucketResult struct {
+	IsTruncated           bool            `xml:"IsTruncated"`
+	NextContinuationToken string          `xml:"NextContinuationToken"`
+	Contents              []objectSummary `xml:"Contents"`
+}
+
+type objectSummary struct {
+	Key          string    `xml:"Key"`
+	LastModified time.Time `xml:"LastModified"`
+	Size         int64     `xml:"Size"`
+}
+
+func (s *s3) list(ctx context.Context, query url.Values) (_ *listBucketResult, err error) {
+	res, err := s.do(ctx, http.MethodGet, "", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, res.Body.Close())
+	}()
+
+	var v listBucketResult
+	if err = xml.NewDecoder(res.Body).Decode(&v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// do sends a request for the given key with the given headers and returns the response if it succeeds.
+func (s *s3) do(ctx context.Context, method, key string, query url.Values, header http.Header) (*http.Response, error) {
+	u, err := url.Parse(s.cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	p := "/" + s.cfg.Bucket
+	if key != "" {
+		p += "/" + key
+	}
+	u.RawPath = strings.TrimSuffix(u.EscapedPath(), "/") + escapePath(p)
+	u.Path = strings.TrimSuffix(u.Path, "/") + p
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if s.cfg.AccessKeyID != "" {
+		signRequest(req, s.cfg, time.Now())
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode/100 == 2 {
+		return res, nil
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusNotFound:
+		return nil, fs.ErrNotExist
+	case http.StatusForbidden:
+		return nil, fs.ErrPermission
+	case http.StatusPreconditionFailed:
+		return nil, errModified
+	}
+
+	var v struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err = xml.NewDecoder(res.Body).Decode(&v); err != nil || v.Message == "" {
+		return nil, errors.New(res.Status)
+	}
+	return nil, fmt.Errorf("%v: %v", v.Code, v.Message)
+}
+
+// errModified is returned when an object is modified while reading it.
+var errModified = errors.New("object was modified while reading it")
+
+// object is an object opened from a bucket. Besides reading the object from the start, it reads ranges of the object
+// with ReadAt, e.g. for zip archives, by requests of the ranges. Since readers of ranges usually read the following
+// range next, each request reads the rest of the object, which is continued if the next range follows the previous one.
+type object struct {
+	io.ReadCloser
+	info fs.FileInfo
+	s    *s3
+	name string
+	// etag makes requests of ranges fail if the object is modified after opening it.
+	etag string
+
+	mu sync.Mutex
+	// rest is the response of the last request of a range, which is at offset pos.
+	rest io.ReadCloser
+	pos  int64
+}
+
+func (o *object) Stat() (fs.FileInfo, error) {
+	return o.info, nil
+}
+
+// ReadAt implements io.ReaderAt.
+func (o *object) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, &fs.PathError{Op: "read", Path: o.name, Err: fs.ErrInvalid}
+	}
+	if off >= o.info.Size() {
+		return 0, io.EOF
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.rest == nil || o.pos != off {
+		if err := o.closeRest(); err != nil {
+			return 0, err
+		}
+		header := http.Header{"Range": {fmt.Sprintf("bytes=%v-", off)}}
+		if o.etag != "" {
+			header.Set("If-Match", o.etag)
+		}
+		res, err := o.s.do(context.Background(), http.MethodGet, o.s.cfg.Prefix+o.name, nil, header)
+		if err != nil {
+			return 0, &fs.PathError{Op: "read", Path: o.name, Err: err}
+		}
+		if res.StatusCode != http.StatusPartialContent {
+			err = fmt.Errorf("range requests are not supported: %v", res.Status)
+			return 0, &fs.PathError{Op: "read", Path: o.name, Err: errors.Join(err, res.Body.Close())}
+		}
+		o.rest, o.pos = res.Body, off
+	}
+
+	n, err := io.ReadFull(o.rest, p)
+	o.pos += int64(n)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (o *object) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return errors.Join(o.ReadCloser.Close(), o.closeRest())
+}
+
+// closeRest closes the response of the last request of a range. o.mu must be locked.
+func (o *object) closeRest() error {
+	if o.rest == nil {
+		return nil
+	}
+	err := o.rest.Close()
+	o.rest = nil
+	return err
+}
+
+type objectInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func newObjectInfo(name string, header http.Header) *objectInfo {
+	size, _ := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+	modTime, _ := http.ParseTime(header.Get("Last-Modified"))
+	return &objectInfo{name: path.Base(name), size: size, modTime: modTime}
+}
+
+func (i *objectInfo) Name() string       { return i.name }
+func (i *objectInfo) Size() int64        { return i.size }
+func (i *objectInfo) Mode() fs.FileMode  { return 0444 }
+func (i *objectInfo) ModTime() time.Time { return i.modTime }
+func (i *objectInfo) IsDir() bool        { return false }
+func (i *objectInfo) Sys() any           { return nil }
+
+// rootInfo describes the root directory of a bucket.
+type rootInfo struct{}
+
+func (rootInfo) Name() string       { return "." }
+func (rootInfo) Size() int64        { return 0 }
+func (rootInfo) Mode() fs.FileMode  { return fs.ModeDir | 0555 }
+func (rootInfo) ModTime() time.Time { return time.Time{} }
+func (rootInfo) IsDir() bool        { return true }
+func (rootInfo) Sys() any           { return nil }
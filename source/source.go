@@ -0,0 +1,67 @@
+// source.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+// Package source provides libraries of image files stored in local directories, archives and S3 compatible buckets.
+package source
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// Source is a library of image files. Names of files follow the conventions of io/fs, i.e. they are slash-separated
+// paths relative to the root of the library.
+type Source interface {
+	fs.StatFS
+
+	// Walk walks the file tree of the library from its root in the manner of fs.WalkDir.
+	Walk(ctx context.Context, fn fs.WalkDirFunc) error
+}
+
+// Watcher is an optional interface implemented by sources which can report changes of their files.
+type Watcher interface {
+	// Watch returns a channel which receives names of changed directories. The channel is closed when ctx is done.
+	Watch(ctx context.Context) (<-chan string, error)
+}
+
+// New returns a source of the given location, which is either a path to a local directory or an URL of an S3
+// compatible bucket such as "s3://bucket/prefix". Files stored in archives are also accessible through the source.
+//
+// The endpoint, region and credentials of S3 compatible storages are read from the standard AWS environment
+// variables: AWS_ENDPOINT_URL, AWS_REGION, AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN.
+func New(location string) (Source, error) {
+	if bucket, ok := strings.CutPrefix(location, "s3://"); ok {
+		bucket, prefix, _ := strings.Cut(bucket, "/")
+		if bucket == "" {
+			return nil, fmt.Errorf("no bucket name: %v", location)
+		}
+		return Archives(NewS3(S3ConfigFromEnv(bucket, prefix), nil)), nil
+	}
+
+	info, err := os.Stat(location)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%v is not a directory", location)
+	}
+	return Archives(Dir(location)), nil
+}
+
+// walk calls fs.WalkDir but stops when ctx is done.
+func walk(ctx context.Context, fsys fs.FS, fn fs.WalkDirFunc) error {
+	return fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fn(name, d, err)
+	})
+}
@@ -0,0 +1,159 @@
+// stats.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/catalog"
+	"github.com/jkawamoto/sd-image-viewer/image"
+)
+
+// statsCommand prints summaries of the index and statistics of images matching the same filters as GET /images.
+type statsCommand struct {
+	global *options
+	filterOptions
+	Checkpoints int    `long:"checkpoints" default:"10" description:"number of the most used checkpoints to print"`
+	Top         int    `long:"top" default:"10" description:"number of the most used samplers, tags of prompts and resolutions to print; zero prints all"`
+	Interval    string `long:"interval" choice:"day" choice:"week" choice:"month" default:"day" description:"interval of periods counting images"`
+	Periods     int    `long:"periods" default:"7" description:"number of the latest periods to print; zero prints all"`
+}
+
+func (c *statsCommand) Execute([]string) (err error) {
+	if c.Checkpoints < 0 || c.Top < 0 || c.Periods < 0 {
+		return errors.New("checkpoints, top and periods must not be negative")
+	}
+	filter, err := c.filter("")
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(c.global.Config, defaultConfig(), c.global.apply)
+	if err != nil {
+		return err
+	}
+
+	index, err := catalog.OpenBleveReadOnly(cfg.Index)
+	if err != nil {
+		return fmt.Errorf("failed to open the index: %w", err)
+	}
+	defer func() {
+		err = errors.Join(err, index.Close())
+	}()
+
+	libs := []*library{{}}
+	if len(cfg.Libraries) != 0 {
+		libs = make([]*library, len(cfg.Libraries))
+		for i, l := range cfg.Libraries {
+			libs[i] = &library{Name: l.Name}
+		}
+	}
+	req := &catalog.StatsRequest{Filter: filter, Interval: c.Interval, Periods: c.Periods, Size: c.Top}
+	return writeStats(context.Background(), os.Stdout, index, libs, req, c.Checkpoints)
+}
+
+// writeStats writes the numbers of failures and when the given libraries were indexed, and statistics of images given
+// by the request, where at most the given number of checkpoints are written.
+func writeStats(
+	ctx context.Context, w io.Writer, c catalog.Catalog, libs []*library, req *catalog.StatsRequest, checkpoints int,
+) error {
+	// the statistics are computed with enough terms for both the checkpoints and the other lists.
+	top := req.Size
+	if top > 0 && checkpoints > top {
+		r := *req
+		r.Size = checkpoints
+		req = &r
+	}
+	stats, err := c.Stats(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to compute statistics: %w", err)
+	}
+	version, err := c.GetMeta(indexVersionKey)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Index version:\t%s\n", version)
+	fmt.Fprintf(tw, "Images:\t%v\n", stats.Total)
+
+	failures, err := c.SearchFailures(ctx, &catalog.FailureRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to count failures: %w", err)
+	}
+	fmt.Fprintf(tw, "Failures:\t%v\n", failures.Total)
+	for _, class := range image.FailureClasses {
+		res, err := c.SearchFailures(ctx, &catalog.FailureRequest{Classes: []string{class}})
+		if err != nil {
+			return fmt.Errorf("failed to count failures: %w", err)
+		}
+		if res.Total != 0 {
+			fmt.Fprintf(tw, "  %v:\t%v\n", class, res.Total)
+		}
+	}
+
+	fmt.Fprintln(tw, "Last indexed:\t")
+	for _, lib := range libs {
+		name := lib.Name
+		if name == "" {
+			name = "(default)"
+		}
+		v, err := c.GetMeta(lib.lastIndexedKey())
+		if err != nil {
+			return err
+		}
+		var t time.Time
+		if v == nil {
+			fmt.Fprintf(tw, "  %v:\tnever\n", name)
+		} else if err = t.UnmarshalText(v); err != nil {
+			return fmt.Errorf("failed to parse the last indexed time: %w", err)
+		} else {
+			fmt.Fprintf(tw, "  %v:\t%v\n", name, t.Local().Format(time.DateTime))
+		}
+	}
+
+	writeFacets(tw, "Images per "+req.Interval, stats.Created, 0)
+	if checkpoints > 0 && len(stats.Checkpoints) != 0 {
+		fmt.Fprintln(tw, "Checkpoints:\t")
+		for i, v := range stats.Checkpoints {
+			if i == checkpoints {
+				break
+			}
+			fmt.Fprintf(tw, "  %v:\t%v\tsteps %.1f, CFG scale %.1f\n", v.Name, v.Count, v.AverageSteps, v.AverageCFGScale)
+			for _, f := range v.Created {
+				fmt.Fprintf(tw, "    %v:\t%v\n", f.Term, f.Count)
+			}
+		}
+	}
+	writeFacets(tw, "Samplers", stats.Samplers, top)
+	writeFacets(tw, "Prompt tags", stats.PromptTags, top)
+	writeFacets(tw, "Resolutions", stats.Resolutions, top)
+	writeFacets(tw, "Sizes", stats.Sizes, 0)
+	return tw.Flush()
+}
+
+// writeFacets writes at most size terms and their counts under the given title unless there are no terms. Zero size
+// means no limits.
+func writeFacets(w io.Writer, title string, facets []*catalog.Facet, size int) {
+	if len(facets) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%v:\t\n", title)
+	for i, f := range facets {
+		if i == size && size > 0 {
+			break
+		}
+		fmt.Fprintf(w, "  %v:\t%v\n", f.Term, f.Count)
+	}
+}
@@ -0,0 +1,393 @@
+// thumbnail.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+// Package thumbnail creates thumbnails of images and caches them in a directory.
+package thumbnail
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// DefaultWidth is the width of thumbnails created in the background.
+	DefaultWidth = 256
+
+	jpegQuality = 85
+	// evictionRatio is the ratio of the size of the cache to its limit after evicting thumbnails.
+	evictionRatio = 0.9
+	// queueSize is the number of thumbnails waiting to be created in the background.
+	queueSize = 256
+
+	jpegExt = ".jpg"
+	pngExt  = ".png"
+	tmpExt  = ".tmp"
+)
+
+// Widths are the widths of thumbnails. Requested widths are rounded up to one of them so that thumbnails are shared.
+var Widths = []int{128, 256, 512, 1024}
+
+// Width rounds up the given width to one of Widths. Widths larger than all of them are rounded down to the largest.
+func Width(w int) int {
+	for _, v := range Widths {
+		if w <= v {
+			return v
+		}
+	}
+	return Widths[len(Widths)-1]
+}
+
+// Cache creates thumbnails and stores them in a directory. When the total size of thumbnails exceeds the limit, the
+// least recently used ones are removed.
+//
+// Thumbnails are keyed by digests of the contents of the original files, e.g. those computed while indexing, and their
+// widths, so that modified files get new thumbnails, stale ones are removed eventually, and files of the same content
+// share thumbnails.
+type Cache struct {
+	dir    string
+	limit  int64
+	logger *log.Logger
+
+	mu   sync.Mutex
+	size int64
+
+	// sem limits the number of thumbnails created at the same time, which require decoding full-size images.
+	sem    chan struct{}
+	queue  chan *job
+	wg     sync.WaitGroup
+	closed bool
+	// closing guards sending to queue against closing it.
+	closing sync.RWMutex
+	// stopped is set when closing, and makes workers discard queued thumbnails.
+	stopped atomic.Bool
+}
+
+type job struct {
+	fsys fs.FS
+	name string
+	key  string
+}
+
+// Open opens a cache in the given directory, which is created if it doesn't exist. limit is the maximum total size
+// of thumbnails in bytes.
+func Open(dir string, limit int64, logger *log.Logger) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	c := &Cache{
+		dir:    dir,
+		limit:  limit,
+		logger: logger,
+		sem:    make(chan struct{}, runtime.NumCPU()),
+		queue:  make(chan *job, queueSize),
+	}
+	files, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		c.size += f.size
+	}
+
+	workers := runtime.NumCPU() / 2
+	if workers == 0 {
+		workers = 1
+	}
+	c.wg.Add(workers)
+	for i := 0; i != workers; i++ {
+		go func() {
+			defer c.wg.Done()
+			for j := range c.queue {
+				if c.stopped.Load() {
+					// queued thumbnails are discarded not to delay shutdown; they are created on demand.
+					continue
+				}
+				if f, _, err := c.lookup(j.key); err == nil {
+					c.closeFile(f)
+					continue
+				}
+				if _, _, err := c.create(j.fsys, j.name, j.key, DefaultWidth); err != nil {
+					c.logger.Printf("Failed to create a thumbnail of %v: %v", j.name, err)
+				}
+			}
+		}()
+	}
+	return c, nil
+}
+
+// Open returns the thumbnail of the given width of the file of the given name in fsys with its content type. digest
+// is the digest of the content of the file. The thumbnail is created if it isn't cached.
+func (c *Cache) Open(fsys fs.FS, name, digest string, width int) (*os.File, string, error) {
+	k := key(digest, width)
+	f, contentType, err := c.lookup(k)
+	if err == nil {
+		return f, contentType, nil
+	}
+	return c.create(fsys, name, k, width)
+}
+
+// Prefetch creates the thumbnail of DefaultWidth of the given file in the background if it isn't cached. It blocks
+// while many thumbnails are waiting to be created until ctx is done.
+func (c *Cache) Prefetch(ctx context.Context, fsys fs.FS, name, digest string) {
+	c.closing.RLock()
+	defer c.closing.RUnlock()
+	if c.closed {
+		return
+	}
+
+	select {
+	case c.queue <- &job{fsys: fsys, name: name, key: key(digest, DefaultWidth)}:
+	case <-ctx.Done():
+	}
+}
+
+// Create creates the thumbnail of DefaultWidth of the given file if it isn't cached, and waits for it. It is used for
+// files which can't be opened again cheaply, e.g. entries of archives read while walking them.
+func (c *Cache) Create(fsys fs.FS, name, digest string) error {
+	k := key(digest, DefaultWidth)
+	f, _, err := c.lookup(k)
+	if err != nil {
+		f, _, err = c.create(fsys, name, k, DefaultWidth)
+		if err != nil {
+			return err
+		}
+	}
+	c.closeFile(f)
+	return nil
+}
+
+// Close stops creating thumbnails in the background. Thumbnails being created are completed, and the queued ones are
+// discarded.
+func (c *Cache) Close() error {
+	c.stopped.Store(true)
+	c.closing.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.queue)
+	}
+	c.closing.Unlock()
+
+	c.wg.Wait()
+	return nil
+}
+
+// key returns the key of the thumbnail of the given width of a file of the given digest.
+func key(digest string, width int) string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%v\x00%v", digest, width)))
+	return hex.EncodeToString(h[:])
+}
+
+// path returns the path of the thumbnail of the given key. Thumbnails are stored in subdirectories named by the first
+// two characters of their keys to keep directories small.
+func (c *Cache) path(key, ext string) string {
+	return filepath.Join(c.dir, key[:2], key+ext)
+}
+
+// lookup opens the cached thumbnail of the given key, and marks it as recently used.
+func (c *Cache) lookup(key string) (*os.File, string, error) {
+	for _, ext := range []string{jpegExt, pngExt} {
+		name := c.path(key, ext)
+		f, err := os.Open(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, "", err
+		}
+
+		now := time.Now()
+		if err = os.Chtimes(name, now, now); err != nil {
+			c.logger.Printf("Failed to update the modification time of a thumbnail: %v", err)
+		}
+		return f, contentType(ext), nil
+	}
+	return nil, "", fs.ErrNotExist
+}
+
+// create creates the thumbnail of the given key and opens it.
+func (c *Cache) create(fsys fs.FS, name, key string, width int) (_ *os.File, _ string, err error) {
+	c.sem <- struct{}{}
+	defer func() {
+		<-c.sem
+	}()
+
+	src, err := fsys.Open(name)
+	if err != nil {
+		return nil, "", err
+	}
+	img, _, err := image.Decode(src)
+	err = errors.Join(err, src.Close())
+	if err != nil {
+		return nil, "", err
+	}
+	img = Resize(img, width)
+
+	ext, encode := jpegExt, func(w io.Writer, img image.Image) error {
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+	}
+	if o, ok := img.(interface{ Opaque() bool }); ok && !o.Opaque() {
+		ext, encode = pngExt, png.Encode
+	}
+
+	dst := c.path(key, ext)
+	if err = os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return nil, "", err
+	}
+	// thumbnails are written to temporary files first so that incomplete ones are never served.
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "*"+tmpExt)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, os.Remove(tmp.Name()))
+		}
+	}()
+	if err = encode(tmp, img); err != nil {
+		return nil, "", errors.Join(err, tmp.Close())
+	}
+	info, err := tmp.Stat()
+	if err != nil {
+		return nil, "", errors.Join(err, tmp.Close())
+	}
+	if err = tmp.Close(); err != nil {
+		return nil, "", err
+	}
+	if err = os.Rename(tmp.Name(), dst); err != nil {
+		return nil, "", err
+	}
+
+	f, err := os.Open(dst)
+	if err != nil {
+		return nil, "", err
+	}
+	c.add(info.Size())
+	return f, contentType(ext), nil
+}
+
+// add adds the given size of a new thumbnail to the size of the cache, and evicts thumbnails if it exceeds the limit.
+func (c *Cache) add(size int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.size += size
+	if c.size <= c.limit {
+		return
+	}
+	if err := c.evict(); err != nil {
+		c.logger.Printf("Failed to evict thumbnails: %v", err)
+	}
+}
+
+// evict removes the least recently used thumbnails until the size of the cache is reduced to evictionRatio of the
+// limit. c.mu must be locked.
+func (c *Cache) evict() error {
+	files, err := c.files()
+	if err != nil {
+		return err
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	c.size = 0
+	for _, f := range files {
+		c.size += f.size
+	}
+	for _, f := range files {
+		if c.size <= int64(float64(c.limit)*evictionRatio) {
+			break
+		}
+		if err = os.Remove(f.name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		c.size -= f.size
+	}
+	return nil
+}
+
+type cachedFile struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+// files returns thumbnails in the cache. Temporary files left by interrupted processes are removed.
+func (c *Cache) files() ([]*cachedFile, error) {
+	var res []*cachedFile
+	err := filepath.WalkDir(c.dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if strings.HasSuffix(name, tmpExt) {
+			if info, err := d.Info(); err == nil && time.Since(info.ModTime()) > time.Hour {
+				return os.Remove(name)
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		res = append(res, &cachedFile{name: name, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	return res, err
+}
+
+func (c *Cache) closeFile(f *os.File) {
+	if err := f.Close(); err != nil {
+		c.logger.Printf("Failed to close a thumbnail: %v", err)
+	}
+}
+
+func contentType(ext string) string {
+	if ext == pngExt {
+		return "image/png"
+	}
+	return "image/jpeg"
+}
+
+// Resize returns a copy of the given image scaled down to the given width, keeping its aspect ratio, with the
+// Catmull-Rom filter. It returns the image as is if it isn't wider than the width.
+func Resize(img image.Image, width int) image.Image {
+	b := img.Bounds()
+	if b.Dx() <= width {
+		return img
+	}
+	height := b.Dy() * width / b.Dx()
+	if height == 0 {
+		height = 1
+	}
+
+	res := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(res, res.Bounds(), img, b, draw.Src, nil)
+	return res
+}
@@ -0,0 +1,234 @@
+// thumbnail_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package thumbnail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func newTestFS(t *testing.T, names ...string) fstest.MapFS {
+	t.Helper()
+
+	fsys := make(fstest.MapFS)
+	for i, name := range names {
+		img := image.NewNRGBA(image.Rect(0, 0, 600, 400))
+		for y := 0; y != 400; y++ {
+			for x := 0; x != 600; x++ {
+				img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: uint8(i * 50), A: 0xff})
+			}
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			t.Fatal(err)
+		}
+		fsys[name] = &fstest.MapFile{Data: buf.Bytes(), ModTime: time.Unix(int64(i), 0)}
+	}
+	return fsys
+}
+
+func openTestCache(t *testing.T, limit int64) (*Cache, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	c, err := Open(dir, limit, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return c, dir
+}
+
+func countFiles(t *testing.T, dir string) int {
+	t.Helper()
+
+	n := 0
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			n++
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestWidth(t *testing.T) {
+	cases := []struct {
+		width  int
+		expect int
+	}{
+		{width: 1, expect: 128},
+		{width: 128, expect: 128},
+		{width: 200, expect: 256},
+		{width: 1024, expect: 1024},
+		{width: 4096, expect: 1024},
+	}
+	for _, c := range cases {
+		if res := Width(c.width); res != c.expect {
+			t.Errorf("expect %v, got %v", c.expect, res)
+		}
+	}
+}
+
+func TestResize(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 600, 400))
+	if res := Resize(img, 128).Bounds(); res != image.Rect(0, 0, 128, 85) {
+		t.Errorf("expect %v, got %v", image.Rect(0, 0, 128, 85), res)
+	}
+	if res := Resize(img, 1024); res != image.Image(img) {
+		t.Error("expect a narrower image is returned as is")
+	}
+}
+
+func TestCacheOpen(t *testing.T) {
+	fsys := newTestFS(t, "a.png", "b.png")
+	c, dir := openTestCache(t, 1<<20)
+
+	// files of the same digest share the thumbnail.
+	for _, name := range []string{"a.png", "b.png"} {
+		f, contentType, err := c.Open(fsys, name, "digest", 256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if contentType != "image/jpeg" {
+			t.Errorf("expect image/jpeg, got %v", contentType)
+		}
+		cfg, _, err := image.DecodeConfig(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = f.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Width != 256 || cfg.Height != 170 {
+			t.Errorf("expect 256x170, got %vx%v", cfg.Width, cfg.Height)
+		}
+	}
+	if n := countFiles(t, dir); n != 1 {
+		t.Errorf("expect the thumbnail is cached once, got %v files", n)
+	}
+
+	// a modified file has a new digest and a new thumbnail.
+	f, _, err := c.Open(fsys, "a.png", "modified", 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if n := countFiles(t, dir); n != 2 {
+		t.Errorf("expect 2 thumbnails, got %v", n)
+	}
+}
+
+func TestCacheEvict(t *testing.T) {
+	names := []string{"a.png", "b.png", "c.png"}
+	fsys := newTestFS(t, names...)
+
+	// the limit allows about two and a half thumbnails.
+	tmp, _ := openTestCache(t, 1<<20)
+	f, _, err := tmp.Open(fsys, "a.png", "a.png", 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	c, _ := openTestCache(t, st.Size()*5/2)
+
+	var files []string
+	for i, name := range names {
+		f, _, err := c.Open(fsys, name, name, 128)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, f.Name())
+		if err = f.Close(); err != nil {
+			t.Fatal(err)
+		}
+		// modification times are used to find least recently used thumbnails.
+		used := time.Now().Add(time.Duration(i-len(names)) * time.Minute)
+		if err = os.Chtimes(f.Name(), used, used); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err = os.Stat(files[0]); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expect the least recently used thumbnail is removed: %v", err)
+	}
+	for _, name := range files[1:] {
+		if _, err = os.Stat(name); err != nil {
+			t.Errorf("expect %v remains: %v", name, err)
+		}
+	}
+}
+
+func TestCachePrefetch(t *testing.T) {
+	fsys := newTestFS(t, "a.png", "b.png")
+	dir := t.TempDir()
+	c, err := Open(dir, 1<<20, log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a.png", "b.png"} {
+		c.Prefetch(context.Background(), fsys, name, name)
+	}
+	for i := 0; countFiles(t, dir) != 2; i++ {
+		if i == 100 {
+			t.Fatal("expect 2 thumbnails are created in the background")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// prefetching after closing does nothing.
+	c.Prefetch(context.Background(), fsys, "a.png", "c.png")
+}
+
+func TestCacheCreate(t *testing.T) {
+	fsys := newTestFS(t, "a.png")
+	c, dir := openTestCache(t, 1<<20)
+
+	// the thumbnail is created before returning, and isn't created again.
+	for i := 0; i != 2; i++ {
+		if err := c.Create(fsys, "a.png", "a.png"); err != nil {
+			t.Fatal(err)
+		}
+		if n := countFiles(t, dir); n != 1 {
+			t.Errorf("expect 1 thumbnail, got %v", n)
+		}
+		delete(fsys, "a.png")
+	}
+}
@@ -0,0 +1,231 @@
+// selfsigned.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package tlscert
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// CAFile is the name of the certificate of the self-signed CA, which clients can install to trust the server.
+	CAFile    = "ca.crt"
+	caKeyFile = "ca.key"
+	// CertFile and KeyFile are the names of the server certificate and its private key.
+	CertFile = "server.crt"
+	KeyFile  = "server.key"
+
+	caValidity = 10 * 365 * 24 * time.Hour
+	// certValidity is kept shorter than 398 days, which some clients require even for private CAs.
+	certValidity = 397 * 24 * time.Hour
+	// renewBefore is the remaining validity period at which server certificates are renewed.
+	renewBefore = 30 * 24 * time.Hour
+
+	organization = "SD image viewer"
+)
+
+// SelfSigned creates a CA and a server certificate signed by it in the given directory, and returns paths to the
+// server certificate and its key. The server certificate is valid for the given host names and IP addresses.
+//
+// Existing files are reused. The server certificate is created again if it expires soon or doesn't cover all the
+// names, but the CA is kept so that clients which have installed it keep trusting the server.
+func SelfSigned(dir string, names []string, logger *log.Logger) (certFile, keyFile string, err error) {
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return "", "", err
+	}
+	certFile, keyFile = filepath.Join(dir, CertFile), filepath.Join(dir, KeyFile)
+
+	ca, caKey, err := loadCA(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		ca, caKey, err = createCA(dir)
+		if err == nil {
+			logger.Printf("Created a self-signed CA certificate %v, which clients can install to trust the server",
+				filepath.Join(dir, CAFile))
+		}
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	if valid(certFile, keyFile, ca, names) {
+		return certFile, keyFile, nil
+	}
+	if err = createCert(certFile, keyFile, ca, caKey, names); err != nil {
+		return "", "", err
+	}
+	logger.Printf("Created a server certificate %v for %v", certFile, names)
+	return certFile, keyFile, nil
+}
+
+// loadCA reads the certificate and the private key of the CA in the given directory.
+func loadCA(dir string) (*x509.Certificate, crypto.Signer, error) {
+	pair, err := tls.LoadX509KeyPair(filepath.Join(dir, CAFile), filepath.Join(dir, caKeyFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	ca, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	key, ok := pair.PrivateKey.(crypto.Signer)
+	if !ok || !ca.IsCA {
+		return nil, nil, fmt.Errorf("%v is not a CA certificate", filepath.Join(dir, CAFile))
+	}
+	return ca, key, nil
+}
+
+// createCA creates a CA in the given directory.
+func createCA(dir string) (*x509.Certificate, crypto.Signer, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+	host, _ := os.Hostname()
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{organization}, CommonName: organization + " CA " + host},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, nil, err
+	}
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = writePair(filepath.Join(dir, CAFile), filepath.Join(dir, caKeyFile), der, key); err != nil {
+		return nil, nil, err
+	}
+	return ca, key, nil
+}
+
+// createCert creates a server certificate for the given names signed by the CA.
+func createCert(certFile, keyFile string, ca *x509.Certificate, caKey crypto.Signer, names []string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{organization}},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(certValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, name := range names {
+		if ip := net.ParseIP(name); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else {
+			tmpl.DNSNames = append(tmpl.DNSNames, name)
+		}
+	}
+	if len(names) != 0 {
+		tmpl.Subject.CommonName = names[0]
+	}
+	if ca.NotAfter.Before(tmpl.NotAfter) {
+		tmpl.NotAfter = ca.NotAfter
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, key.Public(), caKey)
+	if err != nil {
+		return err
+	}
+	return writePair(certFile, keyFile, der, key)
+}
+
+// valid returns true if the server certificate in the given files is signed by the CA, covers all the names and
+// doesn't expire soon.
+func valid(certFile, keyFile string, ca *x509.Certificate, names []string) bool {
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil || time.Until(cert.NotAfter) < renewBefore {
+		return false
+	}
+	if cert.CheckSignatureFrom(ca) != nil {
+		return false
+	}
+	for _, name := range names {
+		if cert.VerifyHostname(name) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// writePair writes a certificate and its private key in PEM. The key is written first so that loaders watching the
+// certificate don't read a new certificate with an old key.
+func writePair(certFile, keyFile string, der []byte, key *ecdsa.PrivateKey) error {
+	b, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err = writeFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b}), 0600); err != nil {
+		return err
+	}
+	return writeFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644)
+}
+
+// writeFile writes data to a temporary file and renames it so that the file is replaced atomically.
+func writeFile(name string, data []byte, perm fs.FileMode) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	_, err = f.Write(data)
+	if err = errors.Join(err, f.Chmod(perm), f.Close()); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+// newSerialNumber returns a random serial number of certificates.
+func newSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
@@ -0,0 +1,134 @@
+// tlscert.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+// Package tlscert provides certificates of the HTTPS server. Certificates are loaded from files, which are reloaded
+// when they are modified, or created by a self-signed CA stored next to the index without any network access.
+package tlscert
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// checkInterval is the minimum interval of checking modifications of certificate files.
+const checkInterval = 5 * time.Second
+
+// Loader loads a certificate and its private key from files, and reloads them when they are modified so that renewed
+// certificates are used without restarting the server.
+type Loader struct {
+	certFile string
+	keyFile  string
+	logger   *log.Logger
+	// renew creates the files again if the certificate expires soon. It is nil unless the certificate is self-signed.
+	renew func() error
+
+	mu       sync.Mutex
+	cert     *tls.Certificate
+	notAfter time.Time
+	modTime  time.Time
+	checked  time.Time
+}
+
+// Load loads a certificate and its private key from the given files.
+func Load(certFile, keyFile string, logger *log.Logger) (*Loader, error) {
+	l := &Loader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		logger:   logger,
+	}
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// LoadSelfSigned creates a self-signed certificate for the given names in the given directory by SelfSigned, and
+// loads it. The certificate is renewed while running when it expires soon.
+func LoadSelfSigned(dir string, names []string, logger *log.Logger) (*Loader, error) {
+	certFile, keyFile, err := SelfSigned(dir, names, logger)
+	if err != nil {
+		return nil, err
+	}
+	l, err := Load(certFile, keyFile, logger)
+	if err != nil {
+		return nil, err
+	}
+	l.renew = func() error {
+		_, _, err := SelfSigned(dir, names, logger)
+		return err
+	}
+	return l, nil
+}
+
+// GetCertificate returns the current certificate. It can be used as tls.Config.GetCertificate.
+func (l *Loader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if time.Since(l.checked) >= checkInterval {
+		if l.renew != nil && time.Until(l.notAfter) < renewBefore {
+			// the renewed files are loaded below since their modification times change.
+			if err := l.renew(); err != nil {
+				l.logger.Printf("Failed to renew the certificate: %v", err)
+			}
+		}
+		if modTime, err := l.lastModified(); err == nil && !modTime.Equal(l.modTime) {
+			// the current certificate is kept if the files are being updated or broken, and they are tried again later.
+			if err = l.load(); err != nil {
+				l.logger.Printf("Failed to reload the certificate: %v", err)
+			} else {
+				l.logger.Printf("Reloaded the certificate %v", l.certFile)
+			}
+		}
+		l.checked = time.Now()
+	}
+	return l.cert, nil
+}
+
+// load reads the files. l.mu must be locked unless l is being created.
+func (l *Loader) load() error {
+	modTime, err := l.lastModified()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load %v: %w", l.certFile, err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse %v: %w", l.certFile, err)
+	}
+	l.cert = &cert
+	l.notAfter = leaf.NotAfter
+	l.modTime = modTime
+	return nil
+}
+
+// lastModified returns the last modification time of the certificate and the key files.
+func (l *Loader) lastModified() (time.Time, error) {
+	var res time.Time
+	var errs []error
+	for _, name := range []string{l.certFile, l.keyFile} {
+		info, err := os.Stat(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if info.ModTime().After(res) {
+			res = info.ModTime()
+		}
+	}
+	return res, errors.Join(errs...)
+}
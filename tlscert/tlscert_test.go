@@ -0,0 +1,228 @@
+// tlscert_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package tlscert
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"io"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// verify checks the server certificate in dir is trusted by the CA in dir for the given name.
+func verify(t *testing.T, dir, name string) error {
+	t.Helper()
+
+	l, err := Load(filepath.Join(dir, CertFile), filepath.Join(dir, KeyFile), log.New(io.Discard, "", 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := l.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, CAFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(b) {
+		t.Fatal("failed to parse the CA certificate")
+	}
+	_, err = leaf.Verify(x509.VerifyOptions{DNSName: name, Roots: roots})
+	return err
+}
+
+func TestSelfSigned(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "tls")
+	logger := log.New(io.Discard, "", 0)
+
+	certFile, keyFile, err := SelfSigned(dir, []string{"localhost", "127.0.0.1", "::1", "nas.local"}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certFile != filepath.Join(dir, CertFile) || keyFile != filepath.Join(dir, KeyFile) {
+		t.Errorf("expect files in %v, got %v and %v", dir, certFile, keyFile)
+	}
+	for _, name := range []string{"localhost", "127.0.0.1", "::1", "nas.local"} {
+		if err = verify(t, dir, name); err != nil {
+			t.Errorf("%v: %v", name, err)
+		}
+	}
+	if err = verify(t, dir, "example.com"); err == nil {
+		t.Error("expect the certificate isn't valid for other names")
+	}
+	for _, name := range []string{caKeyFile, KeyFile} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("expect %v is private, got %v", name, info.Mode())
+		}
+	}
+
+	ca, err := os.ReadFile(filepath.Join(dir, CAFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := os.ReadFile(certFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the same names reuse the certificate.
+	if _, _, err = SelfSigned(dir, []string{"localhost", "nas.local"}, logger); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(certFile); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(b, cert) {
+		t.Error("expect the certificate is reused")
+	}
+
+	// a new name renews the certificate with the same CA.
+	if _, _, err = SelfSigned(dir, []string{"localhost", "example.com"}, logger); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(filepath.Join(dir, CAFile)); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(b, ca) {
+		t.Error("expect the CA is kept")
+	}
+	if err = verify(t, dir, "example.com"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLoader(t *testing.T) {
+	src := t.TempDir()
+	logger := log.New(io.Discard, "", 0)
+	certFile, keyFile, err := SelfSigned(src, []string{"localhost"}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := Load(certFile, keyFile, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := l.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// broken files keep the current certificate.
+	if err = os.WriteFile(certFile, []byte("broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err = os.Chtimes(certFile, future, future); err != nil {
+		t.Fatal(err)
+	}
+	l.checked = time.Time{}
+	if res, err := l.GetCertificate(nil); err != nil || res != cert {
+		t.Errorf("expect the current certificate, got %v (%v)", res, err)
+	}
+
+	// renewed files are loaded.
+	if err = os.Remove(certFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = SelfSigned(src, []string{"localhost"}, logger); err != nil {
+		t.Fatal(err)
+	}
+	future = future.Add(time.Minute)
+	if err = os.Chtimes(certFile, future, future); err != nil {
+		t.Fatal(err)
+	}
+	l.checked = time.Time{}
+	res, err := l.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == cert || bytes.Equal(res.Certificate[0], cert.Certificate[0]) {
+		t.Error("expect the certificate is reloaded")
+	}
+
+	if _, err = Load(filepath.Join(src, "missing.crt"), keyFile, logger); err == nil {
+		t.Error("expect an error")
+	}
+}
+
+func TestLoadSelfSigned(t *testing.T) {
+	dir := t.TempDir()
+	logger := log.New(io.Discard, "", 0)
+	l, err := LoadSelfSigned(dir, []string{"localhost"}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// replace the certificate with one expiring soon.
+	ca, caKey, err := loadCA(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, key.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = writePair(filepath.Join(dir, CertFile), filepath.Join(dir, KeyFile), der, key); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err = os.Chtimes(filepath.Join(dir, CertFile), future, future); err != nil {
+		t.Fatal(err)
+	}
+	l.checked = time.Time{}
+	cert, err := l.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(cert.Certificate[0], der) {
+		t.Fatal("expect the expiring certificate is loaded")
+	}
+
+	// the expiring certificate is renewed on the next check.
+	l.checked = time.Time{}
+	cert, err = l.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Until(leaf.NotAfter) < renewBefore {
+		t.Errorf("expect the certificate is renewed, got one expiring at %v", leaf.NotAfter)
+	}
+}
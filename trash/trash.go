@@ -0,0 +1,333 @@
+// trash.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+// Package trash moves deleted images into trash folders of their libraries so that they can be restored until they are
+// purged. Each trash folder follows the layout of the FreeDesktop.org trash specification, i.e. trashed files are
+// stored in its files directory and their original paths and deletion dates are stored in its info directory.
+package trash
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jkawamoto/sd-image-viewer/archive"
+)
+
+const (
+	// DirName is the name of trash folders, which are created in the root directories of libraries.
+	DirName = ".trash"
+
+	filesDir   = "files"
+	infoDir    = "info"
+	infoExt    = ".trashinfo"
+	infoHeader = "[Trash Info]"
+	// dateLayout is the layout of deletion dates in local time defined by the specification.
+	dateLayout = "2006-01-02T15:04:05"
+)
+
+var (
+	// ErrNotFound is returned when the given image or item doesn't exist.
+	ErrNotFound = errors.New("not found")
+	// ErrNotSupported is returned when the given image can't be moved to the trash, i.e. it is stored in an S3 bucket
+	// or an archive.
+	ErrNotSupported = errors.New("deleting the image is not supported")
+	// ErrExists is returned when restoring an item whose original path is used by another file.
+	ErrExists = errors.New("file already exists")
+)
+
+// Item is an image in the trash.
+type Item struct {
+	// ID identifies the item, i.e. the name of the file in the trash folder prefixed with the library name.
+	ID string
+	// Image is the ID of the image, which the item is restored to.
+	Image   string
+	Deleted time.Time
+}
+
+// Trash manages trash folders of libraries in local directories.
+type Trash struct {
+	// roots maps library names to their root directories. The name is empty if there is only one library.
+	roots map[string]string
+	mu    sync.Mutex
+}
+
+// New returns a Trash of the libraries in the given directories, which are mapped from their names. Libraries which
+// aren't in local directories, e.g. S3 buckets, are mapped to empty strings, and images in them can't be deleted.
+func New(roots map[string]string) *Trash {
+	return &Trash{roots: roots}
+}
+
+// Delete moves the image of the given ID into the trash folder of its library.
+func (t *Trash) Delete(id string) (*Item, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	lib, name, root, err := t.resolve(id)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(name, archive.Separator) || name == DirName || strings.HasPrefix(name, DirName+"/") {
+		return nil, fmt.Errorf("%w: %v", ErrNotSupported, id)
+	}
+	src := filepath.Join(root, filepath.FromSlash(name))
+	if info, err := os.Lstat(src); errors.Is(err, fs.ErrNotExist) || err == nil && !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, id)
+	} else if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(root, DirName)
+	for _, d := range []string{filesDir, infoDir} {
+		if err = os.MkdirAll(filepath.Join(dir, d), 0700); err != nil {
+			return nil, err
+		}
+	}
+
+	deleted := time.Now()
+	key, err := reserve(dir, path.Base(name), name, deleted)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Rename(src, filepath.Join(dir, filesDir, key)); err != nil {
+		return nil, errors.Join(err, os.Remove(filepath.Join(dir, infoDir, key+infoExt)))
+	}
+	return &Item{ID: itemID(lib, key), Image: id, Deleted: deleted.Truncate(time.Second)}, nil
+}
+
+// List returns items in the trash folders sorted by their deletion dates from newest to oldest.
+func (t *Trash) List() ([]*Item, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.list()
+}
+
+// Restore moves the item of the given ID back to its original path, and returns it.
+func (t *Trash) Restore(id string) (*Item, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	lib, key, ok := t.cutItemID(id)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, id)
+	}
+	dir := filepath.Join(t.roots[lib], DirName)
+	item, name, err := readInfo(dir, lib, key)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, id)
+	} else if err != nil {
+		return nil, err
+	}
+
+	dst := filepath.Join(t.roots[lib], filepath.FromSlash(name))
+	if _, err = os.Lstat(dst); err == nil {
+		return nil, fmt.Errorf("%w: %v", ErrExists, item.Image)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err = os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return nil, err
+	}
+	if err = os.Rename(filepath.Join(dir, filesDir, key), dst); errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, id)
+	} else if err != nil {
+		return nil, err
+	}
+	return item, os.Remove(filepath.Join(dir, infoDir, key+infoExt))
+}
+
+// Purge permanently deletes items deleted before the given time, and returns them.
+func (t *Trash) Purge(before time.Time) ([]*Item, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	items, err := t.list()
+	if err != nil {
+		return nil, err
+	}
+	var res []*Item
+	for _, item := range items {
+		if !item.Deleted.Before(before) {
+			continue
+		}
+		lib, key, _ := t.cutItemID(item.ID)
+		dir := filepath.Join(t.roots[lib], DirName)
+		err = os.Remove(filepath.Join(dir, filesDir, key))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return res, err
+		}
+		if err = os.Remove(filepath.Join(dir, infoDir, key+infoExt)); err != nil {
+			return res, err
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
+
+// list returns items in the trash folders. Info files which can't be parsed or whose files are missing are ignored.
+func (t *Trash) list() ([]*Item, error) {
+	res := []*Item{}
+	for lib, root := range t.roots {
+		if root == "" {
+			continue
+		}
+		dir := filepath.Join(root, DirName)
+		entries, err := os.ReadDir(filepath.Join(dir, infoDir))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			key, ok := strings.CutSuffix(e.Name(), infoExt)
+			if !ok || e.IsDir() {
+				continue
+			}
+			if _, err = os.Lstat(filepath.Join(dir, filesDir, key)); err != nil {
+				continue
+			}
+			if item, _, err := readInfo(dir, lib, key); err == nil {
+				res = append(res, item)
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].Deleted.Equal(res[j].Deleted) {
+			return res[i].Deleted.After(res[j].Deleted)
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res, nil
+}
+
+// resolve returns the name of the library containing the image of the given ID, the name of the file in the library
+// and the root directory of the library.
+func (t *Trash) resolve(id string) (lib, name, root string, err error) {
+	name = id
+	if _, single := t.roots[""]; !single {
+		var ok bool
+		if lib, name, ok = strings.Cut(id, "/"); !ok {
+			return "", "", "", fmt.Errorf("%w: %v", ErrNotFound, id)
+		}
+	}
+	root, ok := t.roots[lib]
+	if !ok {
+		return "", "", "", fmt.Errorf("%w: %v", ErrNotFound, id)
+	} else if root == "" {
+		return "", "", "", fmt.Errorf("%w: %v", ErrNotSupported, id)
+	}
+	if !fs.ValidPath(name) || name == "." {
+		return "", "", "", fmt.Errorf("%w: %v", ErrNotFound, id)
+	}
+	return lib, name, root, nil
+}
+
+// cutItemID splits the given item ID into the library name and the name of the file in the trash folder.
+func (t *Trash) cutItemID(id string) (lib, key string, ok bool) {
+	key = id
+	if _, single := t.roots[""]; !single {
+		if lib, key, ok = strings.Cut(id, "/"); !ok {
+			return "", "", false
+		}
+	}
+	if t.roots[lib] == "" || key == "" || strings.ContainsAny(key, `/\`) || key == "." || key == ".." {
+		return "", "", false
+	}
+	return lib, key, true
+}
+
+// itemID returns the ID of the item of the given key in the library.
+func itemID(lib, key string) string {
+	if lib == "" {
+		return key
+	}
+	return lib + "/" + key
+}
+
+// reserve creates an info file of the image of the given name in the trash folder, and returns the key of the item.
+// The key is the given base name, which is numbered if another item uses it.
+func reserve(dir, base, name string, deleted time.Time) (string, error) {
+	info := fmt.Sprintf("%v\nPath=%v\nDeletionDate=%v\n",
+		infoHeader, (&url.URL{Path: name}).EscapedPath(), deleted.Format(dateLayout))
+
+	ext := path.Ext(base)
+	for i := 1; ; i++ {
+		key := base
+		if i > 1 {
+			key = strings.TrimSuffix(base, ext) + "." + strconv.Itoa(i) + ext
+		}
+		if _, err := os.Lstat(filepath.Join(dir, filesDir, key)); err == nil {
+			continue
+		}
+		// the info file is created exclusively so that concurrent processes don't use the same key.
+		f, err := os.OpenFile(filepath.Join(dir, infoDir, key+infoExt), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		} else if err != nil {
+			return "", err
+		}
+		_, err = f.WriteString(info)
+		if err = errors.Join(err, f.Close()); err != nil {
+			return "", errors.Join(err, os.Remove(f.Name()))
+		}
+		return key, nil
+	}
+}
+
+// readInfo reads the info file of the given key in the trash folder, and returns the item and the original name of the
+// file in the library.
+func readInfo(dir, lib, key string) (_ *Item, name string, err error) {
+	f, err := os.Open(filepath.Join(dir, infoDir, key+infoExt))
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	item := &Item{ID: itemID(lib, key)}
+	s := bufio.NewScanner(f)
+	if !s.Scan() || strings.TrimSpace(s.Text()) != infoHeader {
+		return nil, "", fmt.Errorf("invalid trash info %v", key)
+	}
+	for s.Scan() {
+		k, v, ok := strings.Cut(s.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch k {
+		case "Path":
+			if name, err = url.PathUnescape(v); err != nil {
+				return nil, "", fmt.Errorf("invalid path in trash info %v: %w", key, err)
+			}
+		case "DeletionDate":
+			if item.Deleted, err = time.ParseInLocation(dateLayout, v, time.Local); err != nil {
+				return nil, "", fmt.Errorf("invalid deletion date in trash info %v: %w", key, err)
+			}
+		}
+	}
+	if err = s.Err(); err != nil {
+		return nil, "", err
+	}
+	// absolute paths are used by trash folders in home directories, which aren't managed by this package.
+	if !fs.ValidPath(name) || name == "." || item.Deleted.IsZero() {
+		return nil, "", fmt.Errorf("invalid trash info %v", key)
+	}
+	item.Image = itemID(lib, name)
+	return item, name, nil
+}
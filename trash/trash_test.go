@@ -0,0 +1,168 @@
+// trash_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package trash
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFiles creates files of the given names in dir, whose contents are their names.
+func writeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestTrash(t *testing.T) {
+	outputs, inputs := t.TempDir(), t.TempDir()
+	writeFiles(t, outputs, "a.png", "sub/a.png")
+	writeFiles(t, inputs, "b.png")
+	tr := New(map[string]string{"outputs": outputs, "inputs": inputs, "remote": ""})
+
+	first, err := tr.Delete("outputs/a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID != "outputs/a.png" || first.Image != "outputs/a.png" {
+		t.Errorf("expect an item of outputs/a.png, got %+v", first)
+	}
+	if _, err = os.Stat(filepath.Join(outputs, "a.png")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expect the file is moved, got %v", err)
+	}
+	info, err := os.ReadFile(filepath.Join(outputs, DirName, infoDir, "a.png"+infoExt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(info), infoHeader+"\nPath=a.png\nDeletionDate=") {
+		t.Errorf("expect a trash info file, got %q", info)
+	}
+
+	// files of the same name are numbered.
+	second, err := tr.Delete("outputs/sub/a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.ID != "outputs/a.2.png" {
+		t.Errorf("expect %v, got %v", "outputs/a.2.png", second.ID)
+	}
+
+	for id, expect := range map[string]error{
+		"outputs/a.png":           ErrNotFound,
+		"outputs/sub":             ErrNotFound,
+		"outputs/a.zip!/c.png":    ErrNotSupported,
+		"outputs/.trash/a.png":    ErrNotSupported,
+		"remote/c.png":            ErrNotSupported,
+		"unknown/c.png":           ErrNotFound,
+		"outputs/../inputs/b.png": ErrNotFound,
+	} {
+		if _, err = tr.Delete(id); !errors.Is(err, expect) {
+			t.Errorf("%v: expect %v, got %v", id, expect, err)
+		}
+	}
+
+	items, err := tr.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expect 2 items, got %v", items)
+	}
+	for _, item := range items {
+		if item.Image != "outputs/a.png" && item.Image != "outputs/sub/a.png" {
+			t.Errorf("unexpected item: %+v", item)
+		}
+	}
+
+	// the restored file is moved back to its original path, which must not be used.
+	writeFiles(t, outputs, "a.png")
+	if _, err = tr.Restore(first.ID); !errors.Is(err, ErrExists) {
+		t.Errorf("expect %v, got %v", ErrExists, err)
+	}
+	res, err := tr.Restore(second.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Image != "outputs/sub/a.png" {
+		t.Errorf("expect %v, got %v", "outputs/sub/a.png", res.Image)
+	}
+	if b, err := os.ReadFile(filepath.Join(outputs, "sub", "a.png")); err != nil || string(b) != "sub/a.png" {
+		t.Errorf("expect the file is restored, got %q (%v)", b, err)
+	}
+	if _, err = tr.Restore(second.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expect %v, got %v", ErrNotFound, err)
+	}
+	if _, err = tr.Restore("outputs/../a.png"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expect %v, got %v", ErrNotFound, err)
+	}
+
+	purged, err := tr.Purge(time.Now().Add(-time.Hour))
+	if err != nil || len(purged) != 0 {
+		t.Errorf("expect no items are purged, got %v (%v)", purged, err)
+	}
+	purged, err = tr.Purge(time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(purged) != 1 || purged[0].ID != first.ID {
+		t.Errorf("expect %v is purged, got %v", first.ID, purged)
+	}
+	if items, err = tr.List(); err != nil || len(items) != 0 {
+		t.Errorf("expect the trash is empty, got %v (%v)", items, err)
+	}
+	if _, err = os.Stat(filepath.Join(outputs, DirName, filesDir, "a.png")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expect the file is deleted, got %v", err)
+	}
+}
+
+func TestTrashSingleLibrary(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, "sub/a.png")
+	tr := New(map[string]string{"": root})
+
+	item, err := tr.Delete("sub/a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.ID != "a.png" || item.Image != "sub/a.png" {
+		t.Errorf("expect an item without library names, got %+v", item)
+	}
+	if _, err = tr.Restore(item.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(filepath.Join(root, "sub", "a.png")); err != nil {
+		t.Errorf("expect the file is restored, got %v", err)
+	}
+}
+
+func TestTrashSingleRemoteLibrary(t *testing.T) {
+	tr := New(map[string]string{"": ""})
+	for _, id := range []string{"a.png", "sub/a.png"} {
+		if _, err := tr.Delete(id); !errors.Is(err, ErrNotSupported) {
+			t.Errorf("%v: expect %v, got %v", id, ErrNotSupported, err)
+		}
+	}
+	if _, err := tr.Restore("a.png"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expect %v, got %v", ErrNotFound, err)
+	}
+	if items, err := tr.List(); err != nil || len(items) != 0 {
+		t.Errorf("expect no items, got %v (%v)", items, err)
+	}
+}
@@ -0,0 +1,228 @@
+// user.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/jkawamoto/sd-image-viewer/auth"
+)
+
+// usersFileSuffix is appended to the path of the index to get the file of the user store.
+const usersFileSuffix = ".users"
+
+// userCommand manages users of the server. Authentication is required once a user is added.
+type userCommand struct {
+	Add    userAddCommand    `command:"add" description:"Add a user"`
+	Passwd userPasswdCommand `command:"passwd" description:"Change the password of a user"`
+	Remove userRemoveCommand `command:"remove" description:"Remove a user, and revoke the sessions, API tokens and share links"`
+	List   userListCommand   `command:"list" description:"List users"`
+	Token  userTokenCommand  `command:"token" description:"Manage API tokens of a user"`
+}
+
+func newUserCommand(opts *options) *userCommand {
+	return &userCommand{
+		Add:    userAddCommand{global: opts},
+		Passwd: userPasswdCommand{global: opts},
+		Remove: userRemoveCommand{global: opts},
+		List:   userListCommand{global: opts},
+		Token: userTokenCommand{
+			Create: userTokenCreateCommand{global: opts},
+			List:   userTokenListCommand{global: opts},
+			Revoke: userTokenRevokeCommand{global: opts},
+		},
+	}
+}
+
+// userArgs is the positional argument of commands managing a user.
+type userArgs struct {
+	User string `positional-arg-name:"user" required:"yes"`
+}
+
+type userAddCommand struct {
+	global *options
+	Args   userArgs `positional-args:"yes"`
+}
+
+func (c *userAddCommand) Execute([]string) error {
+	users, err := openUsers(c.global)
+	if err != nil {
+		return err
+	}
+	password, err := readPassword(os.Stdin, os.Stderr)
+	if err != nil {
+		return err
+	}
+	return users.AddUser(c.Args.User, password)
+}
+
+type userPasswdCommand struct {
+	global *options
+	Args   userArgs `positional-args:"yes"`
+}
+
+func (c *userPasswdCommand) Execute([]string) error {
+	users, err := openUsers(c.global)
+	if err != nil {
+		return err
+	}
+	password, err := readPassword(os.Stdin, os.Stderr)
+	if err != nil {
+		return err
+	}
+	return users.SetPassword(c.Args.User, password)
+}
+
+type userRemoveCommand struct {
+	global *options
+	Args   userArgs `positional-args:"yes"`
+}
+
+func (c *userRemoveCommand) Execute([]string) error {
+	users, err := openUsers(c.global)
+	if err != nil {
+		return err
+	}
+	return users.RemoveUser(c.Args.User)
+}
+
+type userListCommand struct {
+	global *options
+}
+
+func (c *userListCommand) Execute([]string) error {
+	users, err := openUsers(c.global)
+	if err != nil {
+		return err
+	}
+	for _, name := range users.Users() {
+		fmt.Println(name)
+	}
+	return nil
+}
+
+type userTokenCommand struct {
+	Create userTokenCreateCommand `command:"create" description:"Create an API token, which is printed only once"`
+	List   userTokenListCommand   `command:"list" description:"List API tokens"`
+	Revoke userTokenRevokeCommand `command:"revoke" description:"Revoke an API token"`
+}
+
+type userTokenCreateCommand struct {
+	global      *options
+	Description string   `long:"description" description:"description of the token, e.g. the script using it"`
+	Args        userArgs `positional-args:"yes"`
+}
+
+func (c *userTokenCreateCommand) Execute([]string) error {
+	users, err := openUsers(c.global)
+	if err != nil {
+		return err
+	}
+	token, _, err := users.CreateToken(c.Args.User, c.Description)
+	if err != nil {
+		return err
+	}
+	fmt.Println(token)
+	return nil
+}
+
+type userTokenListCommand struct {
+	global *options
+	Args   userArgs `positional-args:"yes"`
+}
+
+func (c *userTokenListCommand) Execute([]string) error {
+	users, err := openUsers(c.global)
+	if err != nil {
+		return err
+	}
+	tokens, err := users.Tokens(c.Args.User)
+	if err != nil {
+		return err
+	}
+	return writeTokens(os.Stdout, tokens)
+}
+
+// writeTokens writes IDs, creation times and descriptions of the given tokens in a table.
+func writeTokens(w io.Writer, tokens []*auth.Token) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tCREATED\tDESCRIPTION")
+	for _, t := range tokens {
+		fmt.Fprintf(tw, "%v\t%v\t%v\n", t.ID, t.Created.Local().Format(time.DateTime), t.Description)
+	}
+	return tw.Flush()
+}
+
+type userTokenRevokeCommand struct {
+	global *options
+	Args   struct {
+		User string `positional-arg-name:"user" required:"yes"`
+		ID   string `positional-arg-name:"id" required:"yes"`
+	} `positional-args:"yes"`
+}
+
+func (c *userTokenRevokeCommand) Execute([]string) error {
+	users, err := openUsers(c.global)
+	if err != nil {
+		return err
+	}
+	return users.RevokeToken(c.Args.User, c.Args.ID)
+}
+
+// openUsers opens the user store next to the index in the configuration.
+func openUsers(opts *options) (*auth.Store, error) {
+	cfg, err := loadConfig(opts.Config, defaultConfig(), opts.apply)
+	if err != nil {
+		return nil, err
+	}
+	users, err := auth.OpenStore(cfg.Index + usersFileSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the user store: %w", err)
+	}
+	return users, nil
+}
+
+// readPassword reads a password. It asks twice without echoing if r is a terminal, and reads a line otherwise so that
+// scripts can give passwords from pipes.
+func readPassword(r *os.File, prompt io.Writer) (string, error) {
+	fd := int(r.Fd())
+	if !term.IsTerminal(fd) {
+		line, err := bufio.NewReader(r).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	fmt.Fprint(prompt, "Password: ")
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(prompt)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprint(prompt, "Retype password: ")
+	again, err := term.ReadPassword(fd)
+	fmt.Fprintln(prompt)
+	if err != nil {
+		return "", err
+	}
+	if string(password) != string(again) {
+		return "", errors.New("passwords don't match")
+	}
+	return string(password), nil
+}
@@ -0,0 +1,267 @@
+// collection.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package userdata
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// collectionIDSize is the size of random bytes of collection IDs.
+	collectionIDSize = 8
+	// MaxCollectionNameLength and MaxCollectionDescriptionLength are the maximum numbers of characters of names and
+	// descriptions of collections.
+	MaxCollectionNameLength        = 256
+	MaxCollectionDescriptionLength = 4096
+)
+
+var collectionsBucket = []byte("collections")
+
+var (
+	// ErrCollectionNotFound is returned when the given collection doesn't exist.
+	ErrCollectionNotFound = errors.New("collection not found")
+	// ErrInvalidCollection is returned when a collection has an invalid name, description or cover.
+	ErrInvalidCollection = errors.New("invalid collection")
+)
+
+// Collection is a curated and ordered set of images.
+type Collection struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Cover is the ID of the cover image, which must be in the collection. The first image is used if it is empty.
+	Cover string `json:"cover,omitempty"`
+	// Images are IDs of images in the collection in order.
+	Images  []string  `json:"images"`
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+}
+
+// CoverImage returns the ID of the cover image. It returns an empty string if the collection has no images.
+func (c *Collection) CoverImage() string {
+	if c.Cover != "" {
+		return c.Cover
+	}
+	if len(c.Images) != 0 {
+		return c.Images[0]
+	}
+	return ""
+}
+
+// Add inserts the given images at the given position. Images already in the collection are moved to the position.
+// A negative position or a position past the end appends the images.
+func (c *Collection) Add(position int, ids ...string) {
+	added := make(map[string]bool, len(ids))
+	var insert []string
+	for _, id := range ids {
+		if !added[id] {
+			added[id] = true
+			insert = append(insert, id)
+		}
+	}
+
+	res := make([]string, 0, len(c.Images)+len(insert))
+	for i, id := range c.Images {
+		if i == position {
+			res = append(res, insert...)
+			insert = nil
+		}
+		if !added[id] {
+			res = append(res, id)
+		}
+	}
+	c.Images = append(res, insert...)
+}
+
+// Remove removes the given images. The cover is reset if it is removed.
+func (c *Collection) Remove(ids ...string) {
+	removed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		removed[id] = true
+	}
+
+	res := make([]string, 0, len(c.Images))
+	for _, id := range c.Images {
+		if !removed[id] {
+			res = append(res, id)
+		}
+	}
+	c.Images = res
+	if removed[c.Cover] {
+		c.Cover = ""
+	}
+}
+
+// Set replaces the images with the given ones in the given order, e.g. to reorder them. The cover is reset if it is
+// removed.
+func (c *Collection) Set(ids ...string) {
+	c.Images = make([]string, 0, len(ids))
+	c.Add(-1, ids...)
+	if c.Cover != "" && !c.contains(c.Cover) {
+		c.Cover = ""
+	}
+}
+
+// contains returns true if the collection has the image of the given ID.
+func (c *Collection) contains(id string) bool {
+	for _, v := range c.Images {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize trims spaces of the name and the description, and checks the collection is valid.
+func (c *Collection) normalize() error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return fmt.Errorf("%w: name is empty", ErrInvalidCollection)
+	}
+	if utf8.RuneCountInString(c.Name) > MaxCollectionNameLength {
+		return fmt.Errorf("%w: name is longer than %v characters", ErrInvalidCollection, MaxCollectionNameLength)
+	}
+	c.Description = strings.TrimSpace(c.Description)
+	if utf8.RuneCountInString(c.Description) > MaxCollectionDescriptionLength {
+		return fmt.Errorf(
+			"%w: description is longer than %v characters", ErrInvalidCollection, MaxCollectionDescriptionLength)
+	}
+	if c.Cover != "" && !c.contains(c.Cover) {
+		return fmt.Errorf("%w: cover %v is not in the collection", ErrInvalidCollection, c.Cover)
+	}
+	if c.Images == nil {
+		c.Images = []string{}
+	}
+	return nil
+}
+
+// Collections returns all collections sorted by their names.
+func (s *Store) Collections() ([]*Collection, error) {
+	var res []*Collection
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(collectionsBucket).ForEach(func(k, v []byte) error {
+			var c Collection
+			if err := json.Unmarshal(v, &c); err != nil {
+				return fmt.Errorf("failed to parse collection %s: %w", k, err)
+			}
+			res = append(res, &c)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Name != res[j].Name {
+			return res[i].Name < res[j].Name
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res, nil
+}
+
+// Collection returns the collection of the given ID.
+func (s *Store) Collection(id string) (res *Collection, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		res, err = getCollection(tx, id)
+		return err
+	})
+	return res, err
+}
+
+// CreateCollection creates a collection with a new ID, and returns it. The ID and timestamps of the given collection
+// are ignored.
+func (s *Store) CreateCollection(c *Collection) (*Collection, error) {
+	id := make([]byte, collectionIDSize)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	res := *c
+	res.ID = hex.EncodeToString(id)
+	res.Created = time.Now()
+	res.Updated = res.Created
+	if err := res.normalize(); err != nil {
+		return nil, err
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return putCollection(tx, &res)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// UpdateCollection updates the collection of the given ID with the given function, and returns the updated
+// collection. Nothing is changed if fn returns an error.
+func (s *Store) UpdateCollection(id string, fn func(c *Collection) error) (res *Collection, err error) {
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		res, err = getCollection(tx, id)
+		if err != nil {
+			return err
+		}
+		if err = fn(res); err != nil {
+			return err
+		}
+		res.ID = id
+		res.Updated = time.Now()
+		if err = res.normalize(); err != nil {
+			return err
+		}
+		return putCollection(tx, res)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteCollection deletes the collection of the given ID. Images in the collection are not deleted.
+func (s *Store) DeleteCollection(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(collectionsBucket)
+		if b.Get([]byte(id)) == nil {
+			return fmt.Errorf("%w: %v", ErrCollectionNotFound, id)
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
+// getCollection returns the collection of the given ID.
+func getCollection(tx *bolt.Tx, id string) (*Collection, error) {
+	v := tx.Bucket(collectionsBucket).Get([]byte(id))
+	if v == nil {
+		return nil, fmt.Errorf("%w: %v", ErrCollectionNotFound, id)
+	}
+	var c Collection
+	if err := json.Unmarshal(v, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse collection %v: %w", id, err)
+	}
+	return &c, nil
+}
+
+// putCollection stores the given collection.
+func putCollection(tx *bolt.Tx, c *Collection) error {
+	v, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(collectionsBucket).Put([]byte(c.ID), v)
+}
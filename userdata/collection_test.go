@@ -0,0 +1,156 @@
+// collection_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package userdata
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCollectionAdd(t *testing.T) {
+	cases := []struct {
+		name     string
+		images   []string
+		position int
+		ids      []string
+		expect   []string
+	}{
+		{name: "append", images: []string{"a", "b"}, position: -1, ids: []string{"c"}, expect: []string{"a", "b", "c"}},
+		{
+			name:     "insert",
+			images:   []string{"a", "b"},
+			position: 1,
+			ids:      []string{"c", "d"},
+			expect:   []string{"a", "c", "d", "b"},
+		},
+		{name: "past the end", images: []string{"a"}, position: 5, ids: []string{"b"}, expect: []string{"a", "b"}},
+		{name: "empty", position: 0, ids: []string{"a"}, expect: []string{"a"}},
+		{name: "duplicated", images: []string{"a"}, position: -1, ids: []string{"b", "b"}, expect: []string{"a", "b"}},
+		{
+			name:     "move",
+			images:   []string{"a", "b", "c"},
+			position: 0,
+			ids:      []string{"c"},
+			expect:   []string{"c", "a", "b"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			coll := &Collection{Images: c.images}
+			coll.Add(c.position, c.ids...)
+			if !reflect.DeepEqual(coll.Images, c.expect) {
+				t.Errorf("expect %v, got %v", c.expect, coll.Images)
+			}
+		})
+	}
+}
+
+func TestCollectionRemove(t *testing.T) {
+	c := &Collection{Cover: "b", Images: []string{"a", "b", "c"}}
+	c.Remove("b", "d")
+	if expect := []string{"a", "c"}; !reflect.DeepEqual(c.Images, expect) {
+		t.Errorf("expect %v, got %v", expect, c.Images)
+	}
+	if c.Cover != "" {
+		t.Errorf("expect the cover is reset, got %v", c.Cover)
+	}
+	if res := c.CoverImage(); res != "a" {
+		t.Errorf("expect %v, got %v", "a", res)
+	}
+
+	c.Set("c")
+	if expect := []string{"c"}; !reflect.DeepEqual(c.Images, expect) {
+		t.Errorf("expect %v, got %v", expect, c.Images)
+	}
+}
+
+func TestStoreCollections(t *testing.T) {
+	s := openStore(t, filepath.Join(t.TempDir(), "userdata"))
+
+	created, err := s.CreateCollection(&Collection{
+		Name:        " LoRA training set ",
+		Description: "v3",
+		Images:      []string{"a.png", "b.png"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ID == "" || created.Name != "LoRA training set" || created.Created.IsZero() {
+		t.Errorf("expect a new collection, got %+v", created)
+	}
+	if _, err = s.CreateCollection(&Collection{Name: "client deliverables"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []*Collection{
+		{Name: " "},
+		{Name: strings.Repeat("x", MaxCollectionNameLength+1)},
+		{Name: "cover", Cover: "c.png"},
+	} {
+		if _, err = s.CreateCollection(c); !errors.Is(err, ErrInvalidCollection) {
+			t.Errorf("expect %v, got %v", ErrInvalidCollection, err)
+		}
+	}
+
+	list, err := s.Collections()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Name != "LoRA training set" || list[1].Name != "client deliverables" {
+		t.Errorf("expect collections sorted by names, got %v", list)
+	}
+
+	updated, err := s.UpdateCollection(created.ID, func(c *Collection) error {
+		c.Name = "LoRA training set v3"
+		c.Cover = "b.png"
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.Collection(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Name != "LoRA training set v3" || res.CoverImage() != "b.png" || !res.Updated.Equal(updated.Updated) {
+		t.Errorf("expect %+v, got %+v", updated, res)
+	}
+	if !reflect.DeepEqual(res.Images, created.Images) {
+		t.Errorf("expect %v, got %v", created.Images, res.Images)
+	}
+
+	// invalid updates don't change anything.
+	if _, err = s.UpdateCollection(created.ID, func(c *Collection) error {
+		c.Cover = "c.png"
+		return nil
+	}); !errors.Is(err, ErrInvalidCollection) {
+		t.Errorf("expect %v, got %v", ErrInvalidCollection, err)
+	}
+	if res, err = s.Collection(created.ID); err != nil || res.Cover != "b.png" {
+		t.Errorf("expect the collection isn't changed, got %+v (%v)", res, err)
+	}
+
+	if err = s.DeleteCollection(created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Collection(created.ID); !errors.Is(err, ErrCollectionNotFound) {
+		t.Errorf("expect %v, got %v", ErrCollectionNotFound, err)
+	}
+	if err = s.DeleteCollection(created.ID); !errors.Is(err, ErrCollectionNotFound) {
+		t.Errorf("expect %v, got %v", ErrCollectionNotFound, err)
+	}
+	_, err = s.UpdateCollection(created.ID, func(*Collection) error { return nil })
+	if !errors.Is(err, ErrCollectionNotFound) {
+		t.Errorf("expect %v, got %v", ErrCollectionNotFound, err)
+	}
+}
@@ -0,0 +1,225 @@
+// userdata.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+// Package userdata stores data users added to images, i.e. annotations and collections. The data is stored in a file
+// next to the index but separately from it so that it survives rebuilding the index.
+package userdata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/jkawamoto/sd-image-viewer/image"
+)
+
+// lockTimeout is the maximum duration to wait for another process to release the file.
+const lockTimeout = 5 * time.Second
+
+var annotationsBucket = []byte("annotations")
+
+// ErrLocked is returned when the file is used by another process, e.g. a running server.
+var ErrLocked = errors.New("user data is used by another process")
+
+// File identifies the content of an image file so that data of the image can follow it when it is moved or renamed.
+type File struct {
+	Size int64 `json:"size"`
+	// Fingerprint is the hex encoded SHA-256 hash of the content.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// FileOf returns the File of the file of the given name in fsys.
+func FileOf(fsys fs.FS, name string) (_ *File, err error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return nil, err
+	}
+	return &File{Size: n, Fingerprint: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// annotationRecord is an annotation stored with the file it was added to.
+type annotationRecord struct {
+	Annotation *image.Annotation `json:"annotation"`
+	File       *File             `json:"file,omitempty"`
+}
+
+// Store is a store of user data saved in a bbolt database.
+type Store struct {
+	db *bolt.DB
+	// mu serializes functions given to Exclusive.
+	mu sync.Mutex
+}
+
+// Open opens the store saved in the file of the given name. The file is created if it doesn't exist.
+func Open(name string) (*Store, error) {
+	db, err := bolt.Open(name, 0600, &bolt.Options{Timeout: lockTimeout})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("%w: %v", ErrLocked, name)
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{annotationsBucket, collectionsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the store.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Exclusive calls fn while no other functions given to Exclusive are running. Annotations are read or modified and
+// indexed with images in fn so that the indexer and requests modifying annotations don't overwrite documents indexed
+// by each other with stale images or stale annotations.
+func (s *Store) Exclusive(fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn()
+}
+
+// Annotation returns the annotation of the image of the given ID. It returns nil if the image doesn't have any.
+func (s *Store) Annotation(id string) (res *image.Annotation, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		r, err := getAnnotation(tx, id)
+		if r != nil {
+			res = r.Annotation
+		}
+		return err
+	})
+	return res, err
+}
+
+// SetAnnotation replaces the annotation of the image of the given ID, which is stored in the given file. A zero
+// annotation removes it.
+func (s *Store) SetAnnotation(id string, a *image.Annotation, file *File) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(annotationsBucket)
+		if a.IsZero() {
+			return b.Delete([]byte(id))
+		}
+		v, err := json.Marshal(&annotationRecord{Annotation: a, File: file})
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(id), v)
+	})
+}
+
+// Annotated returns IDs of annotated images and the files they are stored in. The file is nil if it is unknown.
+func (s *Store) Annotated() (map[string]*File, error) {
+	res := make(map[string]*File)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(annotationsBucket).ForEach(func(k, v []byte) error {
+			var r annotationRecord
+			if err := json.Unmarshal(v, &r); err != nil {
+				return fmt.Errorf("failed to parse the annotation of %s: %w", k, err)
+			}
+			res[string(k)] = r.File
+			return nil
+		})
+	})
+	return res, err
+}
+
+// MoveAnnotation moves the annotation of the image of ID from to the image of ID to, e.g. when the file is renamed.
+// It returns false without changing anything if from doesn't have an annotation or to already has one.
+func (s *Store) MoveAnnotation(from, to string) (moved bool, err error) {
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(annotationsBucket)
+		v := b.Get([]byte(from))
+		if v == nil || b.Get([]byte(to)) != nil {
+			return nil
+		}
+		if err := b.Put([]byte(to), v); err != nil {
+			return err
+		}
+		moved = true
+		return b.Delete([]byte(from))
+	})
+	return moved, err
+}
+
+// RemoveImages removes annotations of the images of the given IDs and removes the images from collections, e.g. when
+// their files are deleted permanently.
+func (s *Store) RemoveImages(ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		annotations := tx.Bucket(annotationsBucket)
+		for _, id := range ids {
+			if err := annotations.Delete([]byte(id)); err != nil {
+				return err
+			}
+		}
+
+		// collections are updated after reading them since buckets can't be modified while iterating over them.
+		var updated []*Collection
+		err := tx.Bucket(collectionsBucket).ForEach(func(k, v []byte) error {
+			var c Collection
+			if err := json.Unmarshal(v, &c); err != nil {
+				return fmt.Errorf("failed to parse collection %s: %w", k, err)
+			}
+			n := len(c.Images)
+			if c.Remove(ids...); len(c.Images) != n {
+				c.Updated = time.Now()
+				updated = append(updated, &c)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, c := range updated {
+			if err = putCollection(tx, c); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// getAnnotation returns the annotation record of the given ID or nil if it doesn't exist.
+func getAnnotation(tx *bolt.Tx, id string) (*annotationRecord, error) {
+	v := tx.Bucket(annotationsBucket).Get([]byte(id))
+	if v == nil {
+		return nil, nil
+	}
+	var r annotationRecord
+	if err := json.Unmarshal(v, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse the annotation of %v: %w", id, err)
+	}
+	return &r, nil
+}
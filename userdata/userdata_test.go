@@ -0,0 +1,195 @@
+// userdata_test.go
+//
+// Copyright (c) 2023 Junpei Kawamoto
+//
+// This software is released under the MIT License.
+//
+// http://opensource.org/licenses/mit-license.php
+
+package userdata
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jkawamoto/sd-image-viewer/image"
+)
+
+func openStore(t *testing.T, name string) *Store {
+	t.Helper()
+	s, err := Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := s.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return s
+}
+
+func TestFileOf(t *testing.T) {
+	fsys := fstest.MapFS{"a.png": {Data: []byte("abc")}}
+	f, err := FileOf(fsys, "a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := &File{
+		Size:        3,
+		Fingerprint: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+	}
+	if !reflect.DeepEqual(f, expect) {
+		t.Errorf("expect %v, got %v", expect, f)
+	}
+
+	if _, err = FileOf(fsys, "b.png"); err == nil {
+		t.Error("expect an error")
+	}
+}
+
+func TestStoreAnnotation(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "userdata")
+	s := openStore(t, name)
+
+	a := &image.Annotation{Favorite: true, Rating: 4, Tags: []string{"keeper"}, Note: "nice"}
+	file := &File{Size: 10, Fingerprint: "abc"}
+	if err := s.SetAnnotation("a.png", a, file); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetAnnotation("b.png", &image.Annotation{Rating: 1}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := s.Annotation("a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res, a) {
+		t.Errorf("expect %v, got %v", a, res)
+	}
+	if res, err = s.Annotation("c.png"); err != nil || res != nil {
+		t.Errorf("expect no annotations, got %v (%v)", res, err)
+	}
+
+	annotated, err := s.Annotated()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := map[string]*File{"a.png": file, "b.png": nil}
+	if !reflect.DeepEqual(annotated, expect) {
+		t.Errorf("expect %v, got %v", expect, annotated)
+	}
+
+	// zero annotations are removed.
+	if err = s.SetAnnotation("b.png", &image.Annotation{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if res, err = s.Annotation("b.png"); err != nil || res != nil {
+		t.Errorf("expect no annotations, got %v (%v)", res, err)
+	}
+}
+
+func TestStoreMoveAnnotation(t *testing.T) {
+	s := openStore(t, filepath.Join(t.TempDir(), "userdata"))
+
+	a := &image.Annotation{Favorite: true}
+	b := &image.Annotation{Rating: 2}
+	if err := s.SetAnnotation("a.png", a, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetAnnotation("b.png", b, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		from, to string
+		moved    bool
+		expect   map[string]*image.Annotation
+	}{
+		{
+			name:   "annotated destination",
+			from:   "a.png",
+			to:     "b.png",
+			expect: map[string]*image.Annotation{"a.png": a, "b.png": b},
+		},
+		{
+			name:   "not annotated source",
+			from:   "c.png",
+			to:     "d.png",
+			expect: map[string]*image.Annotation{"a.png": a, "b.png": b},
+		},
+		{
+			name:   "renamed",
+			from:   "a.png",
+			to:     "dir/a.png",
+			moved:  true,
+			expect: map[string]*image.Annotation{"a.png": nil, "b.png": b, "dir/a.png": a},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			moved, err := s.MoveAnnotation(c.from, c.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if moved != c.moved {
+				t.Errorf("expect %v, got %v", c.moved, moved)
+			}
+			for id, expect := range c.expect {
+				res, err := s.Annotation(id)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(res, expect) {
+					t.Errorf("%v: expect %v, got %v", id, expect, res)
+				}
+			}
+		})
+	}
+}
+
+func TestStoreRemoveImages(t *testing.T) {
+	s := openStore(t, filepath.Join(t.TempDir(), "userdata"))
+
+	for _, id := range []string{"a.png", "b.png"} {
+		if err := s.SetAnnotation(id, &image.Annotation{Favorite: true}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c, err := s.CreateCollection(&Collection{Name: "set", Images: []string{"a.png", "b.png"}, Cover: "a.png"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := s.CreateCollection(&Collection{Name: "other", Images: []string{"b.png"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = s.RemoveImages("a.png", "missing.png"); err != nil {
+		t.Fatal(err)
+	}
+
+	if a, err := s.Annotation("a.png"); err != nil || a != nil {
+		t.Errorf("expect the annotation is removed, got %v (%v)", a, err)
+	}
+	if a, err := s.Annotation("b.png"); err != nil || a == nil {
+		t.Errorf("expect the annotation is kept, got %v (%v)", a, err)
+	}
+	res, err := s.Collection(c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res.Images, []string{"b.png"}) || res.Cover != "" {
+		t.Errorf("expect the image is removed from the collection, got %v with cover %q", res.Images, res.Cover)
+	}
+	if res, err = s.Collection(other.ID); err != nil {
+		t.Fatal(err)
+	} else if !res.Updated.Equal(other.Updated) {
+		t.Errorf("expect the other collection isn't updated, got %v", res.Updated)
+	}
+}